@@ -7,15 +7,23 @@ import (
 )
 
 const (
-	envPrefix           = "CRONMAN"
-	keyPort             = "PORT"
-	keyDSN              = "DSN"
-	keyMigrations       = "MIGRATIONS"
-	keyRedisAddr        = "REDIS_ADDR"
-	keyRedisPassword    = "REDIS_PASSWORD"
-	keyDirectorEnabled  = "DIRECTOR_ENABLED"
-	keyHTTPReadTimeout  = "HTTP_READ_TIMEOUT"
-	keyHTTPWriteTimeout = "HTTP_WRITE_TIMEOUT"
+	envPrefix            = "CRONMAN"
+	keyPort              = "PORT"
+	keyDSN               = "DSN"
+	keyMigrations        = "MIGRATIONS"
+	keyRedisAddr         = "REDIS_ADDR"
+	keyRedisPassword     = "REDIS_PASSWORD"
+	keyDirectorEnabled   = "DIRECTOR_ENABLED"
+	keyHTTPReadTimeout   = "HTTP_READ_TIMEOUT"
+	keyHTTPWriteTimeout  = "HTTP_WRITE_TIMEOUT"
+	keySaveInterval      = "SAVE_INTERVAL"
+	keyJobPollInterval   = "JOB_POLL_INTERVAL"
+	keyReconcileEnabled  = "RECONCILE_ENABLED"
+	keyReconcileInterval = "RECONCILE_INTERVAL"
+	keyReconcileDryRun   = "RECONCILE_DRY_RUN"
+
+	keyAdminReconcileInterval = "ADMIN_RECONCILE_INTERVAL"
+	keyCatchUpLookback        = "CATCH_UP_LOOKBACK"
 )
 
 var global *config
@@ -43,6 +51,13 @@ func (c *config) loadDefaults() {
 	c.viper.SetDefault(keyDirectorEnabled, false)
 	c.viper.SetDefault(keyHTTPReadTimeout, 500*time.Millisecond)
 	c.viper.SetDefault(keyHTTPWriteTimeout, 30*time.Minute)
+	c.viper.SetDefault(keySaveInterval, 30*time.Minute)
+	c.viper.SetDefault(keyJobPollInterval, 5*time.Second)
+	c.viper.SetDefault(keyReconcileEnabled, false)
+	c.viper.SetDefault(keyReconcileInterval, time.Hour)
+	c.viper.SetDefault(keyReconcileDryRun, true)
+	c.viper.SetDefault(keyAdminReconcileInterval, 10*time.Minute)
+	c.viper.SetDefault(keyCatchUpLookback, 6*time.Hour)
 }
 
 func Port() int {
@@ -76,3 +91,31 @@ func HTTPReadTimeout() time.Duration {
 func HTTPWriteTimeout() time.Duration {
 	return global.viper.GetDuration(keyHTTPWriteTimeout)
 }
+
+func SaveInterval() time.Duration {
+	return global.viper.GetDuration(keySaveInterval)
+}
+
+func JobPollInterval() time.Duration {
+	return global.viper.GetDuration(keyJobPollInterval)
+}
+
+func ReconcileEnabled() bool {
+	return global.viper.GetBool(keyReconcileEnabled)
+}
+
+func ReconcileInterval() time.Duration {
+	return global.viper.GetDuration(keyReconcileInterval)
+}
+
+func ReconcileDryRun() bool {
+	return global.viper.GetBool(keyReconcileDryRun)
+}
+
+func AdminReconcileInterval() time.Duration {
+	return global.viper.GetDuration(keyAdminReconcileInterval)
+}
+
+func CatchUpLookback() time.Duration {
+	return global.viper.GetDuration(keyCatchUpLookback)
+}
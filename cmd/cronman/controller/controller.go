@@ -10,15 +10,19 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tjper/rustcron/cmd/cronman/db"
 	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/logger"
+	"github.com/tjper/rustcron/cmd/cronman/mapgen"
 	"github.com/tjper/rustcron/cmd/cronman/model"
 	"github.com/tjper/rustcron/cmd/cronman/rcon"
+	"github.com/tjper/rustcron/cmd/cronman/server"
 	"github.com/tjper/rustcron/cmd/cronman/userdata"
 	"github.com/tjper/rustcron/internal/event"
+	"github.com/tjper/rustcron/internal/session"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -26,36 +30,75 @@ import (
 
 // CreateServer instruct the Controller to create the server based on the input
 // specified. On success, the server has been created and is in a dormant
-// state.
+// state. If the server's instance fails to be created, the server is left
+// dormant and failed.
 func (ctrl Controller) CreateServer(
 	ctx context.Context,
 	input model.Server,
 ) (*model.DormantServer, error) {
-	instance, err := ctrl.serverDirector.Region(input.Region).CreateInstance(
-		ctx,
-		input.InstanceKind,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating instance; %w", err)
+	// The server's ID is assigned prior to its creation so that the creation
+	// may be audited.
+	if input.ID == uuid.Nil {
+		input.ID = uuid.New()
 	}
 
-	input.InstanceID = *instance.Instance.InstanceId
-	input.AllocationID = *instance.Address.AllocationId
-	input.ElasticIP = *instance.Address.PublicIp
+	if err := ctrl.audit(ctx, input.ID, model.AuditActionCreateServer, func() error {
+		plugins, err := db.ListDefaultPlugins(ctx, ctrl.store)
+		if err != nil {
+			return fmt.Errorf("while listing default plugins: %w", err)
+		}
+		for _, plugin := range plugins {
+			if input.Plugins.Enabled(plugin.ID) {
+				continue
+			}
+			input.Plugins = append(input.Plugins, model.ServerPlugin{PluginID: plugin.ID})
+		}
 
-	dormant := &model.DormantServer{
-		Server: input,
-	}
+		// The server is recorded prior to its instance being created so that
+		// an interrupted creation may be recovered.
+		input.Lifecycle = model.LifecycleCreating
+		dormant := &model.DormantServer{
+			Server: input,
+		}
 
-	if err := ctrl.store.WithContext(ctx).Create(&dormant).Error; err != nil {
-		return nil, fmt.Errorf("while creating dormant server: %w", err)
+		if err := ctrl.store.WithContext(ctx).Create(&dormant).Error; err != nil {
+			return fmt.Errorf("while creating dormant server: %w", err)
+		}
+
+		return ctrl.settle(dormant.Server.ID, ctrl.createInstance(ctx, dormant.Server))
+	}); err != nil {
+		return nil, err
 	}
 
 	if err := ctrl.notifier.Notify(ctx); err != nil {
 		return nil, fmt.Errorf("while notifying director: %w", err)
 	}
 
-	return dormant, nil
+	return db.GetDormantServer(ctx, ctrl.store, input.ID)
+}
+
+// createInstance creates the server's instance and records it.
+func (ctrl Controller) createInstance(ctx context.Context, server model.Server) error {
+	instance, err := ctrl.serverDirector.Region(server.Region).CreateInstance(
+		ctx,
+		server.InstanceKind,
+		server.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("creating instance; %w", err)
+	}
+
+	if err := ctrl.store.
+		WithContext(ctx).
+		Model(&server).
+		Updates(map[string]interface{}{
+			"instance_id":   *instance.Instance.InstanceId,
+			"allocation_id": *instance.Address.AllocationId,
+			"elastic_ip":    *instance.Address.PublicIp,
+		}).Error; err != nil {
+		return fmt.Errorf("while recording server instance: %w", err)
+	}
+	return nil
 }
 
 // GetServer retrieves the server from the underlyig store. The returned
@@ -95,8 +138,12 @@ func (ctrl Controller) UpdateServer(
 	ctx context.Context,
 	input UpdateServerInput,
 ) (*model.DormantServer, error) {
-	dormant, err := db.UpdateServer(ctx, ctrl.store, input.ID, input.Changes)
-	if err != nil {
+	var dormant *model.DormantServer
+	if err := ctrl.audit(ctx, input.ID, model.AuditActionUpdateServer, func() error {
+		var err error
+		dormant, err = db.UpdateServer(ctx, ctrl.store, input.ID, input.Changes)
+		return err
+	}); err != nil {
 		return nil, fmt.Errorf("update server; %w", err)
 	}
 
@@ -114,8 +161,12 @@ func (ctrl Controller) ArchiveServer(
 	ctx context.Context,
 	id uuid.UUID,
 ) (*model.ArchivedServer, error) {
-	server, err := db.MakeServerArchived(ctx, ctrl.store, id)
-	if err != nil {
+	var server *model.ArchivedServer
+	if err := ctrl.audit(ctx, id, model.AuditActionArchiveServer, func() error {
+		var err error
+		server, err = db.MakeServerArchived(ctx, ctrl.store, id)
+		return err
+	}); err != nil {
 		return nil, err
 	}
 
@@ -125,6 +176,101 @@ func (ctrl Controller) ArchiveServer(
 	return server, nil
 }
 
+// DecommissionServer releases the cloud resources of the archived server
+// specified by id, and marks the server decommissioned. Each resource is
+// forgotten once released, an interrupted decommission may be retried.
+func (ctrl Controller) DecommissionServer(
+	ctx context.Context,
+	id uuid.UUID,
+) (*model.ArchivedServer, error) {
+	archived, err := db.GetArchivedServer(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+	if archived.IsDecommissioned() {
+		return archived, nil
+	}
+
+	if err := ctrl.audit(ctx, id, model.AuditActionDecommissionServer, func() error {
+		return ctrl.transition(ctx, id, model.LifecycleDecommissioning, func() error {
+			return ctrl.decommission(ctx, archived.Server)
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return db.GetArchivedServer(ctx, ctrl.store, id)
+}
+
+// decommission releases the server's instance and address, and marks the
+// archived server decommissioned. Resources that no longer exist are treated
+// as released.
+func (ctrl Controller) decommission(ctx context.Context, archived model.Server) error {
+	manager := ctrl.serverDirector.Region(archived.Region)
+
+	if archived.InstanceID != "" {
+		err := manager.DeleteInstance(ctx, archived.InstanceID)
+		if err != nil && !errors.Is(err, server.ErrInstanceDNE) {
+			return fmt.Errorf("while deleting instance: %w", err)
+		}
+		if err := db.ClearServerInstance(ctx, ctrl.store, archived.ID); err != nil {
+			return err
+		}
+	}
+
+	if archived.AllocationID != "" {
+		err := manager.ReleaseAddress(ctx, archived.AllocationID)
+		if err != nil && !errors.Is(err, server.ErrAddressDNE) {
+			return fmt.Errorf("while releasing address: %w", err)
+		}
+		if err := db.ClearServerAddress(ctx, ctrl.store, archived.ID); err != nil {
+			return err
+		}
+	}
+
+	return db.DecommissionArchivedServer(ctx, ctrl.store, archived.ID)
+}
+
+// UnarchiveServer returns the archived server specified by id to dormant. If
+// the server has been decommissioned, an instance is provisioned first.
+// Unarchiving a server that has already been returned to dormant is a no-op.
+func (ctrl Controller) UnarchiveServer(
+	ctx context.Context,
+	id uuid.UUID,
+) (*model.DormantServer, error) {
+	archived, err := db.GetArchivedServer(ctx, ctrl.store, id)
+	if errors.Is(err, ierrors.ErrServerNotArchived) {
+		return db.GetDormantServer(ctx, ctrl.store, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctrl.audit(ctx, id, model.AuditActionUnarchiveServer, func() error {
+		return ctrl.transition(ctx, id, model.LifecycleUnarchiving, func() error {
+			if archived.Server.InstanceID == "" || archived.Server.AllocationID == "" {
+				// Release whatever remains of a partial decommission, prior to
+				// provisioning a new instance and address.
+				if err := ctrl.decommission(ctx, archived.Server); err != nil {
+					return err
+				}
+				if err := ctrl.createInstance(ctx, archived.Server); err != nil {
+					return err
+				}
+			}
+			_, err := db.MakeServerDormantFromArchived(ctx, ctrl.store, id)
+			return err
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := ctrl.notifier.Notify(ctx); err != nil {
+		return nil, fmt.Errorf("while notifying director: %w", err)
+	}
+
+	return db.GetDormantServer(ctx, ctrl.store, id)
+}
+
 // StartServer instructs the Controller start the server specified by id. Once
 // the method returns successfully, the server has been updated, and is
 // running, but has not yet been exposed to users.
@@ -132,25 +278,46 @@ func (ctrl Controller) StartServer(
 	ctx context.Context,
 	id uuid.UUID,
 ) (*model.DormantServer, error) {
-	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+	if err := ctrl.audit(ctx, id, model.AuditActionStartServer, func() error {
+		dormant, err := db.GetDormantServer(ctx, ctrl.store, id)
+		if err != nil {
+			return fmt.Errorf("while retrieving dormant server to start: %w", err)
+		}
+
+		return ctrl.transition(ctx, id, model.LifecycleStarting, func() error {
+			return ctrl.startServer(ctx, dormant.Server)
+		})
+	}); err != nil {
+		return nil, err
+	}
 
 	dormant, err := db.GetDormantServer(ctx, ctrl.store, id)
 	if err != nil {
-		return nil, fmt.Errorf("while retrieving dormant server to start: %w", err)
+		return nil, fmt.Errorf("while retrieving started dormant server: %w", err)
 	}
 
-	server := dormant.Server
+	return dormant, nil
+}
+
+// startServer starts the server's instance, applying the server's current
+// wipe if it has not yet been applied.
+func (ctrl Controller) startServer(ctx context.Context, server model.Server) error {
+	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+
 	options := []userdata.Option{
 		userdata.WithCloudWatchAgent(),
-		userdata.WithQueueBypassPlugin(),
-		userdata.WithVanishPlugin(),
-		userdata.WithAdminRadarPlugin(),
+		userdata.WithPlugins(server.Plugins.Userdata()),
 		userdata.WithUserCfg(
 			server.ID.String(),
 			server.Owners.SteamIDs(),
 			server.Moderators.SteamIDs(),
 		),
-		userdata.WithServerCfg(server.ID.String(), server.Vips.Active().SteamIDs()),
+		userdata.WithServerCfg(
+			server.ID.String(),
+			server.Vips.Active().SteamIDs(),
+			server.Plugins.Userdata(),
+		),
+		userdata.WithBansCfg(server.ID.String(), server.Bans.Userdata()),
 	}
 
 	wipe := server.Wipes.CurrentWipe()
@@ -169,7 +336,11 @@ func (ctrl Controller) StartServer(
 		server.InstanceID,
 		server.Userdata(options...),
 	); err != nil {
-		return nil, fmt.Errorf("start server instance; %w", err)
+		return fmt.Errorf("start server instance; %w", err)
+	}
+	startedAt := ctrl.time.Now()
+	if err := db.UpdateServerInstanceStartedAt(ctx, ctrl.store, server.ID, &startedAt); err != nil {
+		return fmt.Errorf("record server instance started; %w", err)
 	}
 
 	association, err := ctrl.serverDirector.Region(server.Region).MakeInstanceAvailable(
@@ -178,7 +349,7 @@ func (ctrl Controller) StartServer(
 		server.AllocationID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("unable to make server instance available; %w", err)
+		return fmt.Errorf("unable to make server instance available; %w", err)
 	}
 	defer func() {
 		if err := ctrl.serverDirector.Region(server.Region).MakeInstanceUnavailable(
@@ -194,23 +365,18 @@ func (ctrl Controller) StartServer(
 		server.ElasticIP,
 		server.RconPassword,
 	); err != nil {
-		return nil, fmt.Errorf("unable to ping server instance; %w", err)
+		return fmt.Errorf("unable to ping server instance; %w", err)
 	}
 
 	// Assumes wipe is being applied as part of the userdata to the StartInstance
 	// call.
 	if !wipe.AppliedAt.Valid {
 		if err := db.ApplyWipe(ctx, ctrl.store, wipe.ID); err != nil {
-			return nil, fmt.Errorf("while updating server wipe: %w", err)
+			return fmt.Errorf("while updating server wipe: %w", err)
 		}
 	}
 
-	dormant, err = db.GetDormantServer(ctx, ctrl.store, id)
-	if err != nil {
-		return nil, fmt.Errorf("while retrieving started dormant server: %w", err)
-	}
-
-	return dormant, nil
+	return nil
 }
 
 // MakeServerLive instructs the Controller to make the server specified by the id
@@ -219,54 +385,85 @@ func (ctrl Controller) MakeServerLive(
 	ctx context.Context,
 	id uuid.UUID,
 ) (*model.LiveServer, error) {
-	server, err := db.GetDormantServer(ctx, ctrl.store, id)
-	if err != nil {
-		return nil, fmt.Errorf("get dormant server; %w", err)
+	if err := ctrl.audit(ctx, id, model.AuditActionMakeServerLive, func() error {
+		server, err := db.GetDormantServer(ctx, ctrl.store, id)
+		if err != nil {
+			return fmt.Errorf("get dormant server; %w", err)
+		}
+
+		return ctrl.transition(ctx, id, model.LifecycleGoingLive, func() error {
+			return ctrl.makeServerLive(ctx, server.Server)
+		})
+	}); err != nil {
+		return nil, err
 	}
 
-	instance, err := ctrl.serverDirector.Region(server.Server.Region).MakeInstanceAvailable(
+	return db.GetLiveServer(ctx, ctrl.store, id)
+}
+
+// makeServerLive exposes the server's instance to players and moves the
+// server to the live state.
+func (ctrl Controller) makeServerLive(ctx context.Context, server model.Server) error {
+	instance, err := ctrl.serverDirector.Region(server.Region).MakeInstanceAvailable(
 		ctx,
-		server.Server.InstanceID,
-		server.Server.AllocationID,
+		server.InstanceID,
+		server.AllocationID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("make server instance available; %w", err)
+		return fmt.Errorf("make server instance available; %w", err)
 	}
 	if err := ctrl.pingUntilReady(
 		ctx,
-		server.Server.ElasticIP,
-		server.Server.RconPassword,
+		server.ElasticIP,
+		server.RconPassword,
 	); err != nil {
-		return nil, fmt.Errorf("ping until ready; %w", err)
+		return fmt.Errorf("ping until ready; %w", err)
 	}
 
-	liveServer, err := db.MakeServerLive(
+	if _, err := db.MakeServerLive(
 		ctx,
 		ctrl.store,
 		db.MakeServerLiveInput{
-			ID:            id,
+			ID:            server.ID,
 			AssociationID: *instance.AssociationId,
 		},
-	)
+	); err != nil {
+		return fmt.Errorf("make server live; %w", err)
+	}
 
-	liveEvent := event.NewServerStatusChangeEvent(id, event.WithStatusChange(event.Live))
+	liveEvent := event.NewServerStatusChangeEvent(server.ID, event.WithStatusChange(event.Live))
 	if err := ctrl.writeToEventStream(ctx, &liveEvent); err != nil {
-		return nil, fmt.Errorf("while writing server live event: %w", err)
+		return fmt.Errorf("while writing server live event: %w", err)
 	}
 
-	return liveServer, err
+	return nil
 }
 
 // StopServer instructs the Controller stop the server specified by id. Once the
 // method returns successfully, the server has been stopped.
 func (ctrl *Controller) StopServer(ctx context.Context, id uuid.UUID) (*model.DormantServer, error) {
-	server, err := db.GetLiveServer(ctx, ctrl.store, id)
-	if err != nil {
+	if err := ctrl.audit(ctx, id, model.AuditActionStopServer, func() error {
+		server, err := db.GetLiveServer(ctx, ctrl.store, id)
+		if err != nil {
+			return err
+		}
+
+		return ctrl.transition(ctx, id, model.LifecycleStopping, func() error {
+			return ctrl.stopServer(ctx, *server)
+		})
+	}); err != nil {
 		return nil, err
 	}
-	dormantServer, err := db.MakeServerDormant(ctx, ctrl.store, id)
-	if err != nil {
-		return nil, err
+
+	return db.GetDormantServer(ctx, ctrl.store, id)
+}
+
+// stopServer saves and quits the live server, moves the server to the
+// dormant state, and stops the server's instance.
+func (ctrl *Controller) stopServer(ctx context.Context, server model.LiveServer) error {
+	id := server.Server.ID
+	if _, err := db.MakeServerDormant(ctx, ctrl.store, id); err != nil {
+		return err
 	}
 
 	client, err := ctrl.hub.Dial(
@@ -275,297 +472,1633 @@ func (ctrl *Controller) StopServer(ctx context.Context, id uuid.UUID) (*model.Do
 		server.Server.RconPassword,
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer client.Close()
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
+
+	// Save the world and flush the server's configuration before quitting.
+	// global.quit saves as well, failures here are logged rather than
+	// preventing the server from being stopped.
+	if err := ctrl.saveServer(ctx, id, client); err != nil {
+		ctrl.logger.Warn(
+			"while saving server prior to quit",
+			zap.Stringer("server-id", id),
+			zap.Error(err),
+		)
+	}
+	if err := client.WriteCfg(ctx); err != nil {
+		ctrl.logger.Warn(
+			"while writing server config prior to quit",
+			zap.Stringer("server-id", id),
+			zap.Error(err),
+		)
+	}
+
 	if err := client.Quit(ctx); err != nil {
-		return nil, err
+		return err
 	}
 
 	offlineEvent := event.NewServerStatusChangeEvent(id, event.WithStatusChange(event.Offline))
 	if err := ctrl.writeToEventStream(ctx, &offlineEvent); err != nil {
-		return nil, fmt.Errorf("while writing server offline event: %w", err)
+		return fmt.Errorf("while writing server offline event: %w", err)
 	}
 
 	if err := ctrl.serverDirector.Region(server.Server.Region).MakeInstanceUnavailable(
 		ctx,
 		server.AssociationID,
 	); err != nil {
-		return nil, err
+		return err
 	}
 	if err := ctrl.serverDirector.Region(server.Server.Region).StopInstance(
 		ctx,
 		server.Server.InstanceID,
 	); err != nil {
-		return nil, err
+		return err
 	}
-	return dormantServer, nil
+	return db.UpdateServerInstanceStartedAt(ctx, ctrl.store, id, nil)
 }
 
 // WipeServer wipes the specified server.
 func (ctrl *Controller) WipeServer(ctx context.Context, serverID uuid.UUID, wipe model.Wipe) error {
-	if err := db.WipeServer(ctx, ctrl.store, serverID, wipe); err != nil {
-		return fmt.Errorf("while wiping server: %w", err)
-	}
-	return nil
+	return ctrl.audit(ctx, serverID, model.AuditActionWipeServer, func() error {
+		return ctrl.transition(ctx, serverID, model.LifecycleWiping, func() error {
+			if err := db.WipeServer(ctx, ctrl.store, serverID, wipe); err != nil {
+				return fmt.Errorf("while wiping server: %w", err)
+			}
+			return nil
+		})
+	})
 }
 
-var errInvalidServerType = errors.New("invalid server type")
-
-// ListServers evaluates the dst and populates it with the related data. The
-// dst should be of type *model.LiveServer, *model.DormantServer,
-// *model.ArchivedServer.
-func (ctrl *Controller) ListServers(ctx context.Context, dst interface{}) error {
-	switch dst.(type) {
-	case *[]model.LiveServer:
-	case *[]model.DormantServer:
-	case *[]model.ArchivedServer:
-		break
-	default:
-		return errInvalidServerType
-	}
+// DirectEvent performs the actions of the event's occurrence scheduled at the
+// time specified, and records the Execution. The Execution is returned along
+// with the error the actions failed with, if any.
+func (ctrl *Controller) DirectEvent(
+	ctx context.Context,
+	event model.Event,
+	scheduledAt time.Time,
+) (*model.Execution, error) {
+	return ctrl.execute(ctx, event, scheduledAt, nil, func() error {
+		return ctrl.directEvent(ctx, event)
+	})
+}
 
-	if err := db.ListServers(ctx, ctrl.store, dst); err != nil {
-		return err
+// RetryExecution re-runs the event of the failed Execution specified by id.
+// The retry is recorded as a new Execution, which is returned along with the
+// error the retry failed with, if any. If the Execution has not failed, or
+// already has a retry that is running or succeeded, an error wrapping
+// ErrExecutionNotFailed is returned and the event is not re-run.
+func (ctrl *Controller) RetryExecution(ctx context.Context, id uuid.UUID) (*model.Execution, error) {
+	failed, err := db.GetExecution(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	event := failed.Event()
+	return ctrl.execute(ctx, event, failed.ScheduledAt, &failed.ID, func() error {
+		return ctrl.directEvent(ctx, event)
+	})
 }
 
-func (ctrl *Controller) AddServerTags(
+// execute executes fn, and records an Execution of the event's occurrence
+// scheduled at the time specified. retryOf is the ID of the failed Execution
+// being retried, if any. The err returned by fn is returned. Failing to record
+// the Execution is logged rather than failing fn, unless the Execution is a
+// retry; a retry is only run once it is recorded, as recording it checks the
+// retried Execution may be retried.
+func (ctrl *Controller) execute(
 	ctx context.Context,
-	serverID uuid.UUID,
-	tags model.Tags,
-) error {
-	if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+	event model.Event,
+	scheduledAt time.Time,
+	retryOf *uuid.UUID,
+	fn func() error,
+) (*model.Execution, error) {
+	execution := model.NewExecution(event, scheduledAt, actor(ctx), ctrl.time.Now())
+	execution.RetryOf = retryOf
+
+	created := true
+	if err := db.CreateExecution(ctx, ctrl.store, execution); err != nil {
+		if retryOf != nil {
+			return nil, err
+		}
+		created = false
+		ctrl.logger.Error(
+			"while recording execution",
+			zap.Stringer("event-id", event.ID),
+			zap.Stringer("server-id", event.ServerID),
+			zap.Error(err),
+		)
 	}
 
-	for i := range tags {
-		tags[i].ServerID = serverID
+	err := fn()
+	execution.Finish(ctrl.time.Now(), err)
+	if !created {
+		return execution, err
 	}
 
-	if err := ctrl.store.WithContext(ctx).Create(tags).Error; err != nil {
-		return fmt.Errorf("create server tags; serverID: %s, error: %w", serverID, err)
-	}
-	return nil
-}
+	// The outcome is recorded even if the execution was interrupted by its
+	// context.
+	recordCtx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
 
-func (ctrl *Controller) RemoveServerTags(
-	ctx context.Context,
-	serverID uuid.UUID,
-	tagIDs []uuid.UUID,
-) error {
-	if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+	if ferr := db.FinishExecution(recordCtx, ctrl.store, *execution); ferr != nil {
+		ctrl.logger.Error(
+			"while recording execution outcome",
+			zap.Stringer("execution-id", execution.ID),
+			zap.Error(ferr),
+		)
 	}
+	return execution, err
+}
 
-	if err := ctrl.store.WithContext(ctx).Delete(&model.Tag{}, tagIDs).Error; err != nil {
-		return fmt.Errorf("delete server tags; serverID: %s, error: %w", serverID, err)
+// directEvent performs the actions of the event.
+func (ctrl *Controller) directEvent(ctx context.Context, event model.Event) error {
+	switch event.Kind {
+	case model.EventKindStart:
+		if _, err := ctrl.StartServer(ctx, event.ServerID); err != nil {
+			return fmt.Errorf("start server; id: %s, error: %w", event.ServerID, err)
+		}
+	case model.EventKindStop:
+		if _, err := ctrl.StopServer(ctx, event.ServerID); err != nil {
+			return fmt.Errorf("stop server; id: %s, error: %w", event.ServerID, err)
+		}
+	case model.EventKindLive:
+		if _, err := ctrl.MakeServerLive(ctx, event.ServerID); err != nil {
+			return fmt.Errorf("make server live; id: %s, error: %w", event.ServerID, err)
+		}
+	case model.EventKindMapWipe:
+		return ctrl.directWipe(ctx, event.ServerID, model.NewMapWipe)
+	case model.EventKindFullWipe:
+		return ctrl.directWipe(ctx, event.ServerID, model.NewFullWipe)
 	}
 	return nil
 }
 
-func (ctrl *Controller) AddServerEvents(
+// directWipe wipes the server specified by id with a newly generated map. A
+// live server is stopped prior to the wipe, and made live again afterwards; the
+// wipe fails if the server is not made live again.
+func (ctrl *Controller) directWipe(
 	ctx context.Context,
 	serverID uuid.UUID,
-	events model.Events,
-) error {
-	if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+	newWipe func(uint32, uint32) *model.Wipe,
+) (err error) {
+	serverI, err := ctrl.GetServer(ctx, serverID)
+	if err != nil {
+		return err
 	}
 
-	for i := range events {
-		events[i].ServerID = serverID
-	}
+	_, isLive := serverI.(*model.LiveServer)
 
-	if err := ctrl.store.WithContext(ctx).Create(events).Error; err != nil {
-		return fmt.Errorf("create server events; serverID: %s, error: %w", serverID, err)
+	if isLive {
+		if _, err := ctrl.StopServer(ctx, serverID); err != nil {
+			return err
+		}
+		// The wipe fails if the server is not restarted, a server left
+		// stopped is not a successful wipe.
+		defer func() {
+			rerr := ctrl.restartWipedServer(ctx, serverID)
+			if rerr == nil {
+				return
+			}
+			if err == nil {
+				err = rerr
+				return
+			}
+			err = fmt.Errorf("%w; %s", err, rerr)
+		}()
+	}
+
+	var mapSize model.MapSizeKind
+	switch server := serverI.(type) {
+	case *model.LiveServer:
+		mapSize = server.Server.MapSize
+	case *model.DormantServer:
+		mapSize = server.Server.MapSize
+	}
+
+	seed := mapgen.GenerateSeed(mapSize)
+	salt := mapgen.GenerateSalt()
+	wipe := newWipe(seed, salt)
+
+	if err := ctrl.WipeServer(ctx, serverID, *wipe); err != nil {
+		return fmt.Errorf("while wiping server: %w", err)
 	}
 	return nil
 }
 
-func (ctrl *Controller) RemoveServerEvents(
-	ctx context.Context,
-	serverID uuid.UUID,
-	eventIDs []uuid.UUID,
-) error {
-	if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+// restartWipedServer starts the server specified by serverID and makes it
+// live, the server having been stopped to be wiped.
+func (ctrl *Controller) restartWipedServer(ctx context.Context, serverID uuid.UUID) error {
+	if _, err := ctrl.StartServer(ctx, serverID); err != nil {
+		return fmt.Errorf("while restarting a wiped server: %w", err)
 	}
-
-	if err := ctrl.store.WithContext(ctx).Delete(&model.Event{}, eventIDs).Error; err != nil {
-		return fmt.Errorf("delete server events; serverID: %s, error: %w", serverID, err)
+	if _, err := ctrl.MakeServerLive(ctx, serverID); err != nil {
+		return fmt.Errorf("while making a wiped server live: %w", err)
 	}
 	return nil
 }
 
-func (ctrl *Controller) AddServerModerators(
-	ctx context.Context,
-	serverID uuid.UUID,
-	moderators model.Moderators,
-) error {
-	server, err := db.GetServer(ctx, ctrl.store, serverID)
+// RecoverServers settles each server left in a transitional lifecycle,
+// typically by a process that crashed while transitioning it. Servers that
+// were starting or going live are rolled back to a stopped dormant server,
+// servers that were stopping are stopped. Servers that cannot be recovered
+// are marked as failed. Transitions that are still running, in this or
+// another process, are left alone; see transitionStaleAfter.
+func (ctrl *Controller) RecoverServers(ctx context.Context) error {
+	servers, err := db.ListStaleServersByLifecycle(
+		ctx,
+		ctrl.store,
+		model.TransitionalLifecycles,
+		time.Now().Add(-transitionStaleAfter),
+	)
 	if err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		return fmt.Errorf("while listing transitioning servers: %w", err)
 	}
 
-	for i := range moderators {
-		moderators[i].ServerID = serverID
+	for _, server := range servers {
+		ctrl.logger.Info(
+			"recovering transitioning server",
+			zap.Stringer("server-id", server.ID),
+			zap.String("lifecycle", string(server.Lifecycle)),
+		)
+		server := server
+		_ = ctrl.audit(ctx, server.ID, model.AuditActionRecoverServer, func() error {
+			return ctrl.settle(server.ID, ctrl.recoverServer(ctx, server))
+		})
 	}
+	return nil
+}
 
-	if server.StateType == model.LiveServerState {
-		if err := ctrl.rconAddServerModerators(
-			ctx,
-			server.ElasticIP,
-			server.RconPassword,
-			moderators,
-		); err != nil {
-			return err
-		}
-	}
+// FailInterruptedExecutions fails the executions left running by a previous
+// director, typically one that crashed or lost the director lock while
+// executing an event. It is intended to be called once the director lock is
+// acquired, prior to executing events.
+func (ctrl *Controller) FailInterruptedExecutions(ctx context.Context) error {
+	return db.FailInterruptedExecutions(ctx, ctrl.store, model.ActorKindDirector, ctrl.time.Now())
+}
 
-	if err := ctrl.store.WithContext(ctx).Create(moderators).Error; err != nil {
-		return fmt.Errorf("create server moderators; serverID: %s, error: %w", serverID, err)
+// CatchUpServer drives the server specified by id to the state dictated by
+// its most recent start, live or stop event that occurred after since. Events
+// missed while no director was scheduling them are caught up this way, events
+// that occurred prior to since are not replayed. Wipes are not caught up.
+// Servers that are transitioning or have failed are left to be recovered.
+func (ctrl *Controller) CatchUpServer(ctx context.Context, id uuid.UUID, since time.Time) error {
+	server, err := db.GetServer(ctx, ctrl.store, id)
+	if err != nil {
+		return err
+	}
+	if server.StateType != model.LiveServerState && server.StateType != model.DormantServerState {
+		return nil
+	}
+	if server.Lifecycle != model.LifecycleIdle {
+		return nil
 	}
 
-	return nil
-}
-
-func (ctrl *Controller) RemoveServerModerators(
-	ctx context.Context,
-	serverID uuid.UUID,
-	moderatorIDs []uuid.UUID,
-) error {
-	server, err := db.GetServer(ctx, ctrl.store, serverID)
+	now := ctrl.time.Now()
+	occurrence, err := server.LastStateOccurrence(since, now)
 	if err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		return err
+	}
+	if occurrence == nil {
+		return nil
 	}
 
-	moderators := server.Moderators
+	isLive := server.IsLive()
+	running := isLive || server.InstanceStartedAt != nil
 
-	if server.StateType == model.LiveServerState {
-		if err := ctrl.rconRemoveServerModerators(
-			ctx,
-			server.ElasticIP,
-			server.RconPassword,
-			moderators,
-		); err != nil {
-			return err
+	logger := ctrl.logger.With(
+		zap.Stringer("server-id", id),
+		zap.Stringer("event-id", occurrence.Event.ID),
+		zap.String("event-kind", string(occurrence.Event.Kind)),
+		zap.Time("event-at", occurrence.At),
+	)
+	// Caught up actions are audited as performed by the director on behalf
+	// of the missed event.
+	ctx = model.WithActor(ctx, model.NewDirectorActor(occurrence.Event))
+
+	var catchUp func() error
+	switch occurrence.Event.Kind {
+	case model.EventKindStart:
+		if running {
+			return nil
 		}
+		logger.Info("catching up missed start event")
+		catchUp = func() error {
+			if _, err := ctrl.StartServer(ctx, id); err != nil {
+				return fmt.Errorf("while catching up start event: %w", err)
+			}
+			return nil
+		}
+	case model.EventKindLive:
+		if isLive {
+			return nil
+		}
+		logger.Info("catching up missed live event")
+		catchUp = func() error {
+			if !running {
+				if _, err := ctrl.StartServer(ctx, id); err != nil {
+					return fmt.Errorf("while catching up live event: %w", err)
+				}
+			}
+			if _, err := ctrl.MakeServerLive(ctx, id); err != nil {
+				return fmt.Errorf("while catching up live event: %w", err)
+			}
+			return nil
+		}
+	case model.EventKindStop:
+		if !isLive {
+			return nil
+		}
+		logger.Info("catching up missed stop event")
+		catchUp = func() error {
+			if _, err := ctrl.StopServer(ctx, id); err != nil {
+				return fmt.Errorf("while catching up stop event: %w", err)
+			}
+			return nil
+		}
+	default:
+		return nil
 	}
 
-	if err := ctrl.store.WithContext(ctx).Delete(&model.Moderator{}, moderatorIDs).Error; err != nil {
-		return fmt.Errorf("delete server moderators; serverID: %s, error: %w", serverID, err)
-	}
-
-	return nil
+	// The caught up occurrence is recorded as an execution of the missed
+	// event.
+	_, err = ctrl.execute(ctx, occurrence.Event, occurrence.At, nil, catchUp)
+	return err
 }
 
-func (ctrl *Controller) AddServerOwners(
-	ctx context.Context,
-	serverID uuid.UUID,
-	owners model.Owners,
-) error {
-	server, err := db.GetServer(ctx, ctrl.store, serverID)
+// RecoverServer settles the server specified by id if it has been left in a
+// transitional lifecycle. If the server's transition is still running, an
+// error wrapping ErrServerTransition is returned. See RecoverServers for more
+// details.
+func (ctrl *Controller) RecoverServer(ctx context.Context, id uuid.UUID) error {
+	server, err := db.GetServer(ctx, ctrl.store, id)
 	if err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		return err
 	}
-
-	for i := range owners {
-		owners[i].ServerID = serverID
+	if !server.Lifecycle.IsTransitional() {
+		return nil
+	}
+	if server.LifecycleHeartbeatAt != nil &&
+		time.Since(*server.LifecycleHeartbeatAt) < transitionStaleAfter {
+		return fmt.Errorf(
+			"%w; %s transition still running",
+			ierrors.ErrServerTransition,
+			server.Lifecycle,
+		)
 	}
 
-	if server.StateType == model.LiveServerState {
-		if err := ctrl.rconAddServerOwners(
-			ctx,
-			server.ElasticIP,
-			server.RconPassword,
-			owners,
-		); err != nil {
-			return err
+	ctrl.logger.Info(
+		"recovering transitioning server",
+		zap.Stringer("server-id", server.ID),
+		zap.String("lifecycle", string(server.Lifecycle)),
+	)
+	return ctrl.audit(ctx, server.ID, model.AuditActionRecoverServer, func() error {
+		return ctrl.settle(server.ID, ctrl.recoverServer(ctx, *server))
+	})
+}
+
+var errTransitionInterrupted = errors.New("server lifecycle transition interrupted")
+
+// recoverServer resumes or rolls back the interrupted transition of the
+// server.
+func (ctrl *Controller) recoverServer(ctx context.Context, server model.Server) error {
+	manager := ctrl.serverDirector.Region(server.Region)
+
+	switch server.Lifecycle {
+	case model.LifecycleCreating:
+		// Without a recorded instance, it is not known if an instance was
+		// created.
+		if server.InstanceID == "" {
+			return fmt.Errorf("%w while creating instance", errTransitionInterrupted)
+		}
+		return nil
+
+	case model.LifecycleStarting, model.LifecycleGoingLive:
+		// The server went live prior to being interrupted.
+		if server.IsLive() {
+			return nil
+		}
+		if err := manager.StopInstance(ctx, server.InstanceID); err != nil {
+			return fmt.Errorf("while stopping interrupted server instance: %w", err)
+		}
+		return db.UpdateServerInstanceStartedAt(ctx, ctrl.store, server.ID, nil)
+
+	case model.LifecycleStopping:
+		if server.IsLive() {
+			live, err := db.GetLiveServer(ctx, ctrl.store, server.ID)
+			if err != nil {
+				return err
+			}
+			if err := manager.MakeInstanceUnavailable(ctx, live.AssociationID); err != nil {
+				return fmt.Errorf("while making interrupted server instance unavailable: %w", err)
+			}
+			if _, err := db.MakeServerDormant(ctx, ctrl.store, server.ID); err != nil {
+				return err
+			}
+
+			offlineEvent := event.NewServerStatusChangeEvent(server.ID, event.WithStatusChange(event.Offline))
+			if err := ctrl.writeToEventStream(ctx, &offlineEvent); err != nil {
+				return fmt.Errorf("while writing server offline event: %w", err)
+			}
+		}
+		if err := manager.StopInstance(ctx, server.InstanceID); err != nil {
+			return fmt.Errorf("while stopping interrupted server instance: %w", err)
+		}
+		return db.UpdateServerInstanceStartedAt(ctx, ctrl.store, server.ID, nil)
+
+	case model.LifecycleWiping:
+		// Wipes are created within a single transaction, there is nothing to
+		// resume or roll back.
+		return nil
+
+	case model.LifecycleDecommissioning, model.LifecycleUnarchiving:
+		// Each step forgets the resources it releases and records those it
+		// provisions, the operation may be retried. A provisioned instance
+		// that was not recorded is released by the reconciler.
+		return nil
+	}
+
+	return fmt.Errorf("%w; lifecycle: %s", errTransitionInterrupted, server.Lifecycle)
+}
+
+// transition moves the server specified by id into the transitional
+// lifecycle specified while fn executes. Once fn returns, the server is
+// settled. If the server may not transition, an error wrapping
+// ErrServerTransition is returned and fn is not executed. If ctx carries a
+// fencing token, the transition is fenced by it; see db.TransitionServer.
+func (ctrl Controller) transition(
+	ctx context.Context,
+	id uuid.UUID,
+	lifecycle model.Lifecycle,
+	fn func() error,
+) error {
+	token, _ := model.FencingTokenFromContext(ctx)
+	if err := db.TransitionServer(ctx, ctrl.store, id, lifecycle, "", token); err != nil {
+		return err
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.heartbeatTransition(heartbeatCtx, id, lifecycle)
+	}()
+
+	err := fn()
+	stopHeartbeat()
+	<-done
+	return ctrl.settle(id, err)
+}
+
+const (
+	// transitionHeartbeatInterval is the interval at which a running
+	// transition indicates it is still running.
+	transitionHeartbeatInterval = 30 * time.Second
+
+	// transitionStaleAfter is the duration after which a transition without
+	// a heartbeat is considered interrupted, and may be recovered.
+	transitionStaleAfter = 4 * transitionHeartbeatInterval
+)
+
+// heartbeatTransition updates the transition heartbeat of the server
+// specified by id until the context is cancelled.
+func (ctrl Controller) heartbeatTransition(ctx context.Context, id uuid.UUID, lifecycle model.Lifecycle) {
+	ticker := time.NewTicker(transitionHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := db.HeartbeatServerTransition(ctx, ctrl.store, id, lifecycle); err != nil {
+				ctrl.logger.Error(
+					"while updating server transition heartbeat",
+					zap.Stringer("server-id", id),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// settleTimeout is the maximum duration settling a server's lifecycle may
+// take.
+const settleTimeout = 10 * time.Second
+
+// settle settles the server specified by id after a transition. A nil err
+// settles the server as idle, otherwise the server is marked as failed with
+// err as the reason. The err passed is returned.
+//
+// A transition cancelled by its context, typically due to the process
+// shutting down, has been interrupted rather than failed. The server is left
+// transitioning to be recovered by RecoverServers or RecoverServer. The
+// server is also left transitioning if it fails to settle.
+func (ctrl Controller) settle(id uuid.UUID, err error) error {
+	if errors.Is(err, context.Canceled) {
+		ctrl.logger.Warn(
+			"server lifecycle transition interrupted",
+			zap.Stringer("server-id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+
+	to, reason := model.LifecycleIdle, ""
+	if err != nil {
+		to, reason = model.LifecycleFailed, err.Error()
+	}
+
+	if serr := db.TransitionServer(ctx, ctrl.store, id, to, reason, 0); serr != nil {
+		ctrl.logger.Error(
+			"while settling server lifecycle",
+			zap.Stringer("server-id", id),
+			zap.String("lifecycle", string(to)),
+			zap.Error(serr),
+		)
+	}
+	return err
+}
+
+// auditTimeout is the maximum duration recording an audit entry may take.
+const auditTimeout = 10 * time.Second
+
+// audit executes fn, and records an audit entry describing the action fn
+// performs on the server specified by id. The entry records the actor
+// performing the action, the changes fn made to the server, and the outcome
+// of fn. The err returned by fn is returned. Failing to record the entry is
+// logged rather than failing the action.
+func (ctrl Controller) audit(
+	ctx context.Context,
+	id uuid.UUID,
+	action model.AuditAction,
+	fn func() error,
+) error {
+	before := ctrl.auditSnapshot(ctx, id)
+	err := fn()
+
+	// The entry is recorded even if the action was interrupted by its
+	// context.
+	recordCtx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
+
+	after := ctrl.auditSnapshot(recordCtx, id)
+	ctrl.recordAuditEntry(
+		recordCtx,
+		model.NewAuditEntry(id, actor(ctx), action, before.Diff(after), err),
+	)
+	return err
+}
+
+// recordAuditEntry records the audit entry. Failing to record the entry is
+// logged.
+func (ctrl Controller) recordAuditEntry(ctx context.Context, entry *model.AuditEntry) {
+	if err := db.CreateAuditEntry(ctx, ctrl.store, entry); err != nil {
+		ctrl.logger.Error(
+			"while recording audit entry",
+			zap.Stringer("server-id", entry.ServerID),
+			zap.String("action", string(entry.Action)),
+			zap.Error(err),
+		)
+	}
+}
+
+// auditSnapshot captures the audited attributes of the server specified by
+// id. If the server does not exist, or may not be captured, nil is returned.
+func (ctrl Controller) auditSnapshot(ctx context.Context, id uuid.UUID) model.AuditSnapshot {
+	server, err := db.GetServer(ctx, ctrl.store, id)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		return nil
+	}
+	if err != nil {
+		ctrl.logger.Warn("while retrieving server to audit", zap.Stringer("server-id", id), zap.Error(err))
+		return nil
+	}
+
+	snapshot, err := server.AuditSnapshot()
+	if err != nil {
+		ctrl.logger.Warn("while capturing server audit snapshot", zap.Stringer("server-id", id), zap.Error(err))
+		return nil
+	}
+	return snapshot
+}
+
+// actor determines the actor performing actions with ctx. An actor carried by
+// ctx takes precedence over the session user. Actions without either are
+// performed by the system. The fencing token carried by ctx, if any, is
+// recorded on the actor.
+func actor(ctx context.Context) model.Actor {
+	actor, ok := model.ActorFromContext(ctx)
+	if !ok {
+		actor = model.SystemActor
+		if sess, ok := session.FromContext(ctx); ok {
+			actor = model.NewUserActor(sess.User.ID, sess.User.Email)
 		}
 	}
+	if token, ok := model.FencingTokenFromContext(ctx); ok {
+		actor.FencingToken = token
+	}
+	return actor
+}
+
+type ListServerHistoryInput struct {
+	ServerID uuid.UUID
+	// Actions limits the history to the specified actions. If empty, every
+	// action is listed.
+	Actions []model.AuditAction
+	Limit   int
+	Offset  int
+}
+
+// ListServerHistory lists the audit history of the server specified by
+// input.ServerID, most recent first.
+func (ctrl Controller) ListServerHistory(
+	ctx context.Context,
+	input ListServerHistoryInput,
+) ([]model.AuditEntry, error) {
+	if _, err := db.GetServer(ctx, ctrl.store, input.ServerID); err != nil {
+		return nil, err
+	}
+	return db.ListAuditEntries(ctx, ctrl.store, db.ListAuditEntriesInput{
+		ServerID: input.ServerID,
+		Actions:  input.Actions,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+	})
+}
+
+type ListExecutionsInput struct {
+	// ServerID limits the executions to those of the specified server. If
+	// uuid.Nil, executions of every server are listed.
+	ServerID uuid.UUID
+	// Kinds limits the executions to those of events of the specified kinds.
+	// If empty, executions of every kind are listed.
+	Kinds []model.EventKind
+	// Outcome limits the executions to those with the specified outcome. If
+	// empty, executions of every outcome are listed.
+	Outcome model.ExecutionOutcome
+	Limit   int
+	Offset  int
+}
 
-	if err := ctrl.store.WithContext(ctx).Create(owners).Error; err != nil {
-		return fmt.Errorf("create server owners; serverID: %s, error: %w", serverID, err)
+// ListServerExecutions lists the event executions of the server specified by
+// input.ServerID, most recent first.
+func (ctrl Controller) ListServerExecutions(
+	ctx context.Context,
+	input ListExecutionsInput,
+) ([]model.Execution, error) {
+	if _, err := db.GetServer(ctx, ctrl.store, input.ServerID); err != nil {
+		return nil, err
+	}
+	return ctrl.ListExecutions(ctx, input)
+}
+
+// ListExecutions lists event executions, most recent first.
+func (ctrl Controller) ListExecutions(
+	ctx context.Context,
+	input ListExecutionsInput,
+) ([]model.Execution, error) {
+	return db.ListExecutions(ctx, ctrl.store, db.ListExecutionsInput{
+		ServerID: input.ServerID,
+		Kinds:    input.Kinds,
+		Outcome:  input.Outcome,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+	})
+}
+
+// CreateServerJob enqueues a Job that creates the server based on the input
+// specified. See CreateServer for more details.
+func (ctrl Controller) CreateServerJob(ctx context.Context, input model.Server) (*model.Job, error) {
+	job, err := model.NewCreateServerJob(input)
+	if err != nil {
+		return nil, err
+	}
+	return ctrl.enqueueJob(ctx, job)
+}
+
+// StartServerJob enqueues a Job that starts the server specified by id and
+// makes it live.
+func (ctrl Controller) StartServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	return ctrl.enqueueJob(ctx, model.NewStartServerJob(id))
+}
+
+// StopServerJob enqueues a Job that stops the server specified by id.
+func (ctrl Controller) StopServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	return ctrl.enqueueJob(ctx, model.NewStopServerJob(id))
+}
+
+// WipeServerJob enqueues a Job that stops the live server specified by id,
+// applies the wipe, and makes the server live again.
+func (ctrl Controller) WipeServerJob(
+	ctx context.Context,
+	id uuid.UUID,
+	wipe model.Wipe,
+) (*model.Job, error) {
+	job, err := model.NewWipeServerJob(id, wipe)
+	if err != nil {
+		return nil, err
+	}
+	return ctrl.enqueueJob(ctx, job)
+}
+
+// DecommissionServerJob enqueues a Job that decommissions the archived server
+// specified by id. See DecommissionServer for more details.
+func (ctrl Controller) DecommissionServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	archived, err := db.GetArchivedServer(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+	if archived.Server.Lifecycle.IsTransitional() {
+		return nil, ierrors.ErrServerTransition
+	}
+	return ctrl.enqueueJob(ctx, model.NewDecommissionServerJob(id))
+}
+
+// UnarchiveServerJob enqueues a Job that returns the archived server
+// specified by id to dormant. See UnarchiveServer for more details.
+func (ctrl Controller) UnarchiveServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	archived, err := db.GetArchivedServer(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+	if archived.Server.Lifecycle.IsTransitional() {
+		return nil, ierrors.ErrServerTransition
+	}
+	return ctrl.enqueueJob(ctx, model.NewUnarchiveServerJob(id))
+}
+
+// RetryExecutionJob enqueues a Job that retries the failed Execution
+// specified by id. If the Execution has not failed, or has a retry that is
+// pending, running or succeeded, an error wrapping ErrExecutionNotFailed is
+// returned. See RetryExecution for more details.
+func (ctrl Controller) RetryExecutionJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	execution, err := db.GetExecution(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := model.NewRetryExecutionJob(*execution)
+	if err != nil {
+		return nil, err
+	}
+	job.Actor = actor(ctx)
+
+	// The execution is checked to be retryable as the job is created, so
+	// concurrent requests do not enqueue multiple retries.
+	if err := db.CreateRetryExecutionJob(ctx, ctrl.store, job, id); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob retrieves the Job specified by id.
+func (ctrl Controller) GetJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	return db.GetJob(ctx, ctrl.store, id)
+}
+
+func (ctrl Controller) enqueueJob(ctx context.Context, job *model.Job) (*model.Job, error) {
+	job.Actor = actor(ctx)
+	if err := db.CreateJob(ctx, ctrl.store, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetServerConfig retrieves the configuration of the server specified by id.
+// The configuration may be used to create a clone of the server.
+func (ctrl Controller) GetServerConfig(ctx context.Context, id uuid.UUID) (*model.ServerConfig, error) {
+	server, err := db.GetServer(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+	config := server.Config()
+	return &config, nil
+}
+
+// GetServerSchedule expands the events of the server specified by id over the
+// period after from and until to. Only the events of live and dormant servers
+// are directed, other servers have an empty schedule.
+func (ctrl Controller) GetServerSchedule(
+	ctx context.Context,
+	id uuid.UUID,
+	from time.Time,
+	to time.Time,
+) (*model.Schedule, error) {
+	server, err := db.GetServer(ctx, ctrl.store, id)
+	if err != nil {
+		return nil, err
+	}
+	if server.StateType != model.LiveServerState && server.StateType != model.DormantServerState {
+		server.Events = nil
+	}
+	return server.Schedule(from, to)
+}
+
+// CreateServerTemplate saves the configuration under the specified name.
+func (ctrl Controller) CreateServerTemplate(
+	ctx context.Context,
+	name string,
+	config model.ServerConfig,
+) (*model.ServerTemplate, error) {
+	template := &model.ServerTemplate{Name: name, Config: config}
+	if err := db.CreateServerTemplate(ctx, ctrl.store, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetServerTemplate retrieves the template specified by id.
+func (ctrl Controller) GetServerTemplate(ctx context.Context, id uuid.UUID) (*model.ServerTemplate, error) {
+	return db.GetServerTemplate(ctx, ctrl.store, id)
+}
+
+// ListServerTemplates retrieves all templates.
+func (ctrl Controller) ListServerTemplates(ctx context.Context) ([]model.ServerTemplate, error) {
+	return db.ListServerTemplates(ctx, ctrl.store)
+}
+
+// DeleteServerTemplate deletes the template specified by id. Servers created
+// from the template are unaffected.
+func (ctrl Controller) DeleteServerTemplate(ctx context.Context, id uuid.UUID) error {
+	return db.DeleteServerTemplate(ctx, ctrl.store, id)
+}
+
+var errInvalidServerType = errors.New("invalid server type")
+
+// ListServers evaluates the dst and populates it with the related data. The
+// dst should be of type *model.LiveServer, *model.DormantServer,
+// *model.ArchivedServer.
+func (ctrl *Controller) ListServers(ctx context.Context, dst interface{}) error {
+	switch dst.(type) {
+	case *[]model.LiveServer:
+	case *[]model.DormantServer:
+	case *[]model.ArchivedServer:
+		break
+	default:
+		return errInvalidServerType
+	}
+
+	if err := db.ListServers(ctx, ctrl.store, dst); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+func (ctrl *Controller) AddServerTags(
+	ctx context.Context,
+	serverID uuid.UUID,
+	tags model.Tags,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionAddServerTags, func() error {
+		if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		for i := range tags {
+			tags[i].ServerID = serverID
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(tags).Error; err != nil {
+			return fmt.Errorf("create server tags; serverID: %s, error: %w", serverID, err)
+		}
+		return nil
+	})
+}
+
+func (ctrl *Controller) RemoveServerTags(
+	ctx context.Context,
+	serverID uuid.UUID,
+	tagIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionRemoveServerTags, func() error {
+		if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		if err := ctrl.store.WithContext(ctx).Delete(&model.Tag{}, tagIDs).Error; err != nil {
+			return fmt.Errorf("delete server tags; serverID: %s, error: %w", serverID, err)
+		}
+		return nil
+	})
+}
+
+// AddServerEvents adds the Events to the specified server. The server's
+// schedule including the Events is validated against its stored Events; if it
+// has errors, an error wrapping a *model.ScheduleError is returned and the
+// Events are not added. The schedule's warnings are returned.
+func (ctrl *Controller) AddServerEvents(
+	ctx context.Context,
+	serverID uuid.UUID,
+	events model.Events,
+) (model.ScheduleViolations, error) {
+	var warnings model.ScheduleViolations
+	err := ctrl.audit(ctx, serverID, model.AuditActionAddServerEvents, func() error {
+		for i := range events {
+			events[i].ServerID = serverID
+		}
+
+		var err error
+		warnings, err = db.AddServerEvents(ctx, ctrl.store, serverID, events, ctrl.time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := ctrl.notifier.Notify(ctx); err != nil {
+			return fmt.Errorf("while notifying director: %w", err)
+		}
+		return nil
+	})
+	return warnings, err
+}
+
+// RemoveServerEvents removes the Events specified by eventIDs from the
+// specified server. The server's schedule without the Events is validated
+// against its stored Events; if it has errors, e.g. the server is no longer
+// stopped, an error wrapping a *model.ScheduleError is returned and the Events
+// are not removed. The schedule's warnings are returned.
+func (ctrl *Controller) RemoveServerEvents(
+	ctx context.Context,
+	serverID uuid.UUID,
+	eventIDs []uuid.UUID,
+) (model.ScheduleViolations, error) {
+	var warnings model.ScheduleViolations
+	err := ctrl.audit(ctx, serverID, model.AuditActionRemoveServerEvents, func() error {
+		var err error
+		warnings, err = db.RemoveServerEvents(ctx, ctrl.store, serverID, eventIDs, ctrl.time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := ctrl.notifier.Notify(ctx); err != nil {
+			return fmt.Errorf("while notifying director: %w", err)
+		}
+		return nil
+	})
+	return warnings, err
+}
+
+// AddServerBlackouts adds Blackouts to the specified server. The recurring
+// Events of the server do not occur during its Blackouts.
+func (ctrl *Controller) AddServerBlackouts(
+	ctx context.Context,
+	serverID uuid.UUID,
+	blackouts model.Blackouts,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionAddServerBlackouts, func() error {
+		if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		for i := range blackouts {
+			blackouts[i].ServerID = serverID
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(blackouts).Error; err != nil {
+			return fmt.Errorf("create server blackouts; serverID: %s, error: %w", serverID, err)
+		}
+
+		if err := ctrl.notifier.Notify(ctx); err != nil {
+			return fmt.Errorf("while notifying director: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveServerBlackouts removes the specified Blackouts from the server.
+func (ctrl *Controller) RemoveServerBlackouts(
+	ctx context.Context,
+	serverID uuid.UUID,
+	blackoutIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionRemoveServerBlackouts, func() error {
+		if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		if err := ctrl.store.WithContext(ctx).
+			Where("server_id = ?", serverID).
+			Delete(&model.Blackout{}, blackoutIDs).Error; err != nil {
+			return fmt.Errorf("delete server blackouts; serverID: %s, error: %w", serverID, err)
+		}
+
+		if err := ctrl.notifier.Notify(ctx); err != nil {
+			return fmt.Errorf("while notifying director: %w", err)
+		}
+		return nil
+	})
+}
+
+func (ctrl *Controller) AddServerModerators(
+	ctx context.Context,
+	serverID uuid.UUID,
+	moderators model.Moderators,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionAddServerModerators, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		for i := range moderators {
+			moderators[i].ServerID = serverID
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconAddServerModerators(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				moderators,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(moderators).Error; err != nil {
+			return fmt.Errorf("create server moderators; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+func (ctrl *Controller) RemoveServerModerators(
+	ctx context.Context,
+	serverID uuid.UUID,
+	moderatorIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionRemoveServerModerators, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		moderators := server.Moderators
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconRemoveServerModerators(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				moderators,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Delete(&model.Moderator{}, moderatorIDs).Error; err != nil {
+			return fmt.Errorf("delete server moderators; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+func (ctrl *Controller) AddServerOwners(
+	ctx context.Context,
+	serverID uuid.UUID,
+	owners model.Owners,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionAddServerOwners, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		for i := range owners {
+			owners[i].ServerID = serverID
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconAddServerOwners(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				owners,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(owners).Error; err != nil {
+			return fmt.Errorf("create server owners; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
 func (ctrl *Controller) RemoveServerOwners(
 	ctx context.Context,
 	serverID uuid.UUID,
 	ownerIDs []uuid.UUID,
 ) error {
-	server, err := db.GetServer(ctx, ctrl.store, serverID)
+	return ctrl.audit(ctx, serverID, model.AuditActionRemoveServerOwners, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		owners := server.Owners
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconRemoveServerOwners(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				owners,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Delete(&model.Owner{}, ownerIDs).Error; err != nil {
+			return fmt.Errorf("delete server owners; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+func (ctrl *Controller) AddServerBans(
+	ctx context.Context,
+	serverID uuid.UUID,
+	bans model.Bans,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionAddServerBans, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		for i := range bans {
+			bans[i].ServerID = serverID
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconAddServerBans(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				bans,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(bans).Error; err != nil {
+			return fmt.Errorf("create server bans; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+func (ctrl *Controller) RemoveServerBans(
+	ctx context.Context,
+	serverID uuid.UUID,
+	banIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionRemoveServerBans, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		remove := make(map[uuid.UUID]struct{}, len(banIDs))
+		for _, id := range banIDs {
+			remove[id] = struct{}{}
+		}
+
+		var bans model.Bans
+		for _, ban := range server.Bans {
+			if _, ok := remove[ban.ID]; ok {
+				bans = append(bans, ban)
+			}
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconRemoveServerBans(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				bans,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Delete(&model.Ban{}, banIDs).Error; err != nil {
+			return fmt.Errorf("delete server bans; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+// ListPlugins retrieves the Oxide plugin catalog.
+func (ctrl Controller) ListPlugins(ctx context.Context) (model.Plugins, error) {
+	plugins, err := db.ListPlugins(ctx, ctrl.store)
+	if err != nil {
+		return nil, fmt.Errorf("while listing plugins: %w", err)
+	}
+	return plugins, nil
+}
+
+// EnableServerPlugins enables the catalog plugins specified by pluginIDs on
+// the server specified by serverID. Enabled plugins are installed each time
+// the server starts. If the server is live, the plugins are reloaded so that
+// plugins already installed on the server are loaded immediately.
+func (ctrl *Controller) EnableServerPlugins(
+	ctx context.Context,
+	serverID uuid.UUID,
+	pluginIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionEnableServerPlugins, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		plugins, err := db.ListPluginsByIDs(ctx, ctrl.store, uniqueIDs(pluginIDs))
+		if err != nil {
+			return fmt.Errorf("list plugins; serverID: %s, error: %w", serverID, err)
+		}
+
+		var enable model.Plugins
+		serverPlugins := make(model.ServerPlugins, 0, len(plugins))
+		for _, plugin := range plugins {
+			if server.Plugins.Enabled(plugin.ID) {
+				continue
+			}
+			enable = append(enable, plugin)
+			serverPlugins = append(
+				serverPlugins,
+				model.ServerPlugin{ServerID: serverID, PluginID: plugin.ID},
+			)
+		}
+		if len(serverPlugins) == 0 {
+			return nil
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconEnableServerPlugins(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				enable,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.WithContext(ctx).Create(serverPlugins).Error; err != nil {
+			return fmt.Errorf("create server plugins; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+// DisableServerPlugins disables the catalog plugins specified by pluginIDs on
+// the server specified by serverID. Disabled plugins are removed the next time
+// the server starts. If the server is live, the plugins are unloaded.
+func (ctrl *Controller) DisableServerPlugins(
+	ctx context.Context,
+	serverID uuid.UUID,
+	pluginIDs []uuid.UUID,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionDisableServerPlugins, func() error {
+		server, err := db.GetServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		}
+
+		var disable model.Plugins
+		for _, serverPlugin := range server.Plugins {
+			for _, id := range pluginIDs {
+				if serverPlugin.PluginID == id {
+					disable = append(disable, serverPlugin.Plugin)
+					break
+				}
+			}
+		}
+		if len(disable) == 0 {
+			return nil
+		}
+
+		if server.StateType == model.LiveServerState {
+			if err := ctrl.rconDisableServerPlugins(
+				ctx,
+				server.ElasticIP,
+				server.RconPassword,
+				disable,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := ctrl.store.
+			WithContext(ctx).
+			Where("server_id = ? AND plugin_id IN ?", serverID, disable.IDs()).
+			Delete(&model.ServerPlugin{}).Error; err != nil {
+			return fmt.Errorf("delete server plugins; serverID: %s, error: %w", serverID, err)
+		}
+
+		return nil
+	})
+}
+
+// ListServerPlayers retrieves the players connected to the live server
+// specified by serverID.
+func (ctrl Controller) ListServerPlayers(
+	ctx context.Context,
+	serverID uuid.UUID,
+) ([]rcon.Player, error) {
+	server, err := db.GetLiveServer(ctx, ctrl.store, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("while retrieving live server to list players: %w", err)
+	}
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", server.Server.ElasticIP),
+		server.Server.RconPassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial rcon; %w", err)
+	}
+	defer client.Close()
+
+	players, err := client.PlayerList(ctx)
 	if err != nil {
-		return fmt.Errorf("get server; serverID: %s, error: %w", serverID, err)
+		return nil, fmt.Errorf("while listing players: %w", err)
+	}
+	return players, nil
+}
+
+// KickServerPlayer kicks the player specified by steamID from the live server
+// specified by serverID.
+func (ctrl Controller) KickServerPlayer(
+	ctx context.Context,
+	serverID uuid.UUID,
+	steamID string,
+	reason string,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionKickServerPlayer, func() error {
+		server, err := db.GetLiveServer(ctx, ctrl.store, serverID)
+		if err != nil {
+			return fmt.Errorf("while retrieving live server to kick player: %w", err)
+		}
+
+		client, err := ctrl.hub.Dial(
+			ctx,
+			fmt.Sprintf("%s:28016", server.Server.ElasticIP),
+			server.Server.RconPassword,
+		)
+		if err != nil {
+			return fmt.Errorf("dial rcon; %w", err)
+		}
+		defer client.Close()
+
+		if err := client.Kick(ctx, steamID, reason); err != nil {
+			return fmt.Errorf("while kicking player: %w", err)
+		}
+		return nil
+	})
+}
+
+// ExecServerRcon executes the console command on the live server specified
+// by serverID. The command and its result are recorded on behalf of the user
+// specified by userID, regardless of whether the command succeeded.
+func (ctrl Controller) ExecServerRcon(
+	ctx context.Context,
+	serverID uuid.UUID,
+	userID uuid.UUID,
+	command string,
+) (*model.RconCommand, error) {
+	var record *model.RconCommand
+	err := ctrl.audit(ctx, serverID, model.AuditActionExecServerRcon, func() error {
+		var err error
+		record, err = ctrl.execServerRcon(ctx, serverID, userID, command)
+		return err
+	})
+	return record, err
+}
+
+// execServerRcon executes the command on the live server, and records the
+// command along with its response.
+func (ctrl Controller) execServerRcon(
+	ctx context.Context,
+	serverID uuid.UUID,
+	userID uuid.UUID,
+	command string,
+) (*model.RconCommand, error) {
+	server, err := db.GetLiveServer(ctx, ctrl.store, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("while retrieving live server to exec rcon: %w", err)
+	}
+
+	record := &model.RconCommand{
+		ServerID: serverID,
+		UserID:   userID,
+		Command:  command,
+	}
+
+	exec := func() error {
+		client, err := ctrl.hub.Dial(
+			ctx,
+			fmt.Sprintf("%s:28016", server.Server.ElasticIP),
+			server.Server.RconPassword,
+		)
+		if err != nil {
+			return fmt.Errorf("dial rcon; %w", err)
+		}
+		defer client.Close()
+
+		in, err := client.Exec(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		record.Response = in.Message
+		record.ResponseType = in.Type
+		return nil
+	}
+
+	execErr := exec()
+	if execErr != nil {
+		record.Error = execErr.Error()
+	}
+
+	// The command is recorded even if the request is cancelled while it
+	// executes.
+	recordCtx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
+	if err := ctrl.store.WithContext(recordCtx).Create(record).Error; err != nil {
+		return nil, fmt.Errorf("while recording rcon command: %w", err)
+	}
+	if execErr != nil {
+		return record, fmt.Errorf("while executing rcon command: %w", execErr)
+	}
+
+	return record, nil
+}
+
+// ListServerRconCommands retrieves the console commands that have been
+// executed on the server specified by serverID, most recent first.
+func (ctrl Controller) ListServerRconCommands(
+	ctx context.Context,
+	serverID uuid.UUID,
+) (model.RconCommands, error) {
+	if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+		return nil, fmt.Errorf("while retrieving server to list rcon commands: %w", err)
+	}
+
+	commands, err := db.ListRconCommandsByServerID(ctx, ctrl.store, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("while listing rcon commands: %w", err)
+	}
+	return commands, nil
+}
+
+// LiveServerRconForEach executes the specified function for each live server.
+// Servers are processed concurrently, bounded by the configured concurrency,
+// and each server's rcon dial and function execution is bound by the
+// configured per-server timeout. fn must respect the context it is passed.
+// The returned LiveServerResults contains an entry for each live server.
+func (ctrl *Controller) LiveServerRconForEach(
+	ctx context.Context,
+	fn func(context.Context, model.LiveServer, rcon.IRcon) error,
+	options ...ForEachOption,
+) (LiveServerResults, error) {
+	var servers model.LiveServers
+	if err := db.ListServers(ctx, ctrl.store, &servers); err != nil {
+		return nil, fmt.Errorf("while listing live servers: %w", err)
 	}
 
-	owners := server.Owners
+	return ctrl.forEachLiveServer(ctx, servers, fn, options...), nil
+}
 
-	if server.StateType == model.LiveServerState {
-		if err := ctrl.rconRemoveServerOwners(
-			ctx,
-			server.ElasticIP,
-			server.RconPassword,
-			owners,
-		); err != nil {
-			return err
-		}
+// ForEachOption configures a LiveServerRconForEach call.
+type ForEachOption func(*forEachConfig)
+
+// WithConcurrency configures the maximum number of live servers processed at
+// once.
+func WithConcurrency(concurrency int) ForEachOption {
+	return func(cfg *forEachConfig) {
+		cfg.concurrency = concurrency
 	}
+}
 
-	if err := ctrl.store.WithContext(ctx).Delete(&model.Owner{}, ownerIDs).Error; err != nil {
-		return fmt.Errorf("delete server owners; serverID: %s, error: %w", serverID, err)
+// WithServerTimeout configures the maximum duration spent processing a single
+// live server.
+func WithServerTimeout(timeout time.Duration) ForEachOption {
+	return func(cfg *forEachConfig) {
+		cfg.timeout = timeout
 	}
+}
 
-	return nil
+const (
+	defaultForEachConcurrency = 8
+	defaultForEachTimeout     = 30 * time.Second
+)
+
+type forEachConfig struct {
+	concurrency int
+	timeout     time.Duration
 }
 
-// LiveServersRconForEach executes the specified function for each live server.
-func (ctrl *Controller) LiveServerRconForEach(
+// LiveServerResult is the outcome of executing a function against a single
+// live server.
+type LiveServerResult struct {
+	ServerID uuid.UUID
+	Err      error
+	Duration time.Duration
+}
+
+// LiveServerResults is a slice of LiveServerResult.
+type LiveServerResults []LiveServerResult
+
+// Failed retrieves the results that resulted in an error.
+func (results LiveServerResults) Failed() LiveServerResults {
+	failed := make(LiveServerResults, 0)
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (ctrl *Controller) forEachLiveServer(
 	ctx context.Context,
+	servers model.LiveServers,
 	fn func(context.Context, model.LiveServer, rcon.IRcon) error,
-) error {
-	var servers model.LiveServers
-	if err := db.ListServers(ctx, ctrl.store, &servers); err != nil {
-		return fmt.Errorf("while listing live servers: %w", err)
+	options ...ForEachOption,
+) LiveServerResults {
+	cfg := forEachConfig{
+		concurrency: defaultForEachConcurrency,
+		timeout:     defaultForEachTimeout,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
 	}
 
-	closure := func(server model.LiveServer) {
+	closure := func(server model.LiveServer) error {
+		ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+
 		client, err := ctrl.hub.Dial(
 			ctx,
 			fmt.Sprintf("%s:28016", server.Server.ElasticIP),
 			server.Server.RconPassword,
 		)
 		if err != nil {
-			ctrl.logger.Error(
-				"while dialing rcon",
-				zap.Stringer("server", server.Server.ID),
-				zap.Error(err),
-			)
-			return
+			return fmt.Errorf("while dialing rcon: %w", err)
 		}
 		defer client.Close()
 
 		if err := fn(ctx, server, client); err != nil {
-			ctrl.logger.Error(
-				"while executing live server fn",
-				zap.Stringer("server", server.Server.ID),
-				zap.Error(err),
-			)
+			return fmt.Errorf("while executing live server fn: %w", err)
 		}
-	}
-
-	for _, server := range servers {
-		closure(server)
-	}
-	return nil
+		return nil
+	}
+
+	results := make(LiveServerResults, len(servers))
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+	for i := range servers {
+		i := i
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			start := time.Now()
+			err := closure(servers[i])
+			results[i] = LiveServerResult{
+				ServerID: servers[i].Server.ID,
+				Err:      err,
+				Duration: time.Since(start),
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
 }
 
 // CaptureServerInfo retrieves and stores the server info specified live server.
@@ -596,8 +2129,247 @@ func (ctrl *Controller) CaptureServerInfo(ctx context.Context, liveServer model.
 	return nil
 }
 
+// ExpireServerVips revokes the privileges of the live server's expired Vips
+// by removing them from the Oxide vip group. A VipExpired event is written to
+// the event stream for each Vip revoked. Failures do not prevent the remaining
+// Vips from being revoked, the first failure is returned.
+func (ctrl *Controller) ExpireServerVips(
+	ctx context.Context,
+	liveServer model.LiveServer,
+	client rcon.IRcon,
+) error {
+	now := ctrl.time.Now()
+	vips, err := db.ListExpiredVips(ctx, ctrl.store, liveServer.Server.ID, now)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, vip := range vips {
+		if err := ctrl.expireVip(ctx, vip, client, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// expireVip revokes the expired Vip. The Vip is marked as revoked only once
+// it has been removed from the vip group and the VipExpired event has been
+// written, a Vip that fails to be revoked is revoked by a subsequent call. The
+// Vip is locked while it is revoked, so that a concurrently refreshed Vip is
+// not revoked.
+func (ctrl *Controller) expireVip(
+	ctx context.Context,
+	vip model.Vip,
+	client rcon.IRcon,
+	now time.Time,
+) error {
+	revoked, err := db.RevokeVip(ctx, ctrl.store, vip.ID, now, func() error {
+		// A Vip that expired while its server was offline is not in the vip
+		// group, the server's config excludes expired Vips.
+		err := client.RemoveFromGroup(ctx, vip.SteamID, rcon.VipGroup)
+		if err != nil && !errors.Is(err, rcon.ErrNotInGroup) {
+			return fmt.Errorf("while removing expired vip from group: %w", err)
+		}
+
+		expired := event.NewVipExpiredEvent(vip.ServerID, vip.SteamID, vip.ExpiresAt)
+		if err := ctrl.writeToEventStream(ctx, expired); err != nil {
+			return fmt.Errorf("while writing vip expired event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return nil
+	}
+
+	ctrl.logger.Info(
+		"revoked expired vip",
+		zap.Stringer("server-id", vip.ServerID),
+		zap.String("steam-id", vip.SteamID),
+		zap.Time("expires-at", vip.ExpiresAt),
+	)
+	return nil
+}
+
+// AdminChanges are the changes made to a live server's owners and moderators
+// by ReconcileServerAdmins.
+type AdminChanges struct {
+	AddedOwners       []string
+	RemovedOwners     []string
+	AddedModerators   []string
+	RemovedModerators []string
+}
+
+// IsEmpty reports if no changes were made.
+func (c AdminChanges) IsEmpty() bool {
+	return len(c.AddedOwners) == 0 &&
+		len(c.RemovedOwners) == 0 &&
+		len(c.AddedModerators) == 0 &&
+		len(c.RemovedModerators) == 0
+}
+
+// ReconcileServerAdmins reconciles the owners and moderators of the live
+// server with those in the store, the store being authoritative. Owners and
+// moderators are pushed to a live server as they are added and removed, a
+// failed push leaves the server drifted from the store.
+//
+// Rust does not list a server's owners and moderators over RCON. Instead, the
+// outcome of each add and remove, both idempotent, reveals if the server had
+// drifted. Each owner and moderator in the store is added, and each owner and
+// moderator removed from the store is removed. Only those that had drifted are
+// changed, the changes are logged and audited.
+func (ctrl *Controller) ReconcileServerAdmins(
+	ctx context.Context,
+	liveServer model.LiveServer,
+	client rcon.IRcon,
+) error {
+	server, err := db.GetServer(ctx, ctrl.store, liveServer.Server.ID)
+	if err != nil {
+		return fmt.Errorf("while retrieving server to reconcile admins: %w", err)
+	}
+	removedOwners, removedModerators, err := db.ListRemovedServerAdmins(ctx, ctrl.store, server.ID)
+	if err != nil {
+		return err
+	}
+
+	owners := server.Owners.SteamIDs()
+	moderators := server.Moderators.SteamIDs()
+
+	changes, err := reconcileServerAdmins(
+		ctx,
+		client,
+		owners,
+		moderators,
+		difference(removedOwners, owners),
+		difference(removedModerators, moderators),
+	)
+	if changes.IsEmpty() {
+		return err
+	}
+
+	ctrl.logger.Info(
+		"reconciled drifted server admins",
+		zap.Stringer("server-id", server.ID),
+		zap.Strings("added-owners", changes.AddedOwners),
+		zap.Strings("removed-owners", changes.RemovedOwners),
+		zap.Strings("added-moderators", changes.AddedModerators),
+		zap.Strings("removed-moderators", changes.RemovedModerators),
+	)
+
+	// The drifted server's admins prior to reconciliation are those in the
+	// store, less those added, plus those removed.
+	auditChanges := make(model.AuditChanges)
+	if len(changes.AddedOwners) > 0 || len(changes.RemovedOwners) > 0 {
+		auditChanges["owners"] = model.AuditChange{
+			Before: union(difference(owners, changes.AddedOwners), changes.RemovedOwners),
+			After:  owners,
+		}
+	}
+	if len(changes.AddedModerators) > 0 || len(changes.RemovedModerators) > 0 {
+		auditChanges["moderators"] = model.AuditChange{
+			Before: union(difference(moderators, changes.AddedModerators), changes.RemovedModerators),
+			After:  moderators,
+		}
+	}
+	ctrl.recordAuditEntry(
+		ctx,
+		model.NewAuditEntry(server.ID, actor(ctx), model.AuditActionReconcileServerAdmins, auditChanges, err),
+	)
+	return err
+}
+
+// reconcileServerAdmins adds the owners and moderators, and removes the
+// removed owners and moderators from the server via client. Those that were
+// not already in the desired state are returned as changes. Failures do not
+// prevent the remaining owners and moderators from being reconciled, the
+// first failure is returned.
+func reconcileServerAdmins(
+	ctx context.Context,
+	client rcon.IRcon,
+	owners []string,
+	moderators []string,
+	removedOwners []string,
+	removedModerators []string,
+) (AdminChanges, error) {
+	var changes AdminChanges
+	var firstErr error
+
+	apply := func(
+		steamIDs []string,
+		fn func(context.Context, string) error,
+		unchanged error,
+		changed *[]string,
+	) {
+		for _, steamID := range steamIDs {
+			err := fn(ctx, steamID)
+			if errors.Is(err, unchanged) {
+				continue
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("while reconciling admin %s: %w", steamID, err)
+				}
+				continue
+			}
+			*changed = append(*changed, steamID)
+		}
+	}
+
+	// Removals are applied first, a steam ID may have moved from one role to
+	// the other.
+	apply(removedOwners, client.RemoveOwner, rcon.ErrOwnerDNE, &changes.RemovedOwners)
+	apply(removedModerators, client.RemoveModerator, rcon.ErrModeratorDNE, &changes.RemovedModerators)
+	apply(owners, client.AddOwner, rcon.ErrOwnerExists, &changes.AddedOwners)
+	apply(moderators, client.AddModerator, rcon.ErrModeratorExists, &changes.AddedModerators)
+
+	return changes, firstErr
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	exclude := make(map[string]struct{}, len(b))
+	for _, s := range b {
+		exclude[s] = struct{}{}
+	}
+
+	res := make([]string, 0, len(a))
+	for _, s := range a {
+		if _, ok := exclude[s]; ok {
+			continue
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// union returns the elements of a followed by the elements of b that are not
+// in a.
+func union(a, b []string) []string {
+	return append(append([]string{}, a...), difference(b, a)...)
+}
+
+// SaveServer saves the world of the specified live server and records when
+// the save succeeded.
+func (ctrl *Controller) SaveServer(ctx context.Context, liveServer model.LiveServer, rcon rcon.IRcon) error {
+	return ctrl.saveServer(ctx, liveServer.Server.ID, rcon)
+}
+
+func (ctrl *Controller) saveServer(ctx context.Context, serverID uuid.UUID, rcon rcon.IRcon) error {
+	if err := rcon.Save(ctx); err != nil {
+		return fmt.Errorf("while saving server via rcon: %w", err)
+	}
+
+	if err := db.UpdateServerLastSavedAt(ctx, ctrl.store, serverID, ctrl.time.Now()); err != nil {
+		return fmt.Errorf("while recording server save: %w", err)
+	}
+	return nil
+}
+
 func (ctrl *Controller) SayServerTimeRemaining(ctx context.Context, server model.LiveServer, rcon rcon.IRcon) error {
-	_, whenOffline, err := server.Server.Events.NextEvent(ctrl.time.Now(), model.EventKindStop)
+	_, whenOffline, err := server.Server.NextEvent(ctrl.time.Now(), model.EventKindStop)
 	if err != nil {
 		return fmt.Errorf("while determining next stop server event: %w", err)
 	}
@@ -634,8 +2406,220 @@ func (ctrl *Controller) SayServerTimeRemaining(ctx context.Context, server model
 	return nil
 }
 
+// UpdateServerCountdown updates the countdown broadcast to the players of the
+// server specified by serverID prior to stop and wipe events.
+func (ctrl Controller) UpdateServerCountdown(
+	ctx context.Context,
+	serverID uuid.UUID,
+	countdown model.Countdown,
+) error {
+	return ctrl.audit(ctx, serverID, model.AuditActionUpdateServerCountdown, func() error {
+		if _, err := db.GetServer(ctx, ctrl.store, serverID); err != nil {
+			return fmt.Errorf("while retrieving server to update countdown: %w", err)
+		}
+
+		if err := ctrl.store.WithContext(ctx).
+			Model(&model.Server{}).
+			Where("id = ?", serverID).
+			Update("countdown", countdown).Error; err != nil {
+			return fmt.Errorf("while updating server countdown: %w", err)
+		}
+
+		if err := ctrl.notifier.Notify(ctx); err != nil {
+			return fmt.Errorf("while notifying director: %w", err)
+		}
+		return nil
+	})
+}
+
+// CountdownServer broadcasts the countdown of the server specified by
+// serverID to its players prior to the event of the specified kind occurring
+// at the specified time. CountdownServer blocks until the countdown is
+// complete. If the server is not live when the countdown begins, nothing is
+// broadcast.
+func (ctrl *Controller) CountdownServer(
+	ctx context.Context,
+	serverID uuid.UUID,
+	kind model.EventKind,
+	at time.Time,
+) error {
+	server, err := db.GetServer(ctx, ctrl.store, serverID)
+	if err != nil {
+		return fmt.Errorf("while retrieving server to countdown: %w", err)
+	}
+
+	broadcasts, err := server.Countdown.Broadcasts(server.Name, kind, at)
+	if err != nil {
+		return fmt.Errorf("while building countdown broadcasts: %w", err)
+	}
+	if len(broadcasts) == 0 {
+		return nil
+	}
+
+	if err := ctrl.sleepUntil(ctx, broadcasts[0].At); err != nil {
+		return err
+	}
+
+	liveServer, err := db.GetLiveServer(ctx, ctrl.store, serverID)
+	if errors.Is(err, ierrors.ErrServerNotLive) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("while retrieving live server to countdown: %w", err)
+	}
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", liveServer.Server.ElasticIP),
+		liveServer.Server.RconPassword,
+	)
+	if err != nil {
+		return fmt.Errorf("while dialing server to countdown: %w", err)
+	}
+	defer client.Close()
+
+	return ctrl.countdown(ctx, client, broadcasts)
+}
+
 // --- private ---
 
+func (ctrl *Controller) rconEnableServerPlugins(
+	ctx context.Context,
+	elasticIP string,
+	password string,
+	plugins model.Plugins,
+) error {
+	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", elasticIP),
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("dial rcon; %w", err)
+	}
+	defer client.Close()
+
+	for _, plugin := range plugins {
+		// Plugins not yet installed on the server are installed, and their
+		// permissions granted, the next time the server starts.
+		err := client.ReloadPlugin(ctx, plugin.Name)
+		if errors.Is(err, rcon.ErrPluginNotLoaded) {
+			continue
+		}
+		if err != nil {
+			logger.Error("unable to reload plugin", zap.String("plugin", plugin.Name), zap.Error(err))
+		}
+
+		// Grants are idempotent, a permission already granted is not an
+		// error.
+		for _, grant := range plugin.Grants {
+			if err := client.GrantGroupPermission(
+				ctx,
+				grant.Group,
+				grant.Permission,
+			); err != nil && !errors.Is(err, rcon.ErrPermissionAlreadyGranted) {
+				return fmt.Errorf(
+					"grant plugin permission; plugin: %s, grant: %s, error: %w",
+					plugin.Name,
+					grant,
+					err,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) rconDisableServerPlugins(
+	ctx context.Context,
+	elasticIP string,
+	password string,
+	plugins model.Plugins,
+) error {
+	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", elasticIP),
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("dial rcon; %w", err)
+	}
+	defer client.Close()
+
+	for _, plugin := range plugins {
+		if err := client.UnloadPlugin(
+			ctx,
+			plugin.Name,
+		); err != nil && !errors.Is(err, rcon.ErrPluginNotLoaded) {
+			logger.Error("unable to unload plugin", zap.String("plugin", plugin.Name), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) rconAddServerBans(
+	ctx context.Context,
+	elasticIP string,
+	password string,
+	bans model.Bans,
+) error {
+	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", elasticIP),
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("dial rcon; %w", err)
+	}
+	defer client.Close()
+
+	for _, ban := range bans {
+		if err := client.Ban(
+			ctx,
+			ban.SteamID,
+			ban.Reason,
+		); err != nil && !errors.Is(err, rcon.ErrBanExists) {
+			logger.Error("unable to add bans to server", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (ctrl Controller) rconRemoveServerBans(
+	ctx context.Context,
+	elasticIP string,
+	password string,
+	bans model.Bans,
+) error {
+	logger := ctrl.logger.With(logger.ContextFields(ctx)...)
+
+	client, err := ctrl.hub.Dial(
+		ctx,
+		fmt.Sprintf("%s:28016", elasticIP),
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("dial rcon; %w", err)
+	}
+	defer client.Close()
+
+	for _, ban := range bans {
+		if err := client.Unban(
+			ctx,
+			ban.SteamID,
+		); err != nil && !errors.Is(err, rcon.ErrBanDNE) {
+			logger.Error("remove bans", zap.Error(err))
+		}
+	}
+	return nil
+}
+
 func (ctrl *Controller) rconAddServerModerators(
 	ctx context.Context,
 	elasticIP string,
@@ -793,3 +2777,58 @@ func rconURL(host, password string) string {
 	}
 	return url.String()
 }
+
+// uniqueIDs removes duplicate IDs, preserving order.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// countdownTolerance is how late a countdown broadcast may be before it is
+// skipped. A warning that is broadcast late is misleading, e.g. "10 minutes"
+// when only 3 minutes remain.
+const countdownTolerance = time.Second
+
+// countdown says each broadcast at its specified time. Broadcasts that are
+// already late are skipped.
+func (ctrl *Controller) countdown(
+	ctx context.Context,
+	client rcon.IRcon,
+	broadcasts []model.Broadcast,
+) error {
+	for _, broadcast := range broadcasts {
+		if ctrl.time.Now().Sub(broadcast.At) > countdownTolerance {
+			continue
+		}
+		if err := ctrl.sleepUntil(ctx, broadcast.At); err != nil {
+			return err
+		}
+		if err := client.Say(ctx, broadcast.Message); err != nil {
+			return fmt.Errorf("while broadcasting countdown: %w", err)
+		}
+	}
+	return nil
+}
+
+// sleepUntil blocks until the specified time, or the context is done.
+func (ctrl *Controller) sleepUntil(ctx context.Context, at time.Time) error {
+	wait := at.Sub(ctrl.time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ctrl.time.After(wait):
+		return nil
+	}
+}
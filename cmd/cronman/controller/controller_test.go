@@ -4,15 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/tjper/rustcron/cmd/cronman/db"
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/model"
 	"github.com/tjper/rustcron/cmd/cronman/rcon"
+	"github.com/tjper/rustcron/cmd/cronman/rcon/rcontest"
 	"github.com/tjper/rustcron/cmd/cronman/server"
 	"github.com/tjper/rustcron/internal/event"
 	imodel "github.com/tjper/rustcron/internal/model"
@@ -145,18 +150,111 @@ func TestLiveServerRconForEach(t *testing.T) {
 				hub:    rcon.NewHubMock(),
 			}
 
+			var mutex sync.Mutex
+			associationIDs := make([]string, 0)
 			fn := func(_ context.Context, server model.LiveServer, _ rcon.IRcon) error {
-				expect := test.exp.servers[0]
-				expect.Model = server.Model
-				expect.Server.Model = server.Server.Model
-
-				require.Equal(t, expect.Model.ID, server.Model.ID)
-				test.exp.servers = test.exp.servers[1:]
+				mutex.Lock()
+				defer mutex.Unlock()
+				associationIDs = append(associationIDs, server.AssociationID)
 				return nil
 			}
 
-			err = controller.LiveServerRconForEach(ctx, fn)
+			results, err := controller.LiveServerRconForEach(ctx, fn)
 			require.Nil(t, err)
+			require.Len(t, results, len(test.exp.servers))
+			require.Empty(t, results.Failed())
+
+			expIDs := make([]string, 0, len(test.exp.servers))
+			for _, server := range test.exp.servers {
+				expIDs = append(expIDs, server.AssociationID)
+			}
+			require.ElementsMatch(t, expIDs, associationIDs)
+		})
+	}
+}
+
+func TestForEachLiveServer(t *testing.T) {
+	t.Parallel()
+
+	slow := model.LiveServer{Server: model.Server{Model: imodel.Model{ID: uuid.New()}}}
+	fast := make(model.LiveServers, 0, 5)
+	for i := 0; i < 5; i++ {
+		fast = append(fast, model.LiveServer{Server: model.Server{Model: imodel.Model{ID: uuid.New()}}})
+	}
+	servers := append(model.LiveServers{slow}, fast...)
+
+	type expected struct {
+		maxInFlight int32
+	}
+	tests := map[string]struct {
+		concurrency int
+		exp         expected
+	}{
+		"sequential":  {concurrency: 1, exp: expected{maxInFlight: 1}},
+		"concurrent":  {concurrency: 3, exp: expected{maxInFlight: 3}},
+		"unbounded":   {concurrency: len(servers), exp: expected{maxInFlight: int32(len(servers))}},
+		"zero clamps": {concurrency: 0, exp: expected{maxInFlight: 1}},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			controller := &Controller{
+				logger: zap.NewNop(),
+				hub:    rcon.NewHubMock(),
+			}
+
+			var inFlight, maxInFlight int32
+			fn := func(ctx context.Context, server model.LiveServer, _ rcon.IRcon) error {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+
+				if server.Server.ID == slow.Server.ID {
+					// The slow server hangs until its deadline is exceeded.
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			}
+
+			start := time.Now()
+			results := controller.forEachLiveServer(
+				ctx,
+				servers,
+				fn,
+				WithConcurrency(test.concurrency),
+				WithServerTimeout(200*time.Millisecond),
+			)
+			elapsed := time.Since(start)
+
+			require.Len(t, results, len(servers))
+			require.Equal(t, test.exp.maxInFlight, maxInFlight)
+			// Only the slow server's deadline bounds the total duration.
+			require.Less(t, int64(elapsed), int64(time.Second))
+
+			failed := results.Failed()
+			require.Len(t, failed, 1)
+			require.Equal(t, slow.Server.ID, failed[0].ServerID)
+			require.ErrorIs(t, failed[0].Err, context.DeadlineExceeded)
+			require.GreaterOrEqual(t, int64(failed[0].Duration), int64(200*time.Millisecond))
+
+			for i, result := range results[1:] {
+				require.Equal(t, fast[i].Server.ID, result.ServerID)
+				require.Nil(t, result.Err)
+				require.Less(t, int64(result.Duration), int64(200*time.Millisecond))
+			}
 		})
 	}
 }
@@ -484,13 +582,15 @@ func TestStartServer(t *testing.T) {
 		server              model.DormantServer
 	}
 	tests := map[string]struct {
-		wipes      model.Wipes
-		vips       model.Vips
-		owners     model.Owners
-		moderators model.Moderators
-		exp        expected
+		wipes          model.Wipes
+		vips           model.Vips
+		owners         model.Owners
+		moderators     model.Moderators
+		defaultPlugins bool
+		exp            expected
 	}{
 		"queuebypass, adminradar, and vanish plugins": {
+			defaultPlugins: true,
 			wipes: model.Wipes{
 				{
 					Model:     imodel.Model{At: imodel.At{CreatedAt: oneDayAgo}},
@@ -505,6 +605,8 @@ func TestStartServer(t *testing.T) {
 					regexp.MustCompile(`umod\.org\/plugins\/BypassQueue\.cs`),
 					regexp.MustCompile(`umod\.org\/plugins\/Vanish\.cs`),
 					regexp.MustCompile(`umod\.org\/plugins\/AdminRadar\.cs`),
+					regexp.MustCompile(`oxide\.grant group vip bypassqueue\.allow`),
+					regexp.MustCompile(`oxide\.grant group admin vanish\.allow`),
 				},
 				negativeUserdataREs: []*regexp.Regexp{
 					regexp.MustCompile(`player\\\.blueprints.+\|\sxargs\srm`),
@@ -526,9 +628,14 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators:   model.Moderators{},
 						Events:       model.Events{},
+						Blackouts:    model.Blackouts{},
 						Tags:         model.Tags{},
 						Vips:         model.Vips{},
 						Owners:       model.Owners{},
@@ -576,9 +683,14 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators:   model.Moderators{},
 						Events:       model.Events{},
+						Blackouts:    model.Blackouts{},
 						Tags:         model.Tags{},
 						Vips:         model.Vips{},
 						Owners:       model.Owners{},
@@ -628,9 +740,14 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators:   model.Moderators{},
 						Events:       model.Events{},
+						Blackouts:    model.Blackouts{},
 						Tags:         model.Tags{},
 						Vips:         model.Vips{},
 						Owners:       model.Owners{},
@@ -678,9 +795,14 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators:   model.Moderators{},
 						Events:       model.Events{},
+						Blackouts:    model.Blackouts{},
 						Tags:         model.Tags{},
 						Vips:         model.Vips{},
 						Owners:       model.Owners{},
@@ -730,9 +852,14 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators:   model.Moderators{},
 						Events:       model.Events{},
+						Blackouts:    model.Blackouts{},
 						Tags:         model.Tags{},
 						Vips: model.Vips{
 							{SteamID: "expired-vip-steam-id", ExpiresAt: oneMinuteAgo},
@@ -788,16 +915,21 @@ func TestStartServer(t *testing.T) {
 						URL:          "https://rustpm.com",
 						BannerURL:    "https://rustpm.com",
 						Region:       model.RegionUsEast,
+						TimeZone:     "UTC",
 						Options:      map[string]interface{}{},
+						Lifecycle:    model.LifecycleIdle,
+						Bans:         model.Bans{},
+						Plugins:      model.ServerPlugins{},
 						Moderators: model.Moderators{
 							{SteamID: "moderator-steam-id"},
 						},
 						Owners: model.Owners{
 							{SteamID: "owner-steam-id"},
 						},
-						Events: model.Events{},
-						Tags:   model.Tags{},
-						Vips:   model.Vips{},
+						Events:    model.Events{},
+						Blackouts: model.Blackouts{},
+						Tags:      model.Tags{},
+						Vips:      model.Vips{},
 						Wipes: model.Wipes{
 							{
 								Model:     imodel.Model{At: imodel.At{CreatedAt: time.Now().Add(-24 * time.Hour)}},
@@ -848,6 +980,17 @@ func TestStartServer(t *testing.T) {
 			server.Server.Owners = test.owners
 			server.Server.Moderators = test.moderators
 
+			if test.defaultPlugins {
+				plugins, err := db.ListDefaultPlugins(ctx, store)
+				require.Nil(t, err)
+				for _, plugin := range plugins {
+					server.Server.Plugins = append(
+						server.Server.Plugins,
+						model.ServerPlugin{PluginID: plugin.ID},
+					)
+				}
+			}
+
 			err = store.WithContext(ctx).Create(&server).Error
 			require.Nil(t, err)
 			defer func() {
@@ -880,6 +1023,10 @@ func TestStartServer(t *testing.T) {
 			// Set expected server Fields that could not be known ahead of test.
 			test.exp.server.Server.StateID = startedServer.Server.StateID
 			test.exp.server.Server.StateType = startedServer.Server.StateType
+			if test.defaultPlugins {
+				require.Len(t, startedServer.Server.Plugins, len(server.Server.Plugins))
+				test.exp.server.Server.Plugins = startedServer.Server.Plugins
+			}
 
 			// Scrub both expected and actual to ensure that non-deterministic fields
 			// do not affect equality comparisons.
@@ -1064,9 +1211,12 @@ func TestStopServer(t *testing.T) {
 				),
 			)
 
+			now := time.Now().UTC().Truncate(time.Second)
+			hub := rcon.NewHubMock()
 			controller := &Controller{
 				logger: zap.NewNop(),
-				hub:    rcon.NewHubMock(),
+				time:   itime.NewMock(now),
+				hub:    hub,
 				store:  store,
 				serverDirector: NewServerDirector(
 					serverManager,
@@ -1089,10 +1239,1117 @@ func TestStopServer(t *testing.T) {
 				err = store.WithContext(ctx).Delete(dormantServer).Error
 				require.Nil(t, err)
 			}()
+
+			// The world is saved and the config is written prior to quitting.
+			require.Equal(t, "elastic-IP:28016 rcon-password server.save", hub.LPop())
+			require.Equal(t, "elastic-IP:28016 rcon-password server.writecfg", hub.LPop())
+
+			server, err := db.GetServer(ctx, store, test.server.Server.ID)
+			require.Nil(t, err)
+			require.NotNil(t, server.LastSavedAt)
+			require.True(t, now.Equal(*server.LastSavedAt))
 		})
 	}
 }
 
+func TestStopServerFencing(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := db.Open(dsn)
+	require.Nil(t, err)
+
+	err = db.Migrate(store, migrations)
+	require.Nil(t, err)
+
+	serverManager := server.NewMockManager()
+	serverManager.SetMakeInstanceUnavailableHandler(func(_ context.Context, _ string) error {
+		return nil
+	})
+	serverManager.SetStopInstanceHandler(func(_ context.Context, _ string) error {
+		return nil
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	controller := &Controller{
+		logger: zap.NewNop(),
+		time:   itime.NewMock(now),
+		hub:    rcon.NewHubMock(),
+		store:  store,
+		serverDirector: NewServerDirector(
+			serverManager,
+			serverManager,
+			serverManager,
+		),
+		eventStream: stream.NewClientMock(
+			stream.WithWrite(func(_ context.Context, _ []byte) error { return nil }),
+		),
+	}
+
+	liveServer := model.LiveServer{
+		Server:        *zeroServer.Clone(),
+		AssociationID: "stop-server-fencing-association-id",
+	}
+	liveServer.Server.FencingToken = 2
+
+	err = store.WithContext(ctx).Create(&liveServer).Error
+	require.Nil(t, err)
+	defer func() {
+		err = store.WithContext(ctx).Delete(&liveServer).Error
+		require.Nil(t, err)
+	}()
+
+	// A director holding a stale lock may not stop the server.
+	_, err = controller.StopServer(model.WithFencingToken(ctx, 1), liveServer.Server.ID)
+	require.ErrorIs(t, err, ierrors.ErrStaleFencingToken)
+
+	server, err := db.GetServer(ctx, store, liveServer.Server.ID)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), server.FencingToken)
+	require.Equal(t, model.LifecycleIdle, server.Lifecycle)
+
+	dormantServer, err := controller.StopServer(model.WithFencingToken(ctx, 3), liveServer.Server.ID)
+	require.Nil(t, err)
+	defer func() {
+		err = store.WithContext(ctx).Delete(dormantServer).Error
+		require.Nil(t, err)
+	}()
+
+	server, err = db.GetServer(ctx, store, liveServer.Server.ID)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), server.FencingToken)
+}
+
+func TestDirectEventAndRetry(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := db.Open(dsn)
+	require.Nil(t, err)
+
+	err = db.Migrate(store, migrations)
+	require.Nil(t, err)
+
+	errStop := errors.New("instance failed to stop")
+	stopErr := errStop
+
+	serverManager := server.NewMockManager()
+	serverManager.SetMakeInstanceUnavailableHandler(func(_ context.Context, _ string) error {
+		return nil
+	})
+	serverManager.SetStopInstanceHandler(func(_ context.Context, _ string) error {
+		return stopErr
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	controller := &Controller{
+		logger: zap.NewNop(),
+		time:   itime.NewMock(now),
+		hub:    rcon.NewHubMock(),
+		store:  store,
+		serverDirector: NewServerDirector(
+			serverManager,
+			serverManager,
+			serverManager,
+		),
+		eventStream: stream.NewClientMock(
+			stream.WithWrite(func(_ context.Context, _ []byte) error { return nil }),
+		),
+	}
+
+	liveServer := model.LiveServer{
+		Server:        *zeroServer.Clone(),
+		AssociationID: "direct-event-association-id",
+	}
+	err = store.WithContext(ctx).Create(&liveServer).Error
+	require.Nil(t, err)
+	defer func() {
+		err = store.WithContext(ctx).Delete(&liveServer).Error
+		require.Nil(t, err)
+	}()
+
+	event := model.Event{Kind: model.EventKindStop, ServerID: liveServer.Server.ID}
+	event.ID = uuid.New()
+	scheduledAt := now.Add(-time.Minute)
+
+	directorCtx := model.WithActor(ctx, model.NewDirectorActor(event))
+	failed, err := controller.DirectEvent(directorCtx, event, scheduledAt)
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, model.ExecutionOutcomeFailed, failed.Outcome)
+
+	executions, err := controller.ListServerExecutions(ctx, ListExecutionsInput{
+		ServerID: liveServer.Server.ID,
+		Limit:    10,
+	})
+	require.Nil(t, err)
+	require.Len(t, executions, 1)
+	require.Equal(t, failed.ID, executions[0].ID)
+	require.Equal(t, event.ID, executions[0].EventID)
+	require.Equal(t, model.ActorKindDirector, executions[0].Actor.Kind)
+	require.True(t, scheduledAt.Equal(executions[0].ScheduledAt))
+	require.NotNil(t, executions[0].FinishedAt)
+	require.Contains(t, executions[0].Error, errStop.Error())
+
+	// A failed server may be stopped again once the failure is resolved.
+	stopErr = nil
+	retried, err := controller.RetryExecution(ctx, failed.ID)
+	require.Nil(t, err)
+	require.Equal(t, model.ExecutionOutcomeSucceeded, retried.Outcome)
+	require.Equal(t, &failed.ID, retried.RetryOf)
+	require.True(t, scheduledAt.Equal(retried.ScheduledAt))
+
+	_, err = controller.RetryExecution(ctx, retried.ID)
+	require.ErrorIs(t, err, ierrors.ErrExecutionNotFailed)
+
+	// A failed execution may not be retried once a retry has succeeded.
+	_, err = controller.RetryExecution(ctx, failed.ID)
+	require.ErrorIs(t, err, ierrors.ErrExecutionNotFailed)
+	_, err = controller.RetryExecutionJob(ctx, failed.ID)
+	require.ErrorIs(t, err, ierrors.ErrExecutionNotFailed)
+}
+
+func TestFailInterruptedExecutions(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := db.Open(dsn)
+	require.Nil(t, err)
+
+	err = db.Migrate(store, migrations)
+	require.Nil(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	controller := &Controller{
+		logger: zap.NewNop(),
+		time:   itime.NewMock(now),
+		store:  store,
+	}
+
+	event := model.Event{Kind: model.EventKindStart, ServerID: uuid.New()}
+	event.ID = uuid.New()
+
+	// The execution left running by a previous director is failed, the
+	// retry run by a user is left running.
+	interrupted := model.NewExecution(event, now, model.NewDirectorActor(event), now.Add(-time.Minute))
+	err = db.CreateExecution(ctx, store, interrupted)
+	require.Nil(t, err)
+
+	retry := model.NewExecution(event, now, model.NewUserActor(uuid.New(), "user@example.com"), now.Add(-time.Minute))
+	err = db.CreateExecution(ctx, store, retry)
+	require.Nil(t, err)
+
+	err = controller.FailInterruptedExecutions(ctx)
+	require.Nil(t, err)
+
+	execution, err := db.GetExecution(ctx, store, interrupted.ID)
+	require.Nil(t, err)
+	require.Equal(t, model.ExecutionOutcomeFailed, execution.Outcome)
+	require.Equal(t, "interrupted", execution.Error)
+	require.NotNil(t, execution.FinishedAt)
+
+	execution, err = db.GetExecution(ctx, store, retry.ID)
+	require.Nil(t, err)
+	require.Equal(t, model.ExecutionOutcomeRunning, execution.Outcome)
+}
+
+func TestCatchUpServer(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	now := time.Date(2022, time.June, 16, 12, 0, 0, 0, time.UTC)
+
+	type expected struct {
+		stateType string
+	}
+	tests := map[string]struct {
+		events   model.Events
+		lookback time.Duration
+		exp      expected
+	}{
+		"missed stop": {
+			events: model.Events{
+				{Kind: model.EventKindLive, Schedule: "0 8 * * *"},
+				{Kind: model.EventKindStop, Schedule: "0 11 * * *"},
+			},
+			exp: expected{stateType: model.DormantServerState},
+		},
+		"already live": {
+			events: model.Events{
+				{Kind: model.EventKindLive, Schedule: "0 10 * * *"},
+				{Kind: model.EventKindStop, Schedule: "0 5 * * *"},
+			},
+			exp: expected{stateType: model.LiveServerState},
+		},
+		"stop prior to lookback": {
+			events: model.Events{
+				{Kind: model.EventKindLive, Schedule: "0 4 * * *"},
+				{Kind: model.EventKindStop, Schedule: "0 5 * * *"},
+			},
+			exp: expected{stateType: model.LiveServerState},
+		},
+		"stop prior to last schedule": {
+			events: model.Events{
+				{Kind: model.EventKindLive, Schedule: "0 8 * * *"},
+				{Kind: model.EventKindStop, Schedule: "0 11 * * *"},
+			},
+			lookback: 30 * time.Minute,
+			exp:      expected{stateType: model.LiveServerState},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			store, err := db.Open(dsn)
+			require.Nil(t, err)
+
+			err = db.Migrate(store, migrations)
+			require.Nil(t, err)
+
+			serverManager := server.NewMockManager()
+			serverManager.SetMakeInstanceUnavailableHandler(func(_ context.Context, _ string) error {
+				return nil
+			})
+			serverManager.SetStopInstanceHandler(func(_ context.Context, _ string) error {
+				return nil
+			})
+
+			controller := &Controller{
+				logger: zap.NewNop(),
+				time:   itime.NewMock(now),
+				hub:    rcon.NewHubMock(),
+				store:  store,
+				serverDirector: NewServerDirector(
+					serverManager,
+					serverManager,
+					serverManager,
+				),
+				eventStream: stream.NewClientMock(
+					stream.WithWrite(func(_ context.Context, _ []byte) error { return nil }),
+				),
+			}
+
+			liveServer := model.LiveServer{
+				Server:        *zeroServer.Clone(),
+				AssociationID: "catch-up-association-id",
+			}
+			liveServer.Server.Events = test.events
+
+			err = store.WithContext(ctx).Create(&liveServer).Error
+			require.Nil(t, err)
+			defer func() {
+				err = store.WithContext(ctx).Delete(&liveServer).Error
+				require.Nil(t, err)
+			}()
+
+			lookback := test.lookback
+			if lookback == 0 {
+				lookback = 6 * time.Hour
+			}
+			err = controller.CatchUpServer(ctx, liveServer.Server.ID, now.Add(-lookback))
+			require.Nil(t, err)
+
+			server, err := db.GetServer(ctx, store, liveServer.Server.ID)
+			require.Nil(t, err)
+			require.Equal(t, test.exp.stateType, server.StateType)
+			require.Equal(t, model.LifecycleIdle, server.Lifecycle)
+
+			if server.StateType == model.DormantServerState {
+				err = store.WithContext(ctx).Delete(&model.DormantServer{Model: imodel.Model{ID: server.StateID}}).Error
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRecoverServers(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	withLifecycle := func(server model.Server, lifecycle model.Lifecycle) model.Server {
+		server.Lifecycle = lifecycle
+		return server
+	}
+
+	type expected struct {
+		lifecycle     model.Lifecycle
+		live          bool
+		stopped       bool
+		unavailable   bool
+		reasonPattern *regexp.Regexp
+	}
+	tests := map[string]struct {
+		server interface{}
+		exp    expected
+	}{
+		"interrupted starting": {
+			server: &model.DormantServer{
+				Server: withLifecycle(*zeroServer.Clone(), model.LifecycleStarting),
+			},
+			exp: expected{lifecycle: model.LifecycleIdle, stopped: true},
+		},
+		"interrupted going live": {
+			server: &model.DormantServer{
+				Server: withLifecycle(*zeroServer.Clone(), model.LifecycleGoingLive),
+			},
+			exp: expected{lifecycle: model.LifecycleIdle, stopped: true},
+		},
+		"interrupted going live after live": {
+			server: &model.LiveServer{
+				Server:        withLifecycle(*zeroServer.Clone(), model.LifecycleGoingLive),
+				AssociationID: "recover-association-id",
+			},
+			exp: expected{lifecycle: model.LifecycleIdle, live: true},
+		},
+		"interrupted stopping": {
+			server: &model.LiveServer{
+				Server:        withLifecycle(*zeroServer.Clone(), model.LifecycleStopping),
+				AssociationID: "recover-association-id",
+			},
+			exp: expected{lifecycle: model.LifecycleIdle, stopped: true, unavailable: true},
+		},
+		"interrupted wiping": {
+			server: &model.DormantServer{
+				Server: withLifecycle(*zeroServer.Clone(), model.LifecycleWiping),
+			},
+			exp: expected{lifecycle: model.LifecycleIdle},
+		},
+		"interrupted creating": {
+			server: &model.DormantServer{
+				Server: func() model.Server {
+					server := withLifecycle(*zeroServer.Clone(), model.LifecycleCreating)
+					server.InstanceID = ""
+					return server
+				}(),
+			},
+			exp: expected{
+				lifecycle:     model.LifecycleFailed,
+				reasonPattern: regexp.MustCompile(`interrupted while creating instance`),
+			},
+		},
+		"failed": {
+			server: &model.DormantServer{
+				Server: withLifecycle(*zeroServer.Clone(), model.LifecycleFailed),
+			},
+			exp: expected{lifecycle: model.LifecycleFailed},
+		},
+		"starting in another process": {
+			server: &model.DormantServer{
+				Server: func() model.Server {
+					server := withLifecycle(*zeroServer.Clone(), model.LifecycleStarting)
+					heartbeatAt := time.Now()
+					server.LifecycleHeartbeatAt = &heartbeatAt
+					return server
+				}(),
+			},
+			exp: expected{lifecycle: model.LifecycleStarting},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			store, err := db.Open(dsn)
+			require.Nil(t, err)
+
+			err = db.Migrate(store, migrations)
+			require.Nil(t, err)
+
+			err = store.WithContext(ctx).Create(test.server).Error
+			require.Nil(t, err)
+
+			var serverID uuid.UUID
+			switch server := test.server.(type) {
+			case *model.DormantServer:
+				serverID = server.Server.ID
+			case *model.LiveServer:
+				serverID = server.Server.ID
+			}
+
+			var stopped, unavailable bool
+			serverManager := server.NewMockManager()
+			serverManager.SetStopInstanceHandler(func(_ context.Context, _ string) error {
+				stopped = true
+				return nil
+			})
+			serverManager.SetMakeInstanceUnavailableHandler(func(_ context.Context, id string) error {
+				require.Equal(t, "recover-association-id", id)
+				unavailable = true
+				return nil
+			})
+
+			controller := &Controller{
+				logger: zap.NewNop(),
+				store:  store,
+				serverDirector: NewServerDirector(
+					serverManager,
+					serverManager,
+					serverManager,
+				),
+				eventStream: stream.NewClientMock(
+					stream.WithWrite(func(_ context.Context, _ []byte) error { return nil }),
+				),
+			}
+
+			err = controller.RecoverServers(ctx)
+			require.Nil(t, err)
+
+			recovered, err := db.GetServer(ctx, store, serverID)
+			require.Nil(t, err)
+			defer func() {
+				err = store.WithContext(ctx).Delete(recovered).Error
+				require.Nil(t, err)
+			}()
+
+			require.Equal(t, test.exp.lifecycle, recovered.Lifecycle)
+			require.Equal(t, test.exp.live, recovered.IsLive())
+			require.Equal(t, test.exp.stopped, stopped)
+			require.Equal(t, test.exp.unavailable, unavailable)
+			if test.exp.reasonPattern != nil {
+				require.Regexp(t, test.exp.reasonPattern, recovered.LifecycleReason)
+			}
+		})
+	}
+}
+
+func TestDecommissionServer(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	type expected struct {
+		calls  []string
+		failed bool
+	}
+	tests := map[string]struct {
+		deleteErr  error
+		releaseErr error
+		exp        expected
+	}{
+		"decommission": {
+			exp: expected{calls: []string{"delete instance-ID", "release allocation-ID"}},
+		},
+		"resources dne": {
+			deleteErr:  server.ErrInstanceDNE,
+			releaseErr: server.ErrAddressDNE,
+			exp:        expected{calls: []string{"delete instance-ID", "release allocation-ID"}},
+		},
+		"release fails": {
+			releaseErr: errors.New("release failed"),
+			exp: expected{
+				calls:  []string{"delete instance-ID", "release allocation-ID", "release allocation-ID"},
+				failed: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			store, err := db.Open(dsn)
+			require.Nil(t, err)
+
+			err = db.Migrate(store, migrations)
+			require.Nil(t, err)
+
+			archived := &model.ArchivedServer{Server: *zeroServer.Clone()}
+			err = store.WithContext(ctx).Create(archived).Error
+			require.Nil(t, err)
+			defer func() {
+				err = store.WithContext(ctx).Delete(&archived.Server).Error
+				require.Nil(t, err)
+			}()
+
+			calls := make([]string, 0)
+			serverManager := server.NewMockManager()
+			serverManager.SetDeleteInstanceHandler(func(_ context.Context, id string) error {
+				calls = append(calls, "delete "+id)
+				return test.deleteErr
+			})
+			serverManager.SetReleaseAddressHandler(func(_ context.Context, id string) error {
+				calls = append(calls, "release "+id)
+				return test.releaseErr
+			})
+
+			controller := &Controller{
+				logger: zap.NewNop(),
+				store:  store,
+				serverDirector: NewServerDirector(
+					serverManager,
+					serverManager,
+					serverManager,
+				),
+			}
+
+			// Decommissioning is retried, released resources are not released
+			// again.
+			for i := 0; i < 2; i++ {
+				_, err = controller.DecommissionServer(ctx, archived.Server.ID)
+				require.Equal(t, test.exp.failed, err != nil)
+			}
+			require.Equal(t, test.exp.calls, calls)
+
+			decommissioned, err := db.GetArchivedServer(ctx, store, archived.Server.ID)
+			require.Nil(t, err)
+			require.Equal(t, !test.exp.failed, decommissioned.IsDecommissioned())
+			require.Empty(t, decommissioned.Server.InstanceID)
+			require.Equal(t, test.exp.failed, decommissioned.Server.AllocationID != "")
+		})
+	}
+}
+
+func TestRconServerAdmins(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		commands []string
+	}
+	tests := map[string]struct {
+		options []rcontest.Option
+		call    func(context.Context, *Controller) error
+		exp     expected
+	}{
+		"add moderators": {
+			call: func(ctx context.Context, ctrl *Controller) error {
+				return ctrl.rconAddServerModerators(
+					ctx,
+					"elastic-IP",
+					"rcon-password",
+					model.Moderators{{SteamID: "76561197962911631"}, {SteamID: "76561197962911632"}},
+				)
+			},
+			exp: expected{
+				commands: []string{
+					`global.moderatorid "76561197962911631"`,
+					`global.moderatorid "76561197962911632"`,
+				},
+			},
+		},
+		"add existing moderator": {
+			options: []rcontest.Option{rcontest.WithModerators("76561197962911631")},
+			call: func(ctx context.Context, ctrl *Controller) error {
+				return ctrl.rconAddServerModerators(
+					ctx,
+					"elastic-IP",
+					"rcon-password",
+					model.Moderators{{SteamID: "76561197962911631"}},
+				)
+			},
+			exp: expected{
+				commands: []string{`global.moderatorid "76561197962911631"`},
+			},
+		},
+		"remove moderators": {
+			options: []rcontest.Option{rcontest.WithModerators("76561197962911631")},
+			call: func(ctx context.Context, ctrl *Controller) error {
+				return ctrl.rconRemoveServerModerators(
+					ctx,
+					"elastic-IP",
+					"rcon-password",
+					model.Moderators{{SteamID: "76561197962911631"}},
+				)
+			},
+			exp: expected{
+				commands: []string{`global.removemoderator "76561197962911631"`},
+			},
+		},
+		"add owners": {
+			call: func(ctx context.Context, ctrl *Controller) error {
+				return ctrl.rconAddServerOwners(
+					ctx,
+					"elastic-IP",
+					"rcon-password",
+					model.Owners{{SteamID: "76561197962911631"}},
+				)
+			},
+			exp: expected{
+				commands: []string{`global.ownerid "76561197962911631"`},
+			},
+		},
+		"remove non-existent owner": {
+			call: func(ctx context.Context, ctrl *Controller) error {
+				return ctrl.rconRemoveServerOwners(
+					ctx,
+					"elastic-IP",
+					"rcon-password",
+					model.Owners{{SteamID: "76561197962911631"}},
+				)
+			},
+			exp: expected{
+				commands: []string{`global.removeowner "76561197962911631"`},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			options := append(test.options, rcontest.WithPassword("rcon-password"))
+			rconServer := rcontest.NewServer(options...)
+			defer rconServer.Close()
+
+			hub := newRcontestHub(rconServer)
+			defer hub.Close()
+
+			controller := &Controller{
+				logger: zap.NewNop(),
+				hub:    hub,
+			}
+
+			err := test.call(ctx, controller)
+			require.Nil(t, err)
+			require.Equal(t, test.exp.commands, rconServer.Commands())
+		})
+	}
+}
+
+func TestExpireServerVips(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := db.Open(dsn)
+	require.Nil(t, err)
+
+	err = db.Migrate(store, migrations)
+	require.Nil(t, err)
+
+	now := time.Now()
+	liveServer := &model.LiveServer{Server: *alphaServer.Clone()}
+	liveServer.Server.Vips = model.Vips{
+		{SteamID: "76561197962911631", ExpiresAt: now.Add(-time.Hour)},
+		{SteamID: "76561197962911632", ExpiresAt: now.Add(-time.Minute)},
+		{SteamID: "76561197962911633", ExpiresAt: now.Add(time.Hour)},
+		{
+			SteamID:   "76561197962911634",
+			ExpiresAt: now.Add(-2 * time.Hour),
+			RevokedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		},
+	}
+	err = store.WithContext(ctx).Create(liveServer).Error
+	require.Nil(t, err)
+	defer func() {
+		err = store.WithContext(ctx).Delete(liveServer).Error
+		require.Nil(t, err)
+	}()
+
+	// 76561197962911632 expired while the server was offline, and is not a
+	// member of the vip group.
+	rconServer := rcontest.NewServer(
+		rcontest.WithPassword("rcon-password"),
+		rcontest.WithGroup(rcon.VipGroup, "76561197962911631", "76561197962911633"),
+	)
+	defer rconServer.Close()
+
+	hub := newRcontestHub(rconServer)
+	defer hub.Close()
+
+	client, err := hub.Dial(ctx, "elastic-IP:28016", "rcon-password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	expired := make([]string, 0)
+	eventStream := stream.NewClientMock(
+		stream.WithWrite(func(_ context.Context, b []byte) error {
+			var event event.VipExpiredEvent
+			err := json.Unmarshal(b, &event)
+			require.Nil(t, err)
+			require.Equal(t, liveServer.Server.ID, event.ServerID)
+
+			expired = append(expired, event.SteamID)
+			return nil
+		}),
+	)
+
+	controller := &Controller{
+		logger:      zap.NewNop(),
+		store:       store,
+		time:        itime.NewMock(now),
+		eventStream: eventStream,
+	}
+
+	// Revoked Vips are not revoked again.
+	for i := 0; i < 2; i++ {
+		err = controller.ExpireServerVips(ctx, *liveServer, client)
+		require.Nil(t, err)
+	}
+	require.Equal(t, []string{"76561197962911631", "76561197962911632"}, expired)
+	require.Equal(
+		t,
+		[]string{
+			`oxide.usergroup remove 76561197962911631 vip`,
+			`oxide.usergroup remove 76561197962911632 vip`,
+		},
+		rconServer.Commands(),
+	)
+
+	vips, err := db.ListVipsByServerID(ctx, store, liveServer.Server.ID)
+	require.Nil(t, err)
+
+	revoked := make(map[string]bool)
+	for _, vip := range vips {
+		revoked[vip.SteamID] = vip.RevokedAt.Valid
+	}
+	require.Equal(
+		t,
+		map[string]bool{
+			"76561197962911631": true,
+			"76561197962911632": true,
+			"76561197962911633": false,
+			"76561197962911634": true,
+		},
+		revoked,
+	)
+}
+
+func TestExpireServerVipsStreamFailure(t *testing.T) {
+	switch {
+	case dsn == "":
+		t.Skip("CRONMAN_DSN must be set to execute this test.")
+	case migrations == "":
+		t.Skip("CRONMAN_MIGRATIONS must be set to execute this test.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := db.Open(dsn)
+	require.Nil(t, err)
+
+	err = db.Migrate(store, migrations)
+	require.Nil(t, err)
+
+	now := time.Now()
+	liveServer := &model.LiveServer{Server: *alphaServer.Clone()}
+	liveServer.Server.Vips = model.Vips{
+		{SteamID: "76561197962911631", ExpiresAt: now.Add(-time.Hour)},
+	}
+	err = store.WithContext(ctx).Create(liveServer).Error
+	require.Nil(t, err)
+	defer func() {
+		err = store.WithContext(ctx).Delete(liveServer).Error
+		require.Nil(t, err)
+	}()
+
+	rconServer := rcontest.NewServer(
+		rcontest.WithPassword("rcon-password"),
+		rcontest.WithGroup(rcon.VipGroup, "76561197962911631"),
+	)
+	defer rconServer.Close()
+
+	hub := newRcontestHub(rconServer)
+	defer hub.Close()
+
+	client, err := hub.Dial(ctx, "elastic-IP:28016", "rcon-password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	errStream := errors.New("stream unavailable")
+	writes := 0
+	eventStream := stream.NewClientMock(
+		stream.WithWrite(func(_ context.Context, _ []byte) error {
+			writes++
+			if writes == 1 {
+				return errStream
+			}
+			return nil
+		}),
+	)
+
+	controller := &Controller{
+		logger:      zap.NewNop(),
+		store:       store,
+		time:        itime.NewMock(now),
+		eventStream: eventStream,
+	}
+
+	// The Vip is not revoked until its VipExpired event is written.
+	err = controller.ExpireServerVips(ctx, *liveServer, client)
+	require.ErrorIs(t, err, errStream)
+
+	vips, err := db.ListExpiredVips(ctx, store, liveServer.Server.ID, now)
+	require.Nil(t, err)
+	require.Len(t, vips, 1)
+
+	err = controller.ExpireServerVips(ctx, *liveServer, client)
+	require.Nil(t, err)
+	require.Equal(t, 2, writes)
+
+	vips, err = db.ListExpiredVips(ctx, store, liveServer.Server.ID, now)
+	require.Nil(t, err)
+	require.Empty(t, vips)
+}
+
+func TestReconcileServerAdmins(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		changes  AdminChanges
+		commands []string
+	}
+	tests := map[string]struct {
+		options           []rcontest.Option
+		owners            []string
+		moderators        []string
+		removedOwners     []string
+		removedModerators []string
+		exp               expected
+	}{
+		"in sync": {
+			options:    []rcontest.Option{rcontest.WithOwners("76561197962911631"), rcontest.WithModerators("76561197962911632")},
+			owners:     []string{"76561197962911631"},
+			moderators: []string{"76561197962911632"},
+			exp: expected{
+				commands: []string{`global.ownerid "76561197962911631"`, `global.moderatorid "76561197962911632"`},
+			},
+		},
+		"drifted adds": {
+			owners:     []string{"76561197962911631"},
+			moderators: []string{"76561197962911632"},
+			exp: expected{
+				changes: AdminChanges{
+					AddedOwners:     []string{"76561197962911631"},
+					AddedModerators: []string{"76561197962911632"},
+				},
+				commands: []string{`global.ownerid "76561197962911631"`, `global.moderatorid "76561197962911632"`},
+			},
+		},
+		"drifted removals": {
+			options:           []rcontest.Option{rcontest.WithOwners("76561197962911631"), rcontest.WithModerators("76561197962911632")},
+			removedOwners:     []string{"76561197962911631"},
+			removedModerators: []string{"76561197962911632"},
+			exp: expected{
+				changes: AdminChanges{
+					RemovedOwners:     []string{"76561197962911631"},
+					RemovedModerators: []string{"76561197962911632"},
+				},
+				commands: []string{`global.removeowner "76561197962911631"`, `global.removemoderator "76561197962911632"`},
+			},
+		},
+		"removals already applied": {
+			removedOwners:     []string{"76561197962911631"},
+			removedModerators: []string{"76561197962911632"},
+			exp: expected{
+				commands: []string{`global.removeowner "76561197962911631"`, `global.removemoderator "76561197962911632"`},
+			},
+		},
+		"moderator promoted to owner": {
+			options:           []rcontest.Option{rcontest.WithModerators("76561197962911633")},
+			owners:            []string{"76561197962911633"},
+			removedModerators: []string{"76561197962911633"},
+			exp: expected{
+				changes: AdminChanges{
+					AddedOwners:       []string{"76561197962911633"},
+					RemovedModerators: []string{"76561197962911633"},
+				},
+				commands: []string{`global.removemoderator "76561197962911633"`, `global.ownerid "76561197962911633"`},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			options := append(test.options, rcontest.WithPassword("rcon-password"))
+			rconServer := rcontest.NewServer(options...)
+			defer rconServer.Close()
+
+			hub := newRcontestHub(rconServer)
+			defer hub.Close()
+
+			client, err := hub.Dial(ctx, "elastic-IP:28016", "rcon-password")
+			require.Nil(t, err)
+			defer client.Close()
+
+			changes, err := reconcileServerAdmins(
+				ctx,
+				client,
+				test.owners,
+				test.moderators,
+				test.removedOwners,
+				test.removedModerators,
+			)
+			require.Nil(t, err)
+			require.Equal(t, test.exp.changes, changes)
+			require.Equal(t, test.exp.commands, rconServer.Commands())
+		})
+	}
+}
+
+func TestSayServerTimeRemainingRcontest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rconServer := rcontest.NewServer(rcontest.WithPassword("rcon-password"))
+	defer rconServer.Close()
+
+	hub := newRcontestHub(rconServer)
+	defer hub.Close()
+
+	now := time.Now()
+	timeMock := itime.NewMock(now)
+	timeMock.SetUntil(30 * time.Minute)
+
+	when := now.Add(30 * time.Minute)
+	server := model.LiveServer{
+		Server: model.Server{
+			Name: "Rustpm Test Server",
+			Events: model.Events{
+				{
+					Schedule: fmt.Sprintf("%d %d * * *", when.Minute(), when.Hour()),
+					Kind:     model.EventKindStop,
+				},
+			},
+		},
+	}
+
+	controller := &Controller{
+		logger: zap.NewNop(),
+		time:   timeMock,
+		hub:    hub,
+	}
+
+	client, err := hub.Dial(ctx, "elastic-IP:28016", "rcon-password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	chatc, err := client.Subscribe(ctx, rcon.FilterKinds(rcon.InboundKindChat))
+	require.Nil(t, err)
+
+	err = controller.SayServerTimeRemaining(ctx, server, client)
+	require.Nil(t, err)
+
+	in := <-chatc
+	chat, err := in.Chat()
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		"Rustpm Test Server will be going offline in 30 minutes. Visit rustpm.com for more scheduling information.",
+		chat.Message,
+	)
+}
+
+func TestCountdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rconServer := rcontest.NewServer(rcontest.WithPassword("rcon-password"))
+	defer rconServer.Close()
+
+	hub := newRcontestHub(rconServer)
+	defer hub.Close()
+
+	now := time.Now()
+	timeMock := itime.NewMock(now)
+
+	controller := &Controller{
+		logger: zap.NewNop(),
+		time:   timeMock,
+		hub:    hub,
+	}
+
+	client, err := hub.Dial(ctx, "elastic-IP:28016", "rcon-password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	chatc, err := client.Subscribe(ctx, rcon.FilterKinds(rcon.InboundKindChat))
+	require.Nil(t, err)
+
+	broadcasts := []model.Broadcast{
+		{At: now.Add(-time.Minute), Message: "missed"},
+		{At: now, Message: "now"},
+		{At: now.Add(5 * time.Minute), Message: "in 5 minutes"},
+		{At: now.Add(10 * time.Minute), Message: "in 10 minutes"},
+	}
+	err = controller.countdown(ctx, client, broadcasts)
+	require.Nil(t, err)
+
+	for _, exp := range []string{"now", "in 5 minutes", "in 10 minutes"} {
+		in := <-chatc
+		chat, err := in.Chat()
+		require.Nil(t, err)
+		require.Equal(t, exp, chat.Message)
+	}
+	require.Equal(t, now.Add(10*time.Minute), timeMock.Now())
+}
+
+// rcontestHub is an IHub that dials an rcontest.Server regardless of the url
+// requested. This allows Controller functionality to be exercised against a
+// real rcon.Client.
+type rcontestHub struct {
+	*rcon.Hub
+	server *rcontest.Server
+}
+
+func newRcontestHub(server *rcontest.Server) *rcontestHub {
+	return &rcontestHub{
+		Hub:    rcon.NewHub(zap.NewNop()),
+		server: server,
+	}
+}
+
+func (h rcontestHub) Dial(ctx context.Context, _, password string) (rcon.IRcon, error) {
+	return h.Hub.Dial(ctx, h.server.Addr(), password)
+}
+
 // alphaServer is a generic server definition that is used by multiple tests.
 // Before updating please review the affected tests.
 var alphaServer = model.Server{
@@ -1110,7 +2367,11 @@ var alphaServer = model.Server{
 	URL:          "https://rustpm.com",
 	BannerURL:    "https://rustpm.com",
 	Region:       model.RegionUsEast,
+	TimeZone:     "UTC",
 	Options:      map[string]interface{}{},
+	Lifecycle:    model.LifecycleIdle,
+	Bans:         model.Bans{},
+	Plugins:      model.ServerPlugins{},
 	Wipes: model.Wipes{
 		{Kind: model.WipeKindFull, MapSeed: 3000, MapSalt: 4000},
 	},
@@ -1119,6 +2380,7 @@ var alphaServer = model.Server{
 		{Kind: model.EventKindLive, Schedule: "0 12 * * *"},
 		{Kind: model.EventKindStop, Schedule: "0 23 * *"},
 	},
+	Blackouts: model.Blackouts{},
 	Owners: model.Owners{
 		{SteamID: "76561197962911631"},
 	},
@@ -1144,9 +2406,14 @@ var zeroServer = model.Server{
 	URL:          "https://rustpm.com",
 	BannerURL:    "https://rustpm.com",
 	Region:       model.RegionUsEast,
+	TimeZone:     "UTC",
 	Options:      map[string]interface{}{},
+	Lifecycle:    model.LifecycleIdle,
+	Bans:         model.Bans{},
+	Plugins:      model.ServerPlugins{},
 	Wipes:        model.Wipes{},
 	Events:       model.Events{},
+	Blackouts:    model.Blackouts{},
 	Owners:       model.Owners{},
 	Moderators:   model.Moderators{},
 	Tags:         model.Tags{},
@@ -22,11 +22,13 @@ type StreamWriter interface {
 // IServerManager represents the API by which the Controller interacts with
 // Rust servers.
 type IServerManager interface {
-	CreateInstance(ctx context.Context, template model.InstanceKind) (*server.CreateInstanceOutput, error)
+	CreateInstance(ctx context.Context, template model.InstanceKind, serverID uuid.UUID) (*server.CreateInstanceOutput, error)
 	StartInstance(ctx context.Context, id string, userdata string) error
 	StopInstance(ctx context.Context, id string) error
 	MakeInstanceAvailable(ctx context.Context, instanceID, allocationID string) (*server.AssociationOutput, error)
 	MakeInstanceUnavailable(ctx context.Context, associationID string) error
+	DeleteInstance(ctx context.Context, instanceID string) error
+	ReleaseAddress(ctx context.Context, allocationID string) error
 }
 
 // ITime represents the API by which the cronman Controller interacts with
@@ -35,6 +37,7 @@ type IServerManager interface {
 type ITime interface {
 	Now() time.Time
 	Until(time.Time) time.Duration
+	After(time.Duration) <-chan time.Time
 }
 
 // IHub represents the API by which IRcon types may be created.
@@ -40,6 +40,14 @@ var (
 	// ErrServerDNE indicates an operation was performed on a server that does
 	// not exist.
 	ErrServerDNE = errors.New("server does not exist")
+
+	// ErrNoJobs indicates there are no jobs available to be claimed.
+	ErrNoJobs = errors.New("no jobs available")
+
+	// ErrJobAttemptStale indicates the attempt at a job is no longer the
+	// job's current attempt, the job has since been claimed by another
+	// runner or finished.
+	ErrJobAttemptStale = errors.New("job attempt stale")
 )
 
 // UpdateLiveServerInfo encompasses all logic to update the server info of a
@@ -134,6 +142,7 @@ func (f *FindDormantServer) Find(ctx context.Context, db *gorm.DB) error {
 		Preload("Wipes").
 		Preload("Tags").
 		Preload("Events").
+		Preload("Blackouts").
 		Preload("Moderators").
 		Preload("Vips").
 		First(&server, f.ServerID).Error
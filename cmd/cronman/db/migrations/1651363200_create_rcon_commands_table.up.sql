@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS servers.rcon_commands (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+  user_id   UUID NOT NULL,
+
+  command       VARCHAR NOT NULL,
+  response      VARCHAR NOT NULL,
+  response_type VARCHAR NOT NULL,
+  error         VARCHAR NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (server_id) REFERENCES servers.servers (id)
+);
+
+CREATE INDEX IF NOT EXISTS rcon_commands_server_id_created_at_idx
+  ON servers.rcon_commands (server_id, created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS servers.bans (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+
+  steam_id VARCHAR NOT NULL,
+  reason   VARCHAR NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (server_id) REFERENCES servers.servers (id)
+);
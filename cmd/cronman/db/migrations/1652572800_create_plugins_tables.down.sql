@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS servers.server_plugins;
+DROP TABLE IF EXISTS servers.plugin_grants;
+DROP TABLE IF EXISTS servers.plugins;
@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS servers.plugins (
+  id UUID NOT NULL DEFAULT gen_random_uuid(),
+
+  name    VARCHAR NOT NULL,
+  url     VARCHAR NOT NULL,
+  version VARCHAR NOT NULL,
+  "default" BOOLEAN NOT NULL DEFAULT FALSE,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS plugins_name_idx
+  ON servers.plugins (name)
+  WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS servers.plugin_grants (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  plugin_id UUID NOT NULL,
+
+  "group"    VARCHAR NOT NULL,
+  permission VARCHAR NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (plugin_id) REFERENCES servers.plugins (id)
+);
+
+CREATE TABLE IF NOT EXISTS servers.server_plugins (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+  plugin_id UUID NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (server_id) REFERENCES servers.servers (id),
+  FOREIGN KEY (plugin_id) REFERENCES servers.plugins (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS server_plugins_server_id_plugin_id_idx
+  ON servers.server_plugins (server_id, plugin_id)
+  WHERE deleted_at IS NULL;
+
+-- Seed the catalog with the plugins previously installed on every server.
+INSERT INTO servers.plugins (name, url, version, "default", created_at, updated_at) VALUES
+  ('BypassQueue', 'https://umod.org/plugins/BypassQueue.cs', 'latest', TRUE, NOW(), NOW()),
+  ('Vanish', 'https://umod.org/plugins/Vanish.cs', 'latest', TRUE, NOW(), NOW()),
+  ('AdminRadar', 'https://umod.org/plugins/AdminRadar.cs', 'latest', TRUE, NOW(), NOW());
+
+INSERT INTO servers.plugin_grants (plugin_id, "group", permission, created_at, updated_at)
+SELECT plugins.id, grants."group", grants.permission, NOW(), NOW()
+FROM servers.plugins
+JOIN (VALUES
+  ('BypassQueue', 'vip', 'bypassqueue.allow'),
+  ('Vanish', 'admin', 'vanish.allow'),
+  ('AdminRadar', 'admin', 'adminradar.allowed'),
+  ('AdminRadar', 'admin', 'adminradar.bypass')
+) AS grants (plugin, "group", permission) ON grants.plugin = plugins.name;
+
+-- Enable the seeded plugins on existing servers, preserving their behaviour.
+INSERT INTO servers.server_plugins (server_id, plugin_id, created_at, updated_at)
+SELECT servers.id, plugins.id, NOW(), NOW()
+FROM servers.servers
+CROSS JOIN servers.plugins;
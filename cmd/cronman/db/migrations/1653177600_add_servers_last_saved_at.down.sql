@@ -0,0 +1 @@
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS last_saved_at;
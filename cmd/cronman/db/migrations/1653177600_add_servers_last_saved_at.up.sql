@@ -0,0 +1 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS last_saved_at TIMESTAMP WITH TIME ZONE;
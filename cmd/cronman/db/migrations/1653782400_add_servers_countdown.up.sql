@@ -0,0 +1,6 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS countdown JSONB NOT NULL DEFAULT '{
+  "warnings": [600, 300, 60],
+  "finalSeconds": 10,
+  "warningTemplate": "{{.Server}} will be {{.Action}} in {{.Remaining}}. Visit rustpm.com for more scheduling information.",
+  "finalTemplate": "{{.Server}} will be {{.Action}} in {{.Remaining}}..."
+}'::JSONB;
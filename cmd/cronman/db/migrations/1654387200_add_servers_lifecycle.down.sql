@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS servers.servers_lifecycle_idx;
+
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS lifecycle_reason;
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS lifecycle_changed_at;
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS lifecycle;
@@ -0,0 +1,5 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS lifecycle VARCHAR(16) NOT NULL DEFAULT 'idle';
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS lifecycle_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS lifecycle_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS servers_lifecycle_idx ON servers.servers (lifecycle);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS servers.jobs (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  kind      VARCHAR(32) NOT NULL,
+  server_id UUID NOT NULL,
+  payload   JSONB NOT NULL DEFAULT '{}'::JSONB,
+  status    VARCHAR(16) NOT NULL DEFAULT 'pending',
+
+  step            VARCHAR NOT NULL DEFAULT '',
+  steps_completed SMALLINT NOT NULL DEFAULT 0,
+  steps           SMALLINT NOT NULL DEFAULT 0,
+
+  error    VARCHAR NOT NULL DEFAULT '',
+  attempts SMALLINT NOT NULL DEFAULT 0,
+
+  heartbeat_at TIMESTAMP WITH TIME ZONE,
+  started_at   TIMESTAMP WITH TIME ZONE,
+  finished_at  TIMESTAMP WITH TIME ZONE,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS jobs_status_created_at_idx
+  ON servers.jobs (status, created_at);
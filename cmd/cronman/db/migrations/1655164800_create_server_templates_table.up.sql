@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS servers.server_templates (
+  id     UUID NOT NULL DEFAULT gen_random_uuid(),
+  name   VARCHAR NOT NULL,
+  config JSONB NOT NULL DEFAULT '{}'::JSONB,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS server_templates_name_idx
+  ON servers.server_templates (name)
+  WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE servers.archived_servers DROP COLUMN IF EXISTS decommissioned_at;
@@ -0,0 +1 @@
+ALTER TABLE servers.archived_servers ADD COLUMN IF NOT EXISTS decommissioned_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+ALTER TABLE servers.jobs
+  DROP COLUMN IF EXISTS actor;
+
+DROP TABLE IF EXISTS servers.audit_entries;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS servers.audit_entries (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+  actor     JSONB NOT NULL DEFAULT '{}'::JSONB,
+  action    VARCHAR(32) NOT NULL,
+  changes   JSONB NOT NULL DEFAULT '{}'::JSONB,
+  outcome   VARCHAR(16) NOT NULL,
+  error     VARCHAR NOT NULL DEFAULT '',
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_entries_server_id_created_at_idx
+  ON servers.audit_entries (server_id, created_at DESC);
+
+-- Audit entries are append-only.
+CREATE OR REPLACE RULE audit_entries_no_update AS
+  ON UPDATE TO servers.audit_entries DO INSTEAD NOTHING;
+CREATE OR REPLACE RULE audit_entries_no_delete AS
+  ON DELETE TO servers.audit_entries DO INSTEAD NOTHING;
+
+ALTER TABLE servers.jobs
+  ADD COLUMN IF NOT EXISTS actor JSONB NOT NULL DEFAULT '{}'::JSONB;
@@ -0,0 +1 @@
+ALTER TABLE servers.vips DROP COLUMN IF EXISTS revoked_at;
@@ -0,0 +1 @@
+ALTER TABLE servers.vips ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS servers.blackouts;
+ALTER TABLE servers.events DROP COLUMN IF EXISTS run_at;
@@ -0,0 +1,19 @@
+ALTER TABLE servers.events ADD COLUMN IF NOT EXISTS run_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS servers.blackouts (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  server_id UUID NOT NULL,
+
+  starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  ends_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  kind      VARCHAR NOT NULL DEFAULT '',
+  reason    VARCHAR NOT NULL DEFAULT '',
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (server_id) REFERENCES servers.servers (id),
+  CHECK (ends_at > starts_at)
+);
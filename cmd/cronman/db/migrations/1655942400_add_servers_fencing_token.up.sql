@@ -0,0 +1 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS fencing_token BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS servers.executions (
+  id        UUID NOT NULL DEFAULT gen_random_uuid(),
+  event_id  UUID NOT NULL,
+  server_id UUID NOT NULL,
+  kind      VARCHAR(32) NOT NULL,
+  actor     JSONB NOT NULL DEFAULT '{}'::JSONB,
+
+  scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  started_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  finished_at  TIMESTAMP WITH TIME ZONE,
+
+  outcome  VARCHAR(16) NOT NULL DEFAULT 'running',
+  error    VARCHAR NOT NULL DEFAULT '',
+  retry_of UUID REFERENCES servers.executions (id),
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS executions_server_id_started_at_idx
+  ON servers.executions (server_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS executions_started_at_idx
+  ON servers.executions (started_at DESC);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS servers.executions_retry_of_unique_idx;
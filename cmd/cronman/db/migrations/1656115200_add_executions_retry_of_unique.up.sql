@@ -0,0 +1,5 @@
+-- A failed execution may only have a single retry that is running or has
+-- succeeded.
+CREATE UNIQUE INDEX IF NOT EXISTS executions_retry_of_unique_idx
+  ON servers.executions (retry_of)
+  WHERE outcome <> 'failed';
@@ -0,0 +1 @@
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS lifecycle_heartbeat_at;
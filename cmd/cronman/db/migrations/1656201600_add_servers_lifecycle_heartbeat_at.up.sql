@@ -0,0 +1 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS lifecycle_heartbeat_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1 @@
+ALTER TABLE servers.servers DROP COLUMN IF EXISTS instance_started_at;
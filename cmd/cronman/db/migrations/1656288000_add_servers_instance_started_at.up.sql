@@ -0,0 +1,4 @@
+ALTER TABLE servers.servers ADD COLUMN IF NOT EXISTS instance_started_at TIMESTAMP WITH TIME ZONE;
+
+-- Live servers' instances are running.
+UPDATE servers.servers SET instance_started_at = NOW() WHERE state_type = 'servers.live_servers';
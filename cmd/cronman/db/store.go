@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func UpdateServer(
@@ -40,6 +42,9 @@ func UpdateServer(
 	return GetDormantServer(ctx, db, id)
 }
 
+// WipeServer creates the wipe for the server specified by serverID. If the
+// wipe has an ID and a wipe with that ID already exists, the wipe has already
+// been created and nothing is done.
 func WipeServer(ctx context.Context, db *gorm.DB, serverID uuid.UUID, wipe model.Wipe) error {
 	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var server model.Server
@@ -47,6 +52,20 @@ func WipeServer(ctx context.Context, db *gorm.DB, serverID uuid.UUID, wipe model
 			return err
 		}
 
+		if wipe.ID != uuid.Nil {
+			var wipes int64
+			if err := tx.
+				Unscoped().
+				Model(&model.Wipe{}).
+				Where("id = ?", wipe.ID).
+				Count(&wipes).Error; err != nil {
+				return err
+			}
+			if wipes > 0 {
+				return nil
+			}
+		}
+
 		wipe.ServerID = server.ID
 		return tx.Create(&wipe).Error
 	})
@@ -59,6 +78,7 @@ func ListServers(ctx context.Context, db *gorm.DB, dst interface{}) error {
 		Preload("Server.Wipes").
 		Preload("Server.Tags").
 		Preload("Server.Events").
+		Preload("Server.Blackouts").
 		Preload("Server.Moderators").
 		Order("created_at DESC").
 		Find(dst); res.Error != nil {
@@ -68,20 +88,19 @@ func ListServers(ctx context.Context, db *gorm.DB, dst interface{}) error {
 	return nil
 }
 
-func ListActiveServerEvents(ctx context.Context, db *gorm.DB) (model.Events, error) {
-	events := make(model.Events, 0)
+// ListActiveServerEvents retrieves the Events of live and dormant servers,
+// along with the time zone of each Event's server.
+func ListActiveServerEvents(ctx context.Context, db *gorm.DB) ([]model.ZonedEvent, error) {
+	events := make([]model.ZonedEvent, 0)
 	if res := db.
+		WithContext(ctx).
 		Model(&model.Event{}).
+		Select("events.*, servers.time_zone").
+		Joins("JOIN servers.servers ON servers.id = events.server_id").
+		Where("servers.deleted_at IS NULL").
 		Where(
-			"EXISTS (?)",
-			db.
-				Model(&model.Server{}).
-				Select("1").
-				Where("servers.id = events.server_id").
-				Where(
-					db.Where("servers.state_type = ?", model.LiveServerState).
-						Or("servers.state_type = ?", model.DormantServerState),
-				),
+			db.Where("servers.state_type = ?", model.LiveServerState).
+				Or("servers.state_type = ?", model.DormantServerState),
 		).
 		Find(&events); res.Error != nil {
 		return nil, res.Error
@@ -89,6 +108,142 @@ func ListActiveServerEvents(ctx context.Context, db *gorm.DB) (model.Events, err
 	return events, nil
 }
 
+// ListActiveServerBlackouts retrieves the Blackouts of live and dormant
+// servers that have not ended as of now.
+func ListActiveServerBlackouts(ctx context.Context, db *gorm.DB, now time.Time) ([]model.Blackout, error) {
+	blackouts := make([]model.Blackout, 0)
+	if res := db.
+		WithContext(ctx).
+		Model(&model.Blackout{}).
+		Select("blackouts.*").
+		Joins("JOIN servers.servers ON servers.id = blackouts.server_id").
+		Where("servers.deleted_at IS NULL").
+		Where("blackouts.ends_at > ?", now).
+		Where(
+			db.Where("servers.state_type = ?", model.LiveServerState).
+				Or("servers.state_type = ?", model.DormantServerState),
+		).
+		Find(&blackouts); res.Error != nil {
+		return nil, res.Error
+	}
+	return blackouts, nil
+}
+
+// AddServerEvents adds the events to the server specified by id. The
+// schedule of the server including the events is validated as of at within
+// the same transaction; if it has errors, an error wrapping a
+// *model.ScheduleError is returned and the events are not added. The
+// schedule's warnings are returned.
+func AddServerEvents(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	events model.Events,
+	at time.Time,
+) (model.ScheduleViolations, error) {
+	var warnings model.ScheduleViolations
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		server, err := lockServerSchedule(tx, id)
+		if err != nil {
+			return err
+		}
+		server.Events = append(server.Events, events...)
+
+		warnings, err = validateServerSchedule(*server, at)
+		if err != nil {
+			return err
+		}
+		return tx.Create(events).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add server events; id: %s, error: %w", id, err)
+	}
+	return warnings, nil
+}
+
+// RemoveServerEvents removes the events specified by eventIDs from the server
+// specified by id. The schedule of the server without the events is validated
+// as of at within the same transaction; if it has errors, an error wrapping a
+// *model.ScheduleError is returned and the events are not removed. The
+// schedule's warnings are returned.
+func RemoveServerEvents(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	eventIDs []uuid.UUID,
+	at time.Time,
+) (model.ScheduleViolations, error) {
+	removed := make(map[uuid.UUID]struct{}, len(eventIDs))
+	for _, eventID := range eventIDs {
+		removed[eventID] = struct{}{}
+	}
+
+	var warnings model.ScheduleViolations
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		server, err := lockServerSchedule(tx, id)
+		if err != nil {
+			return err
+		}
+		remaining := make(model.Events, 0, len(server.Events))
+		for _, event := range server.Events {
+			if _, ok := removed[event.ID]; !ok {
+				remaining = append(remaining, event)
+			}
+		}
+		server.Events = remaining
+
+		warnings, err = validateServerSchedule(*server, at)
+		if err != nil {
+			return err
+		}
+		return tx.
+			Where("server_id = ?", id).
+			Delete(&model.Event{}, eventIDs).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remove server events; id: %s, error: %w", id, err)
+	}
+	return warnings, nil
+}
+
+// lockServerSchedule locks the server specified by id, so that its schedule
+// may not be changed concurrently, and retrieves it with its events and
+// blackouts.
+func lockServerSchedule(tx *gorm.DB, id uuid.UUID) (*model.Server, error) {
+	var server model.Server
+	res := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&server, id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, cronmanerrors.ErrServerDNE
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if err := tx.Where("server_id = ?", id).Find(&server.Events).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("server_id = ?", id).Find(&server.Blackouts).Error; err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// validateServerSchedule validates the schedule of the server as of at. If
+// the schedule has errors, an error wrapping a *model.ScheduleError is
+// returned, otherwise the schedule's warnings are returned.
+func validateServerSchedule(server model.Server, at time.Time) (model.ScheduleViolations, error) {
+	violations, err := server.ValidateSchedule(at)
+	if err != nil {
+		return nil, err
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	return violations.Warnings(), nil
+}
+
 func ListVipsByServerID(ctx context.Context, db *gorm.DB, serverID uuid.UUID) (model.Vips, error) {
 	var vips model.Vips
 	if err := db.WithContext(ctx).Where("server_id = ?", serverID).Find(&vips).Error; err != nil {
@@ -108,6 +263,115 @@ func GetVipByServerIDAndSteamID(ctx context.Context, db *gorm.DB, serverID uuid.
 	return &vip, nil
 }
 
+// ListExpiredVips retrieves the Vips of the specified server that expired
+// prior to now, and have yet to be revoked.
+func ListExpiredVips(ctx context.Context, db *gorm.DB, serverID uuid.UUID, now time.Time) (model.Vips, error) {
+	vips := make(model.Vips, 0)
+	if err := db.
+		WithContext(ctx).
+		Where("server_id = ? AND expires_at <= ? AND revoked_at IS NULL", serverID, now).
+		Order("expires_at").
+		Find(&vips).Error; err != nil {
+		return nil, fmt.Errorf("while listing expired vips: %w", err)
+	}
+	return vips, nil
+}
+
+// RevokeVip marks the specified Vip as revoked at now once revoke succeeds.
+// The Vip is only revoked if it is still expired and has yet to be revoked,
+// false is returned otherwise. The Vip is locked while revoke executes, this
+// guards against revoking a Vip refreshed since it was retrieved. If revoke
+// fails, the Vip is not marked as revoked.
+func RevokeVip(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	now time.Time,
+	revoke func() error,
+) (bool, error) {
+	var revoked bool
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var vips model.Vips
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND expires_at <= ? AND revoked_at IS NULL", id, now).
+			Find(&vips).Error; err != nil {
+			return err
+		}
+		if len(vips) == 0 {
+			return nil
+		}
+
+		if err := revoke(); err != nil {
+			return err
+		}
+		if err := tx.
+			Model(&model.Vip{}).
+			Where("id = ?", id).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		revoked = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("while revoking vip: %w", err)
+	}
+	return revoked, nil
+}
+
+func ListRconCommandsByServerID(ctx context.Context, db *gorm.DB, serverID uuid.UUID) (model.RconCommands, error) {
+	commands := make(model.RconCommands, 0)
+	if err := db.
+		WithContext(ctx).
+		Where("server_id = ?", serverID).
+		Order("created_at DESC").
+		Find(&commands).Error; err != nil {
+		return nil, fmt.Errorf("while finding rcon commands by server ID: %w", err)
+	}
+	return commands, nil
+}
+
+func ListPlugins(ctx context.Context, db *gorm.DB) (model.Plugins, error) {
+	plugins := make(model.Plugins, 0)
+	if err := db.
+		WithContext(ctx).
+		Preload("Grants").
+		Order("name").
+		Find(&plugins).Error; err != nil {
+		return nil, fmt.Errorf("while finding plugins: %w", err)
+	}
+	return plugins, nil
+}
+
+func ListPluginsByIDs(ctx context.Context, db *gorm.DB, ids []uuid.UUID) (model.Plugins, error) {
+	plugins := make(model.Plugins, 0, len(ids))
+	if err := db.
+		WithContext(ctx).
+		Preload("Grants").
+		Where("id IN ?", ids).
+		Order("name").
+		Find(&plugins).Error; err != nil {
+		return nil, fmt.Errorf("while finding plugins by IDs: %w", err)
+	}
+	if len(plugins) != len(ids) {
+		return nil, fmt.Errorf("while finding plugins by IDs: %w", cronmanerrors.ErrPluginDNE)
+	}
+	return plugins, nil
+}
+
+func ListDefaultPlugins(ctx context.Context, db *gorm.DB) (model.Plugins, error) {
+	plugins := make(model.Plugins, 0)
+	if err := db.
+		WithContext(ctx).
+		Where("\"default\" = ?", true).
+		Order("name").
+		Find(&plugins).Error; err != nil {
+		return nil, fmt.Errorf("while finding default plugins: %w", err)
+	}
+	return plugins, nil
+}
+
 func GetLiveServer(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.LiveServer, error) {
 	server, err := GetServer(ctx, db, id)
 	if err != nil {
@@ -187,9 +451,12 @@ func GetServer(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.Server, e
 		Preload("Wipes").
 		Preload("Tags").
 		Preload("Events").
+		Preload("Blackouts").
 		Preload("Owners").
 		Preload("Moderators").
 		Preload("Vips").
+		Preload("Bans").
+		Preload("Plugins.Plugin.Grants").
 		First(&server, id)
 	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("get server; id: %s, error: %w", id, cronmanerrors.ErrServerDNE)
@@ -291,6 +558,83 @@ func MakeServerArchived(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.
 	return GetArchivedServer(ctx, db, id)
 }
 
+// MakeServerDormantFromArchived moves the archived server specified by id to
+// the dormant state.
+func MakeServerDormantFromArchived(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.DormantServer, error) {
+	archived, err := GetArchivedServer(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var server *model.DormantServer
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+
+		if res := tx.Delete(&archived); res.Error != nil {
+			return fmt.Errorf("delete archived server; id: %s, error: %w", id, res.Error)
+		}
+
+		server = &model.DormantServer{
+			Server: archived.Server,
+		}
+		if res := tx.Create(server); res.Error != nil {
+			return fmt.Errorf("create dormant server; id: %s, error: %w", id, res.Error)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return GetDormantServer(ctx, db, id)
+}
+
+// ClearServerInstance removes the record of the server's instance.
+func ClearServerInstance(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Server{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"instance_id":         "",
+			"instance_started_at": nil,
+		}).Error; err != nil {
+		return fmt.Errorf("clear server instance; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
+// ClearServerAddress removes the record of the server's address.
+func ClearServerAddress(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Server{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"allocation_id": "", "elastic_ip": ""}).Error; err != nil {
+		return fmt.Errorf("clear server address; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
+// DecommissionArchivedServer records that the archived server specified by id
+// has been decommissioned. Decommissioning a decommissioned server is a no-op.
+func DecommissionArchivedServer(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	archived, err := GetArchivedServer(ctx, db, id)
+	if err != nil {
+		return err
+	}
+	if archived.IsDecommissioned() {
+		return nil
+	}
+
+	if err := db.
+		WithContext(ctx).
+		Model(archived).
+		Update("decommissioned_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("decommission archived server; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
 func ApplyWipe(ctx context.Context, db *gorm.DB, wipeID uuid.UUID) error {
 	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var wipe model.Wipe
@@ -302,6 +646,155 @@ func ApplyWipe(ctx context.Context, db *gorm.DB, wipeID uuid.UUID) error {
 	})
 }
 
+// UpdateServerInstanceStartedAt records when the instance of the server
+// specified by id was started. A nil at records that the instance is stopped.
+func UpdateServerInstanceStartedAt(ctx context.Context, db *gorm.DB, id uuid.UUID, at *time.Time) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Server{}).
+		Where("id = ?", id).
+		Update("instance_started_at", at).Error; err != nil {
+		return fmt.Errorf("update server instance started at; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
+// UpdateServerLastSavedAt records that the world of the server specified by
+// id was last saved at the specified time.
+func UpdateServerLastSavedAt(ctx context.Context, db *gorm.DB, id uuid.UUID, at time.Time) error {
+	res := db.WithContext(ctx).
+		Model(&model.Server{}).
+		Where("id = ?", id).
+		Update("last_saved_at", at)
+	if res.Error != nil {
+		return fmt.Errorf("while updating server last saved at: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return cronmanerrors.ErrServerDNE
+	}
+	return nil
+}
+
+// TransitionServer transitions the lifecycle of the server specified by id to
+// the specified Lifecycle. The reason describes why the server has failed and
+// should be empty otherwise. If the server's current Lifecycle may not
+// transition to the specified Lifecycle, an error wrapping
+// ErrServerTransition is returned.
+//
+// A positive fencingToken fences the transition; the token is recorded on the
+// server, and if the server has been transitioned with a greater token, an
+// error wrapping ErrStaleFencingToken is returned.
+func TransitionServer(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	to model.Lifecycle,
+	reason string,
+	fencingToken int64,
+) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var server model.Server
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&server, id).Error; err != nil {
+			return err
+		}
+
+		if fencingToken > 0 && fencingToken < server.FencingToken {
+			return fmt.Errorf(
+				"%w; token: %d, server token: %d",
+				cronmanerrors.ErrStaleFencingToken,
+				fencingToken,
+				server.FencingToken,
+			)
+		}
+		if !server.Lifecycle.CanTransition(to) {
+			return fmt.Errorf(
+				"%w; from: %s, to: %s",
+				cronmanerrors.ErrServerTransition,
+				server.Lifecycle,
+				to,
+			)
+		}
+
+		changes := map[string]interface{}{
+			"lifecycle":              to,
+			"lifecycle_changed_at":   time.Now(),
+			"lifecycle_reason":       reason,
+			"lifecycle_heartbeat_at": time.Now(),
+		}
+		if fencingToken > 0 {
+			changes["fencing_token"] = fencingToken
+		}
+		return tx.Model(&server).Updates(changes).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return cronmanerrors.ErrServerDNE
+	}
+	if err != nil {
+		return fmt.Errorf("while transitioning server; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
+// HeartbeatServerTransition indicates the transition of the server specified
+// by id into lifecycle is still running.
+func HeartbeatServerTransition(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	lifecycle model.Lifecycle,
+) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Server{}).
+		Where("id = ?", id).
+		Where("lifecycle = ?", lifecycle).
+		Update("lifecycle_heartbeat_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("while updating server transition heartbeat; id: %s, error: %w", id, err)
+	}
+	return nil
+}
+
+// ListStaleServersByLifecycle retrieves the servers currently in one of the
+// specified lifecycles whose transition heartbeat occurred prior to
+// staleBefore. Such transitions are no longer running. Archived servers are
+// not included.
+func ListStaleServersByLifecycle(
+	ctx context.Context,
+	db *gorm.DB,
+	lifecycles []model.Lifecycle,
+	staleBefore time.Time,
+) ([]model.Server, error) {
+	servers := make([]model.Server, 0)
+	if err := db.
+		WithContext(ctx).
+		Where("lifecycle IN ?", lifecycles).
+		Where("state_type IN ?", []string{model.LiveServerState, model.DormantServerState}).
+		Where(
+			db.Where("lifecycle_heartbeat_at IS NULL").
+				Or("lifecycle_heartbeat_at < ?", staleBefore),
+		).
+		Order("lifecycle_changed_at").
+		Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("while finding stale servers by lifecycle: %w", err)
+	}
+	return servers, nil
+}
+
+// ListServerResources retrieves every server, including archived servers,
+// with only the fields that identify the server's cloud resources populated.
+func ListServerResources(ctx context.Context, db *gorm.DB) ([]model.Server, error) {
+	servers := make([]model.Server, 0)
+	if err := db.
+		WithContext(ctx).
+		Select("id", "region", "instance_id", "allocation_id", "lifecycle", "state_type").
+		Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("while finding server resources: %w", err)
+	}
+	return servers, nil
+}
+
 func UpdateLiveServer(
 	ctx context.Context,
 	db *gorm.DB,
@@ -324,3 +817,511 @@ func UpdateLiveServer(
 	}
 	return nil
 }
+
+// CreateJob creates the job in the specified db. The job is pending until it
+// is claimed by ClaimJob.
+func CreateJob(ctx context.Context, db *gorm.DB, job *model.Job) error {
+	if err := db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("while creating job: %w", err)
+	}
+	return nil
+}
+
+// GetJob retrieves the job specified by id.
+func GetJob(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.Job, error) {
+	var job model.Job
+	res := db.WithContext(ctx).First(&job, id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get job; id: %s, error: %w", id, cronmanerrors.ErrJobDNE)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("get job; id: %s, error: %w", id, res.Error)
+	}
+	return &job, nil
+}
+
+// claimJobSQL claims the oldest pending job, or the oldest running job that
+// has been orphaned by the process running it. SKIP LOCKED ensures a job is
+// claimed by a single runner.
+const claimJobSQL = `
+UPDATE servers.jobs
+SET
+  status = @running,
+  attempts = attempts + 1,
+  started_at = COALESCE(started_at, NOW()),
+  heartbeat_at = NOW(),
+  updated_at = NOW()
+WHERE id = (
+  SELECT id FROM servers.jobs
+  WHERE deleted_at IS NULL AND (
+    status = @pending OR
+    (status = @running AND heartbeat_at < @orphaned AND attempts < @maxAttempts)
+  )
+  ORDER BY created_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimJob claims the oldest job available to be run. Running jobs whose
+// heartbeat is older than orphaned are considered abandoned by the process
+// running them and may be claimed, as long as the job has been attempted
+// fewer than maxAttempts times. If no job is available ErrNoJobs is returned.
+func ClaimJob(
+	ctx context.Context,
+	db *gorm.DB,
+	orphaned time.Time,
+	maxAttempts uint8,
+) (*model.Job, error) {
+	var job model.Job
+	res := db.WithContext(ctx).Raw(
+		claimJobSQL,
+		sql.Named("running", model.JobStatusRunning),
+		sql.Named("pending", model.JobStatusPending),
+		sql.Named("orphaned", orphaned),
+		sql.Named("maxAttempts", maxAttempts),
+	).Scan(&job)
+	if res.Error != nil {
+		return nil, fmt.Errorf("while claiming job: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrNoJobs
+	}
+	return &job, nil
+}
+
+// FailAbandonedJobs fails running jobs whose heartbeat is older than orphaned
+// and that have been attempted maxAttempts times.
+func FailAbandonedJobs(
+	ctx context.Context,
+	db *gorm.DB,
+	orphaned time.Time,
+	maxAttempts uint8,
+) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Job{}).
+		Where("status = ?", model.JobStatusRunning).
+		Where("heartbeat_at < ?", orphaned).
+		Where("attempts >= ?", maxAttempts).
+		Updates(map[string]interface{}{
+			"status":      model.JobStatusFailed,
+			"error":       fmt.Sprintf("job abandoned after %d attempts", maxAttempts),
+			"finished_at": time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("while failing abandoned jobs: %w", err)
+	}
+	return nil
+}
+
+// HeartbeatJob indicates the attempt at the job specified by id is still
+// running. If attempt is no longer the job's current attempt,
+// ErrJobAttemptStale is returned.
+func HeartbeatJob(ctx context.Context, db *gorm.DB, id uuid.UUID, attempt uint8) error {
+	res := db.
+		WithContext(ctx).
+		Model(&model.Job{}).
+		Where("id = ?", id).
+		Where("attempts = ?", attempt).
+		Where("status = ?", model.JobStatusRunning).
+		Update("heartbeat_at", time.Now())
+	if res.Error != nil {
+		return fmt.Errorf("while updating job heartbeat: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobAttemptStale
+	}
+	return nil
+}
+
+// UpdateJobProgress records the step the attempt at the job specified by id
+// is performing, and the number of steps it has completed. If attempt is no
+// longer the job's current attempt, ErrJobAttemptStale is returned.
+func UpdateJobProgress(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	attempt uint8,
+	step string,
+	completed uint8,
+) error {
+	res := db.
+		WithContext(ctx).
+		Model(&model.Job{}).
+		Where("id = ?", id).
+		Where("attempts = ?", attempt).
+		Where("status = ?", model.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"step":            step,
+			"steps_completed": completed,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("while updating job progress: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobAttemptStale
+	}
+	return nil
+}
+
+// FinishJob records the attempt at the job specified by id as finished. A nil
+// jobErr indicates the job succeeded. If attempt is no longer the job's
+// current attempt, the outcome is not recorded and ErrJobAttemptStale is
+// returned.
+func FinishJob(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+	attempt uint8,
+	jobErr error,
+) error {
+	changes := map[string]interface{}{
+		"status":          model.JobStatusSucceeded,
+		"step":            "",
+		"steps_completed": gorm.Expr("steps"),
+		"finished_at":     time.Now(),
+	}
+	if jobErr != nil {
+		changes["status"] = model.JobStatusFailed
+		changes["error"] = jobErr.Error()
+	}
+
+	res := db.
+		WithContext(ctx).
+		Model(&model.Job{}).
+		Where("id = ?", id).
+		Where("attempts = ?", attempt).
+		Where("status = ?", model.JobStatusRunning).
+		Updates(changes)
+	if res.Error != nil {
+		return fmt.Errorf("while finishing job: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobAttemptStale
+	}
+	return nil
+}
+
+// CreateServerTemplate creates the template in the specified db. If a template
+// with the same name exists, ErrServerTemplateExists is returned.
+func CreateServerTemplate(ctx context.Context, db *gorm.DB, template *model.ServerTemplate) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.
+			Model(&model.ServerTemplate{}).
+			Where("name = ?", template.Name).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return cronmanerrors.ErrServerTemplateExists
+		}
+		return tx.Create(template).Error
+	})
+	if err != nil {
+		return fmt.Errorf("create server template; name: %s, error: %w", template.Name, err)
+	}
+	return nil
+}
+
+// GetServerTemplate retrieves the template specified by id.
+func GetServerTemplate(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.ServerTemplate, error) {
+	var template model.ServerTemplate
+	res := db.WithContext(ctx).First(&template, id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get server template; id: %s, error: %w", id, cronmanerrors.ErrServerTemplateDNE)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("get server template; id: %s, error: %w", id, res.Error)
+	}
+	return &template, nil
+}
+
+// ListServerTemplates retrieves all templates ordered by name.
+func ListServerTemplates(ctx context.Context, db *gorm.DB) ([]model.ServerTemplate, error) {
+	templates := make([]model.ServerTemplate, 0)
+	if err := db.WithContext(ctx).Order("name").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("while finding server templates: %w", err)
+	}
+	return templates, nil
+}
+
+// DeleteServerTemplate deletes the template specified by id.
+func DeleteServerTemplate(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	res := db.WithContext(ctx).Delete(&model.ServerTemplate{}, id)
+	if res.Error != nil {
+		return fmt.Errorf("delete server template; id: %s, error: %w", id, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("delete server template; id: %s, error: %w", id, cronmanerrors.ErrServerTemplateDNE)
+	}
+	return nil
+}
+
+// CreateAuditEntry appends the entry to the audit history.
+func CreateAuditEntry(ctx context.Context, db *gorm.DB, entry *model.AuditEntry) error {
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("create audit entry; server-id: %s, error: %w", entry.ServerID, err)
+	}
+	return nil
+}
+
+// ListAuditEntriesInput is the input to ListAuditEntries.
+type ListAuditEntriesInput struct {
+	ServerID uuid.UUID
+	// Actions limits the entries to those with the specified actions. If
+	// empty, entries of every action are listed.
+	Actions []model.AuditAction
+	// Outcome limits the entries to those with the specified outcome. If
+	// empty, entries of every outcome are listed.
+	Outcome model.AuditOutcome
+	Limit   int
+	Offset  int
+}
+
+// ListAuditEntries lists the server's audit history, most recent first.
+func ListAuditEntries(
+	ctx context.Context,
+	db *gorm.DB,
+	input ListAuditEntriesInput,
+) ([]model.AuditEntry, error) {
+	query := db.
+		WithContext(ctx).
+		Where("server_id = ?", input.ServerID)
+	if len(input.Actions) > 0 {
+		query = query.Where("action IN ?", input.Actions)
+	}
+	if input.Outcome != "" {
+		query = query.Where("outcome = ?", input.Outcome)
+	}
+
+	entries := make([]model.AuditEntry, 0)
+	if err := query.
+		Order("created_at DESC").
+		Order("id").
+		Limit(input.Limit).
+		Offset(input.Offset).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("list audit entries; server-id: %s, error: %w", input.ServerID, err)
+	}
+	return entries, nil
+}
+
+// CreateExecution records the execution in the specified db. If the execution
+// retries another execution, the retried execution is checked to be
+// retryable within the same transaction. An execution may be retried if it
+// failed, and it does not have a retry that is running or succeeded. Otherwise,
+// an error wrapping ErrExecutionNotFailed is returned.
+func CreateExecution(ctx context.Context, db *gorm.DB, execution *model.Execution) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if execution.RetryOf != nil {
+			if err := checkExecutionRetryable(tx, *execution.RetryOf, true); err != nil {
+				return err
+			}
+		}
+		return tx.Create(execution).Error
+	})
+	if err != nil {
+		return fmt.Errorf("create execution; server-id: %s, error: %w", execution.ServerID, err)
+	}
+	return nil
+}
+
+// CreateRetryExecutionJob creates the job retrying the execution specified by
+// executionID. The execution is checked to be retryable, and to not have a
+// pending or running retry job, within the same transaction; otherwise an
+// error wrapping ErrExecutionNotFailed is returned.
+func CreateRetryExecutionJob(
+	ctx context.Context,
+	db *gorm.DB,
+	job *model.Job,
+	executionID uuid.UUID,
+) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkExecutionRetryable(tx, executionID, true); err != nil {
+			return err
+		}
+
+		var jobs int64
+		if err := tx.
+			Model(&model.Job{}).
+			Where("kind = ?", model.JobKindRetryExecution).
+			Where("status IN ?", []model.JobStatus{model.JobStatusPending, model.JobStatusRunning}).
+			Where("payload->>'executionId' = ?", executionID.String()).
+			Count(&jobs).Error; err != nil {
+			return err
+		}
+		if jobs > 0 {
+			return fmt.Errorf("%w; execution retry is pending", cronmanerrors.ErrExecutionNotFailed)
+		}
+		return tx.Create(job).Error
+	})
+	if err != nil {
+		return fmt.Errorf("create retry execution job; execution-id: %s, error: %w", executionID, err)
+	}
+	return nil
+}
+
+// checkExecutionRetryable checks that the execution specified by id may be
+// retried, see CreateExecution. If lock is true, the execution is locked for the remainder of the
+// transaction so that concurrent retries are serialized.
+func checkExecutionRetryable(tx *gorm.DB, id uuid.UUID, lock bool) error {
+	query := tx
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var execution model.Execution
+	res := query.First(&execution, id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return cronmanerrors.ErrExecutionDNE
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if execution.Outcome != model.ExecutionOutcomeFailed {
+		return cronmanerrors.ErrExecutionNotFailed
+	}
+
+	var retries int64
+	if err := tx.
+		Model(&model.Execution{}).
+		Where("retry_of = ? AND outcome <> ?", id, model.ExecutionOutcomeFailed).
+		Count(&retries).Error; err != nil {
+		return err
+	}
+	if retries > 0 {
+		return fmt.Errorf("%w; execution has been retried", cronmanerrors.ErrExecutionNotFailed)
+	}
+	return nil
+}
+
+// FinishExecution records the outcome of the finished execution.
+func FinishExecution(ctx context.Context, db *gorm.DB, execution model.Execution) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Execution{}).
+		Where("id = ?", execution.ID).
+		Updates(map[string]interface{}{
+			"finished_at": execution.FinishedAt,
+			"outcome":     execution.Outcome,
+			"error":       execution.Error,
+		}).Error; err != nil {
+		return fmt.Errorf("finish execution; id: %s, error: %w", execution.ID, err)
+	}
+	return nil
+}
+
+// FailInterruptedExecutions fails running executions performed by actors of
+// the kind specified that started before startedBefore. Such executions were
+// interrupted, e.g. the process performing them exited, and will not finish.
+func FailInterruptedExecutions(
+	ctx context.Context,
+	db *gorm.DB,
+	kind model.ActorKind,
+	startedBefore time.Time,
+) error {
+	if err := db.
+		WithContext(ctx).
+		Model(&model.Execution{}).
+		Where("outcome = ?", model.ExecutionOutcomeRunning).
+		Where("actor->>'kind' = ?", kind).
+		Where("started_at < ?", startedBefore).
+		Updates(map[string]interface{}{
+			"outcome":     model.ExecutionOutcomeFailed,
+			"error":       "interrupted",
+			"finished_at": time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("while failing interrupted executions: %w", err)
+	}
+	return nil
+}
+
+// GetExecution retrieves the execution specified by id.
+func GetExecution(ctx context.Context, db *gorm.DB, id uuid.UUID) (*model.Execution, error) {
+	var execution model.Execution
+	res := db.WithContext(ctx).First(&execution, id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get execution; id: %s, error: %w", id, cronmanerrors.ErrExecutionDNE)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("get execution; id: %s, error: %w", id, res.Error)
+	}
+	return &execution, nil
+}
+
+// ListExecutionsInput is the input to ListExecutions.
+type ListExecutionsInput struct {
+	// ServerID limits the executions to those of the specified server. If
+	// uuid.Nil, executions of every server are listed.
+	ServerID uuid.UUID
+	// Kinds limits the executions to those of events of the specified kinds.
+	// If empty, executions of every kind are listed.
+	Kinds []model.EventKind
+	// Outcome limits the executions to those with the specified outcome. If
+	// empty, executions of every outcome are listed.
+	Outcome model.ExecutionOutcome
+	Limit   int
+	Offset  int
+}
+
+// ListExecutions lists executions, most recently started first.
+func ListExecutions(
+	ctx context.Context,
+	db *gorm.DB,
+	input ListExecutionsInput,
+) ([]model.Execution, error) {
+	query := db.WithContext(ctx)
+	if input.ServerID != uuid.Nil {
+		query = query.Where("server_id = ?", input.ServerID)
+	}
+	if len(input.Kinds) > 0 {
+		query = query.Where("kind IN ?", input.Kinds)
+	}
+	if input.Outcome != "" {
+		query = query.Where("outcome = ?", input.Outcome)
+	}
+
+	executions := make([]model.Execution, 0)
+	if err := query.
+		Order("started_at DESC").
+		Order("id").
+		Limit(input.Limit).
+		Offset(input.Offset).
+		Find(&executions).Error; err != nil {
+		return nil, fmt.Errorf("list executions; server-id: %s, error: %w", input.ServerID, err)
+	}
+	return executions, nil
+}
+
+// ListRemovedServerAdmins lists the steam IDs of the owners and moderators
+// that have been removed from the server specified by id. A steam ID that has
+// since been added again is listed as well.
+func ListRemovedServerAdmins(
+	ctx context.Context,
+	db *gorm.DB,
+	id uuid.UUID,
+) (owners []string, moderators []string, err error) {
+	owners = make([]string, 0)
+	if err := db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&model.Owner{}).
+		Where("server_id = ? AND deleted_at IS NOT NULL", id).
+		Distinct().
+		Pluck("steam_id", &owners).Error; err != nil {
+		return nil, nil, fmt.Errorf("list removed server owners; id: %s, error: %w", id, err)
+	}
+
+	moderators = make([]string, 0)
+	if err := db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&model.Moderator{}).
+		Where("server_id = ? AND deleted_at IS NOT NULL", id).
+		Distinct().
+		Pluck("steam_id", &moderators).Error; err != nil {
+		return nil, nil, fmt.Errorf("list removed server moderators; id: %s, error: %w", id, err)
+	}
+	return owners, moderators, nil
+}
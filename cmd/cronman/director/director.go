@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/tjper/rustcron/cmd/cronman/controller"
 	"github.com/tjper/rustcron/cmd/cronman/db"
-	"github.com/tjper/rustcron/cmd/cronman/mapgen"
+	"github.com/tjper/rustcron/cmd/cronman/lock"
 	"github.com/tjper/rustcron/cmd/cronman/model"
 
 	"github.com/go-redis/redis/v8"
@@ -17,13 +19,65 @@ import (
 )
 
 // WatchAndDirect instructs the Controller to collect upcoming server events and
-// pass them to the EventsProcessor.
+// pass them to the EventsProcessor. Only the director holding the distributed
+// lock directs events. If the lock is lost, directing stops and the director
+// contends for the lock again.
 func (dir Director) WatchAndDirect(ctx context.Context) error {
+	for {
+		err := dir.lead(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, lock.ErrLockLost) {
+			dir.logger.Warn("director lock lost; contending for lock")
+			continue
+		}
+		return err
+	}
+}
+
+// unlockTimeout is the maximum duration releasing the director lock may take.
+const unlockTimeout = 5 * time.Second
+
+// lead acquires the distributed lock and directs events until the context is
+// cancelled or the lock is lost. If the lock is lost, an error wrapping
+// lock.ErrLockLost is returned. Actions taken while directing carry the
+// lock's fencing token.
+func (dir Director) lead(ctx context.Context) error {
 	// acquire distributed lock, only one instance runs the controller
-	if err := dir.distributedLock.Lock(ctx); err != nil {
+	lease, err := dir.distributedLock.Lock(ctx)
+	if err != nil {
 		return fmt.Errorf("acquire director lock; %w", err)
 	}
-	defer dir.distributedLock.Unlock(ctx)
+	defer func() {
+		// The lock is released even if ctx has been cancelled, so another
+		// director may acquire it immediately.
+		ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+		defer cancel()
+
+		if err := dir.distributedLock.Unlock(ctx); err != nil {
+			dir.logger.Error("while releasing director lock", zap.Error(err))
+		}
+	}()
+
+	err = dir.direct(model.WithFencingToken(lease.Context(), lease.Token))
+	if ctx.Err() == nil && lease.Context().Err() != nil {
+		return fmt.Errorf("while directing events: %w", lock.ErrLockLost)
+	}
+	return err
+}
+
+// direct directs server events until the context is cancelled.
+func (dir Director) direct(ctx context.Context) error {
+	// Servers left transitioning by a previous director are recovered prior
+	// to directing events.
+	if err := dir.controller.RecoverServers(ctx); err != nil {
+		dir.logger.Error("while recovering servers", zap.Error(err))
+	}
+	// Executions left running by a previous director will not finish.
+	if err := dir.controller.FailInterruptedExecutions(ctx); err != nil {
+		dir.logger.Error("while failing interrupted executions", zap.Error(err))
+	}
 
 	dir.logger.Info("subscribed to refresh subject")
 	sub := dir.redis.Subscribe(ctx, refreshSubj)
@@ -33,13 +87,61 @@ func (dir Director) WatchAndDirect(ctx context.Context) error {
 		}
 	}()
 
+	// Catch ups outlive the schedule they were started with, direct does not
+	// return until they have finished.
+	var catchUps sync.WaitGroup
+	defer catchUps.Wait()
+
+	// Periodic tasks run on tickers owned by direct, rather than on the
+	// scheduler, as the scheduler is rebuilt on each refresh and would reset
+	// their intervals.
+	periodicCtx, cancelPeriodic := context.WithCancel(ctx)
+	var periodic sync.WaitGroup
+	defer func() {
+		cancelPeriodic()
+		periodic.Wait()
+	}()
+	if dir.saveInterval > 0 {
+		periodic.Add(1)
+		go func() {
+			defer periodic.Done()
+			every(periodicCtx, dir.saveInterval, dir.saveLiveServers)
+		}()
+	}
+	if dir.adminReconcileInterval > 0 {
+		periodic.Add(1)
+		go func() {
+			defer periodic.Done()
+			every(periodicCtx, dir.adminReconcileInterval, dir.reconcileServerAdmins)
+		}()
+	}
+
+	// lastScheduled is when this director last scheduled events. Events that
+	// occurred prior to it were directed, or caught up, already.
+	var lastScheduled time.Time
 	for {
 		events, err := db.ListActiveServerEvents(ctx, dir.store)
 		if err != nil {
 			return fmt.Errorf("failed to list events; %w", err)
 		}
+		blackouts, err := db.ListActiveServerBlackouts(ctx, dir.store, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to list blackouts; %w", err)
+		}
+
+		// Events missed prior to this (re)schedule are caught up. Only events
+		// that occurred since the previous schedule are replayed, so that
+		// actions taken since, e.g. an admin stopping a server, are not
+		// undone by a refresh.
+		now := time.Now()
+		since := now.Add(-dir.catchUpLookback)
+		if lastScheduled.After(since) {
+			since = lastScheduled
+		}
+		dir.catchUp(ctx, &catchUps, events, since)
+		lastScheduled = now
 
-		err = dir.schedule(ctx, sub.Channel(), events)
+		err = dir.schedule(ctx, sub.Channel(), events, blackoutsByServer(blackouts))
 		if errors.Is(err, errDirectorRefresh) {
 			continue
 		}
@@ -54,16 +156,20 @@ var errDirectorRefresh = errors.New("director refresh received")
 func (dir Director) schedule(
 	ctx context.Context,
 	refresh <-chan *redis.Message,
-	events model.Events,
+	events []model.ZonedEvent,
+	blackouts map[uuid.UUID]model.Blackouts,
 ) error {
 	scheduler := cron.New()
 
 	if _, err := scheduler.AddFunc(
 		"* * * * *",
 		func() {
-			if err := dir.controller.LiveServerRconForEach(ctx, dir.controller.CaptureServerInfo); err != nil {
+			results, err := dir.controller.LiveServerRconForEach(ctx, dir.controller.CaptureServerInfo)
+			if err != nil {
 				dir.logger.Error("while capturing live server info", zap.Error(err))
+				return
 			}
+			dir.logResults("capture live server info", results)
 		},
 	); err != nil {
 		dir.logger.Error("while scheduling server info capture", zap.Error(err))
@@ -72,24 +178,87 @@ func (dir Director) schedule(
 	if _, err := scheduler.AddFunc(
 		"*/15 * * * *",
 		func() {
-			if err := dir.controller.LiveServerRconForEach(ctx, dir.controller.SayServerTimeRemaining); err != nil {
+			results, err := dir.controller.LiveServerRconForEach(ctx, dir.controller.SayServerTimeRemaining)
+			if err != nil {
 				dir.logger.Error("while saying server time remaining", zap.Error(err))
+				return
 			}
+			dir.logResults("say server time remaining", results)
 		},
 	); err != nil {
 		dir.logger.Error("while scheduling say server time remaining", zap.Error(err))
 	}
 
+	if _, err := scheduler.AddFunc(
+		"* * * * *",
+		func() {
+			results, err := dir.controller.LiveServerRconForEach(ctx, dir.controller.ExpireServerVips)
+			if err != nil {
+				dir.logger.Error("while expiring server vips", zap.Error(err))
+				return
+			}
+			dir.logResults("expire server vips", results)
+		},
+	); err != nil {
+		dir.logger.Error("while scheduling server vip expiry", zap.Error(err))
+	}
+
+	// Countdowns and one-off events are cancelled when the events are
+	// rescheduled.
+	scheduledCtx, cancelScheduled := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancelScheduled()
+		wg.Wait()
+	}()
+
 	for _, event := range events {
 		this := event
+		serverBlackouts := blackouts[this.ServerID]
+
+		switch this.Kind {
+		case model.EventKindStop, model.EventKindMapWipe, model.EventKindFullWipe:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				dir.countdown(scheduledCtx, this, serverBlackouts)
+			}()
+		}
+
+		if this.IsOneOff() {
+			if !this.RunAt.After(time.Now()) {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case <-scheduledCtx.Done():
+				case <-time.After(time.Until(*this.RunAt)):
+					dir.Direct(ctx, this.Event, *this.RunAt)
+				}
+			}()
+			continue
+		}
 
+		// Events are scheduled in the time zone of their server.
 		if _, err := scheduler.AddFunc(
-			this.Schedule,
+			this.Spec(),
 			func() {
-				if this.Weekday != nil && !this.IsWeekDay(time.Now().UTC()) {
+				now := time.Now()
+				if !occursOnWeekday(this, now) {
 					return
 				}
-				dir.Direct(ctx, this)
+				if serverBlackouts.Suppresses(this.Event, now) {
+					dir.logger.Info(
+						"event suppressed by blackout",
+						zap.Stringer("event-id", this.ID),
+						zap.Stringer("server-id", this.ServerID),
+					)
+					return
+				}
+				// Events are scheduled to the minute.
+				dir.Direct(ctx, this.Event, now.Truncate(time.Minute))
 			},
 		); err != nil {
 			dir.logger.Error(
@@ -114,94 +283,196 @@ func (dir Director) schedule(
 	}
 }
 
-func (dir Director) Direct(ctx context.Context, event model.Event) {
-	var err error
-	switch event.Kind {
-	case model.EventKindStart:
-		err = dir.startServer(ctx, event.ServerID)
-	case model.EventKindStop:
-		err = dir.stopServer(ctx, event.ServerID)
-	case model.EventKindLive:
-		err = dir.serverLive(ctx, event.ServerID)
-	case model.EventKindMapWipe:
-		err = dir.wipeServer(ctx, event.ServerID, model.NewMapWipe)
-	case model.EventKindFullWipe:
-		err = dir.wipeServer(ctx, event.ServerID, model.NewFullWipe)
+// occursOnWeekday checks if the recurring event, fired by the scheduler at
+// now, occurs on its Weekday. The scheduler fires events in the time zone of
+// their server, so the weekday is evaluated in that time zone rather than the
+// time zone of the process. An event without a Weekday occurs every day.
+func occursOnWeekday(event model.ZonedEvent, now time.Time) bool {
+	if event.Weekday == nil {
+		return true
 	}
-	if err != nil {
+	return event.IsWeekDay(now.In(event.Location()))
+}
+
+// logResults logs each failed result and a summary of the results of a
+// LiveServerRconForEach call.
+func (dir Director) logResults(task string, results controller.LiveServerResults) {
+	var slowest time.Duration
+	for _, result := range results {
+		if result.Duration > slowest {
+			slowest = result.Duration
+		}
+		if result.Err == nil {
+			continue
+		}
 		dir.logger.Error(
-			"directing event",
-			zap.Stringer("event-id", event.ID),
-			zap.Stringer("server-id", event.ServerID),
-			zap.Error(err),
+			"live server task failed",
+			zap.String("task", task),
+			zap.Stringer("server-id", result.ServerID),
+			zap.Duration("duration", result.Duration),
+			zap.Error(result.Err),
 		)
 	}
+
+	dir.logger.Debug(
+		"live server task complete",
+		zap.String("task", task),
+		zap.Int("servers", len(results)),
+		zap.Int("failed", len(results.Failed())),
+		zap.Duration("slowest", slowest),
+	)
 }
 
-func (dir Director) startServer(ctx context.Context, serverID uuid.UUID) error {
-	if _, err := dir.controller.StartServer(ctx, serverID); err != nil {
-		return fmt.Errorf("start server; id: %s, error: %w", serverID, err)
+// catchUp drives each server with events to the state its events dictate,
+// catching up events that occurred after since and were missed while no
+// director was scheduling them. Servers are caught up concurrently in the
+// background, as starting a server may take several minutes; each catch up is
+// tracked by wg. A server already transitioning is not caught up, so
+// overlapping catch ups do not conflict.
+func (dir Director) catchUp(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	events []model.ZonedEvent,
+	since time.Time,
+) {
+	if dir.catchUpLookback <= 0 {
+		return
+	}
+
+	serverIDs := make(map[uuid.UUID]struct{})
+	for _, event := range events {
+		serverIDs[event.ServerID] = struct{}{}
+	}
+
+	for serverID := range serverIDs {
+		serverID := serverID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dir.controller.CatchUpServer(ctx, serverID, since); err != nil {
+				dir.logger.Error(
+					"while catching up server events",
+					zap.Stringer("server-id", serverID),
+					zap.Error(err),
+				)
+			}
+		}()
 	}
-	return nil
 }
 
-func (dir Director) serverLive(ctx context.Context, serverID uuid.UUID) error {
-	if _, err := dir.controller.MakeServerLive(ctx, serverID); err != nil {
-		return fmt.Errorf("make server live; id: %s, error: %w", serverID, err)
+// every calls fn every interval until ctx is cancelled. The first call is
+// made once interval has elapsed.
+func every(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A tick may be selected over a cancelled context.
+			if ctx.Err() != nil {
+				return
+			}
+			fn(ctx)
+		}
 	}
-	return nil
 }
 
-func (dir Director) stopServer(ctx context.Context, serverID uuid.UUID) error {
-	if _, err := dir.controller.StopServer(ctx, serverID); err != nil {
-		return fmt.Errorf("stop server; id: %s, error: %w", serverID, err)
+// saveLiveServers saves the world of each live server.
+func (dir Director) saveLiveServers(ctx context.Context) {
+	results, err := dir.controller.LiveServerRconForEach(
+		ctx,
+		dir.controller.SaveServer,
+		controller.WithServerTimeout(saveTimeout),
+	)
+	if err != nil {
+		dir.logger.Error("while saving live servers", zap.Error(err))
+		return
 	}
-	return nil
+	dir.logResults("save live servers", results)
 }
 
-func (dir Director) wipeServer(
-	ctx context.Context,
-	serverID uuid.UUID,
-	newWipe func(uint32, uint32) *model.Wipe,
-) error {
-	serverI, err := dir.controller.GetServer(ctx, serverID)
+// reconcileServerAdmins reconciles the owners and moderators of each live
+// server with the store.
+func (dir Director) reconcileServerAdmins(ctx context.Context) {
+	results, err := dir.controller.LiveServerRconForEach(ctx, dir.controller.ReconcileServerAdmins)
 	if err != nil {
-		return err
+		dir.logger.Error("while reconciling server admins", zap.Error(err))
+		return
 	}
+	dir.logResults("reconcile server admins", results)
+}
 
-	_, isLive := serverI.(*model.LiveServer)
+// blackoutsByServer groups the Blackouts by the ID of their server.
+func blackoutsByServer(blackouts []model.Blackout) map[uuid.UUID]model.Blackouts {
+	grouped := make(map[uuid.UUID]model.Blackouts)
+	for _, blackout := range blackouts {
+		grouped[blackout.ServerID] = append(grouped[blackout.ServerID], blackout)
+	}
+	return grouped
+}
 
-	if isLive {
-		if _, err := dir.controller.StopServer(ctx, serverID); err != nil {
-			return err
+// countdown broadcasts the server's countdown prior to each occurrence of the
+// event that is not suppressed by the blackouts, until the context is
+// cancelled or the event has no future occurrences.
+func (dir Director) countdown(
+	ctx context.Context,
+	event model.ZonedEvent,
+	blackouts model.Blackouts,
+) {
+	for {
+		at, err := event.NextExcept(time.Now(), blackouts)
+		if errors.Is(err, model.ErrNoFutureEvent) {
+			return
+		}
+		if err != nil {
+			dir.logger.Error(
+				"while determining next event for countdown",
+				zap.Stringer("event-id", event.ID),
+				zap.Error(err),
+			)
+			return
 		}
-		defer func() {
-			if _, err := dir.controller.StartServer(ctx, serverID); err != nil {
-				dir.logger.Error("while restarting a wiped server", zap.Error(err))
-				return
-			}
 
-			if _, err := dir.controller.MakeServerLive(ctx, serverID); err != nil {
-				dir.logger.Error("while make a wiped server live", zap.Error(err))
-				return
-			}
-		}()
-	}
+		err = dir.controller.CountdownServer(ctx, event.ServerID, event.Kind, at)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			dir.logger.Error(
+				"while counting down to event",
+				zap.Stringer("event-id", event.ID),
+				zap.Stringer("server-id", event.ServerID),
+				zap.Error(err),
+			)
+		}
 
-	var mapSize model.MapSizeKind
-	switch server := serverI.(type) {
-	case *model.LiveServer:
-		mapSize = server.Server.MapSize
-	case *model.DormantServer:
-		mapSize = server.Server.MapSize
+		// Wait for the event to occur before counting down to its next
+		// occurrence.
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(at) + time.Second):
+		}
 	}
+}
 
-	seed := mapgen.GenerateSeed(mapSize)
-	salt := mapgen.GenerateSalt()
-	wipe := newWipe(seed, salt)
+// Direct directs the event's occurrence scheduled at the time specified. The
+// execution of the event is recorded by the Controller.
+func (dir Director) Direct(ctx context.Context, event model.Event, scheduledAt time.Time) {
+	// The event's actions are audited as performed by the director.
+	ctx = model.WithActor(ctx, model.NewDirectorActor(event))
 
-	if err := dir.controller.WipeServer(ctx, serverID, *wipe); err != nil {
-		return fmt.Errorf("while wiping server: %w", err)
+	execution, err := dir.controller.DirectEvent(ctx, event, scheduledAt)
+	if err != nil {
+		dir.logger.Error(
+			"directing event",
+			zap.Stringer("event-id", event.ID),
+			zap.Stringer("server-id", event.ServerID),
+			zap.Stringer("execution-id", execution.ID),
+			zap.Error(err),
+		)
 	}
-	return nil
 }
@@ -0,0 +1,94 @@
+package director
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccursOnWeekday(t *testing.T) {
+	t.Parallel()
+
+	saturday := time.Saturday
+	la, err := time.LoadLocation("America/Los_Angeles")
+	require.Nil(t, err)
+
+	newEvent := func(weekday *time.Weekday, timeZone string) model.ZonedEvent {
+		return model.ZonedEvent{
+			Event:    model.Event{Schedule: "0 23 * * *", Weekday: weekday, Kind: model.EventKindMapWipe},
+			TimeZone: timeZone,
+		}
+	}
+
+	tests := map[string]struct {
+		event model.ZonedEvent
+		now   time.Time
+		exp   bool
+	}{
+		// Saturday 23:00 in Los Angeles is Sunday in UTC, the time zone of
+		// the process.
+		"late saturday daylight time": {
+			event: newEvent(&saturday, "America/Los_Angeles"),
+			now:   time.Date(2022, time.June, 25, 23, 0, 0, 0, la).UTC(),
+			exp:   true,
+		},
+		"late saturday standard time": {
+			event: newEvent(&saturday, "America/Los_Angeles"),
+			now:   time.Date(2022, time.December, 24, 23, 0, 0, 0, la).UTC(),
+			exp:   true,
+		},
+		"late friday": {
+			event: newEvent(&saturday, "America/Los_Angeles"),
+			now:   time.Date(2022, time.June, 24, 23, 0, 0, 0, la).UTC(),
+			exp:   false,
+		},
+		"saturday utc": {
+			event: newEvent(&saturday, "UTC"),
+			now:   time.Date(2022, time.June, 25, 23, 0, 0, 0, time.UTC),
+			exp:   true,
+		},
+		"no weekday": {
+			event: newEvent(nil, "America/Los_Angeles"),
+			now:   time.Date(2022, time.June, 24, 23, 0, 0, 0, la).UTC(),
+			exp:   true,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, test.exp, occursOnWeekday(test.event, test.now))
+		})
+	}
+}
+
+func TestEvery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		every(ctx, 10*time.Millisecond, func(context.Context) {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("every did not return once its context was cancelled")
+	}
+	require.Equal(t, 3, calls)
+}
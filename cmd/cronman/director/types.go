@@ -22,6 +22,9 @@ const (
 	// controller.Refresh method, must publish to this subject while a the
 	// acting controller listens.
 	refreshSubj = "controller-refresh"
+
+	// saveTimeout is the maximum duration a single live server save may take.
+	saveTimeout = 2 * time.Minute
 )
 
 type Director struct {
@@ -31,6 +34,44 @@ type Director struct {
 	store      *gorm.DB
 
 	distributedLock *lock.Distributed
+
+	saveInterval           time.Duration
+	adminReconcileInterval time.Duration
+	catchUpLookback        time.Duration
+}
+
+// Option mutates a Director instance. Typically used with New to configure
+// a Director instance.
+type Option func(*Director)
+
+// WithSaveInterval is an Option that configures the interval at which the
+// Director saves the world of each live server. A non-positive interval, or
+// omitting the Option, disables scheduled saves.
+func WithSaveInterval(interval time.Duration) Option {
+	return func(dir *Director) {
+		dir.saveInterval = interval
+	}
+}
+
+// WithAdminReconcileInterval is an Option that configures the interval at
+// which the Director reconciles the owners and moderators of each live server
+// with the store. A non-positive interval, or omitting the Option, disables
+// reconciliation.
+func WithAdminReconcileInterval(interval time.Duration) Option {
+	return func(dir *Director) {
+		dir.adminReconcileInterval = interval
+	}
+}
+
+// WithCatchUpLookback is an Option that configures the period of time prior
+// to now within which the Director catches up events it missed, e.g. while no
+// director was running. Events that occurred prior to the lookback are not
+// replayed. A non-positive lookback, or omitting the Option, disables catching
+// up.
+func WithCatchUpLookback(lookback time.Duration) Option {
+	return func(dir *Director) {
+		dir.catchUpLookback = lookback
+	}
 }
 
 func New(
@@ -38,12 +79,18 @@ func New(
 	redis *redis.Redis,
 	store *gorm.DB,
 	controller *controller.Controller,
+	options ...Option,
 ) *Director {
-	return &Director{
+	dir := &Director{
 		logger:          logger.With(zap.String("director-id", uuid.NewString())),
 		redis:           redis,
 		store:           store,
 		controller:      controller,
 		distributedLock: lock.NewDistributed(logger, redis, mutexKey, 2*time.Second),
 	}
+
+	for _, option := range options {
+		option(dir)
+	}
+	return dir
 }
@@ -7,4 +7,14 @@ var (
 	ErrServerNotArchived = errors.New("server is not archived")
 	ErrServerNotDormant  = errors.New("server is not dormant")
 	ErrServerNotLive     = errors.New("server is not live")
+	ErrPluginDNE         = errors.New("plugin does not exist")
+	ErrServerTransition  = errors.New("server lifecycle transition invalid")
+	ErrJobDNE            = errors.New("job does not exist")
+	ErrStaleFencingToken = errors.New("fencing token is stale")
+
+	ErrServerTemplateDNE    = errors.New("server template does not exist")
+	ErrServerTemplateExists = errors.New("server template already exists")
+
+	ErrExecutionDNE       = errors.New("execution does not exist")
+	ErrExecutionNotFailed = errors.New("execution has not failed")
 )
@@ -2,21 +2,58 @@ package lock
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// ErrLockLost indicates a Lease was lost while it was held, e.g. the lock
+// expired before it could be refreshed, or another instance acquired it.
+var ErrLockLost = errors.New("distributed lock lost")
+
+const (
+	// acquireScript sets the lock key to the owner token if the lock key does
+	// not exist. If the lock is acquired, the fencing key is incremented and
+	// its value returned as the fencing token, otherwise 0 is returned.
+	acquireScript = `
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0`
+
+	// refreshScript extends the expiration of the lock key if the lock key is
+	// set to the owner token (compare-and-set). 1 is returned if the lock was
+	// refreshed, otherwise 0 is returned.
+	refreshScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`
+
+	// releaseScript deletes the lock key if the lock key is set to the owner
+	// token (compare-and-delete). 1 is returned if the lock was released,
+	// otherwise 0 is returned.
+	releaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+)
+
 // IRedis is represents the API by which the Redis can be communicated with.
 type IRedis interface {
-	SetNX(context.Context, string, interface{}, time.Duration) (bool, error)
-	SetXX(context.Context, string, interface{}, time.Duration) (bool, error)
+	Eval(context.Context, string, []string, ...interface{}) (interface{}, error)
 }
 
 // NewDistributed creates a Distributed instance. The key is the redis key the
 // lock will use. All Distributed instances with the same redis instance and
-// key will contend for the same lock. The expiration is the rate at which the
-// lock is refreshed.
+// key will contend for the same lock. The expiration is the duration the lock
+// is held for without being refreshed.
 func NewDistributed(
 	logger *zap.Logger,
 	redis IRedis,
@@ -27,11 +64,14 @@ func NewDistributed(
 		logger:     logger,
 		redis:      redis,
 		key:        key,
+		fencingKey: key + ":fencing",
 		expiration: expiration,
 	}
 }
 
-// Distributed is a distributed lock that utilizes Redis.
+// Distributed is a distributed lock that utilizes Redis. Each acquisition of
+// the lock is owned by a unique token, the lock is only refreshed and released
+// by its owner.
 // IMPORTANT: This distributed lock is only effective on single instance
 // of Redis. If a Redis cluster is being used, look into Redigo.
 type Distributed struct {
@@ -40,66 +80,165 @@ type Distributed struct {
 
 	expiration time.Duration
 	key        string
-	cancel     context.CancelFunc
+	fencingKey string
+
+	mutex  sync.Mutex
+	owner  string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Lease is a held distributed lock.
+type Lease struct {
+	// Token is the fencing token of the Lease. Tokens increase each time the
+	// lock is acquired, allowing actions taken by a former holder of the lock
+	// to be rejected.
+	Token int64
+
+	ctx context.Context
+}
+
+// Context retrieves a context that is cancelled when the Lease is lost or
+// released, or the context passed to Lock is cancelled.
+func (l Lease) Context() context.Context {
+	return l.ctx
 }
 
 // Lock seeks to acquire the distributed lock. This method blocks until this
-// distributed lock is acquired or the context is cancelled.
-func (d *Distributed) Lock(ctx context.Context) error {
+// distributed lock is acquired or the context is cancelled. Once acquired, the
+// lock is refreshed until Unlock is called or the lock is lost, at which point
+// the Lease's context is cancelled.
+func (d *Distributed) Lock(ctx context.Context) (*Lease, error) {
+	owner := uuid.NewString()
+
 	ticker := time.NewTicker(d.expiration / 2)
-retry:
+	defer ticker.Stop()
+
+	var token int64
 	for {
+		var err error
+		token, err = d.eval(ctx, acquireScript, []string{d.key, d.fencingKey}, owner, d.expiration.Milliseconds())
+		if err != nil {
+			return nil, fmt.Errorf("while acquiring distributed lock: %w", err)
+		}
+		if token > 0 {
+			break
+		}
+
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
 		case <-ticker.C:
-			set, err := d.redis.SetNX(ctx, d.key, time.Now(), d.expiration)
-			if err != nil {
-				return err
-			}
-			if set {
-				d.logger.Info("lock acquired")
-				break retry
-			}
 		}
 	}
+	d.logger.Info("lock acquired", zap.Int64("fencing-token", token))
 
 	// launch goroutine that periodically refreshes the distributed lock once it
 	// has been acquired. As long at the distributed lock is being refreshed, the
 	// application that originally acquired the distributed lock will keep it.
-	lockCtx, cancel := context.WithCancel(ctx)
-	d.cancel = cancel
+	leaseCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	d.mutex.Lock()
+	d.owner, d.cancel, d.done = owner, cancel, done
+	d.mutex.Unlock()
+
 	go func() {
+		defer close(done)
 		defer cancel()
-		d.maintainLock(lockCtx)
+		d.maintainLock(leaseCtx, owner)
 	}()
-	return nil
+	return &Lease{Token: token, ctx: leaseCtx}, nil
 }
 
-// Unlock releases the distributed lock.
-func (d *Distributed) Unlock(ctx context.Context) {
-	d.cancel()
+// Unlock releases the distributed lock, if it is still owned by this
+// Distributed instance, so that another instance may acquire it immediately.
+func (d *Distributed) Unlock(ctx context.Context) error {
+	d.mutex.Lock()
+	owner, cancel, done := d.owner, d.cancel, d.done
+	d.owner, d.cancel, d.done = "", nil, nil
+	d.mutex.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+
+	released, err := d.eval(ctx, releaseScript, []string{d.key}, owner)
+	if err != nil {
+		return fmt.Errorf("while releasing distributed lock: %w", err)
+	}
+	if released == 0 {
+		d.logger.Warn("lock not released; lock not owned")
+		return nil
+	}
+	d.logger.Info("lock released")
+	return nil
 }
 
 // --- private ---
 
 // maintainLock maintains the lock once it has been acquired. As long at the
 // distributed lock is being refreshed, the application that originally
-// acquired the distributed lock will keep it.
-func (d *Distributed) maintainLock(ctx context.Context) {
-	ticker := time.NewTicker(d.expiration / 2)
+// acquired the distributed lock will keep it. maintainLock returns when the
+// context is cancelled, or the lock is lost. The lock is lost when it is owned
+// by another, or it may not be refreshed before it expires.
+func (d *Distributed) maintainLock(ctx context.Context, owner string) {
+	interval := d.expiration / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	refreshedAt := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			set, err := d.redis.SetXX(ctx, d.key, time.Now(), d.expiration)
-			if err != nil {
-				d.logger.Fatal("failed to maintain distributed lock", zap.Error(err))
+			at := time.Now()
+			refreshed, err := d.eval(ctx, refreshScript, []string{d.key}, owner, d.expiration.Milliseconds())
+			if ctx.Err() != nil {
+				return
 			}
-			if !set {
-				d.logger.Fatal("failed to maintain distributed lock; set failed")
+			if err == nil && refreshed == 0 {
+				d.logger.Error("distributed lock lost; lock owned by another")
+				return
 			}
+			if err == nil {
+				refreshedAt = at
+				continue
+			}
+
+			// The refresh may be retried if the lock will not expire before
+			// the next refresh.
+			if time.Until(refreshedAt.Add(d.expiration)) > interval {
+				d.logger.Warn("while refreshing distributed lock", zap.Error(err))
+				continue
+			}
+			d.logger.Error("distributed lock lost; failed to refresh", zap.Error(err))
+			return
 		}
 	}
 }
+
+// eval evaluates the script, and retrieves its integer result.
+func (d *Distributed) eval(
+	ctx context.Context,
+	script string,
+	keys []string,
+	args ...interface{},
+) (int64, error) {
+	res, err := d.redis.Eval(ctx, script, keys, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := res.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected script result type %T", res)
+	}
+}
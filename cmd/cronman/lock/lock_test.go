@@ -2,6 +2,7 @@ package lock
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -16,15 +17,17 @@ func TestLock(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	redis := &redisMock{
-		lock: new(sync.RWMutex),
-	}
+	redis := newRedisMock()
 
 	lock := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
 
-	err := lock.Lock(ctx)
+	lease, err := lock.Lock(ctx)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), lease.Token)
+
+	err = lock.Unlock(ctx)
 	require.Nil(t, err)
-	lock.Unlock(ctx)
+	require.ErrorIs(t, lease.Context().Err(), context.Canceled)
 
 	require.Equal(t, 1, redis.Acquired())
 }
@@ -33,104 +36,236 @@ func TestWaitForLock(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
 	defer cancel()
 
-	redis := &redisMock{
-		lock: new(sync.RWMutex),
-	}
+	redis := newRedisMock()
 
 	first := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
 
-	err := first.Lock(ctx)
+	_, err := first.Lock(ctx)
 	require.Nil(t, err)
 	defer first.Unlock(ctx)
 
 	second := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
 
-	err = second.Lock(ctx)
+	_, err = second.Lock(ctx)
 	require.ErrorIs(t, err, context.DeadlineExceeded)
 
 	require.Equal(t, 1, redis.Acquired())
+	require.Greater(t, redis.Attempted(), 1)
+	require.Greater(t, redis.Maintained(), 0)
 }
 
 func TestUnlock(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	redis := &redisMock{
-		lock: new(sync.RWMutex),
+	redis := newRedisMock()
+
+	// The expiration exceeds the context timeout, the second lock may only be
+	// acquired if the first is released.
+	first := NewDistributed(zap.NewNop(), redis, key, 10*time.Second)
+
+	lease, err := first.Lock(ctx)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), lease.Token)
+
+	err = first.Unlock(ctx)
+	require.Nil(t, err)
+
+	second := NewDistributed(zap.NewNop(), redis, key, 10*time.Second)
+
+	lease, err = second.Lock(ctx)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), lease.Token)
+	err = second.Unlock(ctx)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, redis.Acquired())
+}
+
+func TestLockContention(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	redis := newRedisMock()
+
+	const contenders = 5
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		holders int
+		tokens  = make([]int64, 0, contenders)
+	)
+	for i := 0; i < contenders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock := NewDistributed(zap.NewNop(), redis, key, 50*time.Millisecond)
+			lease, err := lock.Lock(ctx)
+			require.Nil(t, err)
+
+			mutex.Lock()
+			holders++
+			require.Equal(t, 1, holders)
+			tokens = append(tokens, lease.Token)
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			holders--
+			mutex.Unlock()
+
+			require.Nil(t, lock.Unlock(ctx))
+		}()
 	}
+	wg.Wait()
+
+	require.Equal(t, contenders, redis.Acquired())
+	require.ElementsMatch(t, []int64{1, 2, 3, 4, 5}, tokens)
+}
+
+func TestLockLost(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	redis := newRedisMock()
 
 	first := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
 
-	err := first.Lock(ctx)
+	lease, err := first.Lock(ctx)
 	require.Nil(t, err)
 
-	first.Unlock(ctx)
+	// Another instance takes the lock, e.g. after the lock expired while the
+	// first instance was partitioned from redis.
+	redis.Steal("thief")
 
-	second := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
+	select {
+	case <-ctx.Done():
+		t.Fatal("lease context not cancelled after lock lost")
+	case <-lease.Context().Done():
+	}
+	require.Nil(t, ctx.Err())
 
-	err = second.Lock(ctx)
+	// The first instance may not release the lock it no longer owns.
+	err = first.Unlock(ctx)
 	require.Nil(t, err)
-	second.Unlock(ctx)
+	require.Equal(t, "thief", redis.Owner())
 
-	require.Equal(t, 2, redis.Acquired())
+	// The first instance contends for the lock once it is released, and is
+	// granted a greater fencing token.
+	redis.Steal("")
+	lease, err = first.Lock(ctx)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), lease.Token)
+	require.Nil(t, first.Unlock(ctx))
+}
+
+func TestLockRefreshFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	redis := newRedisMock()
+
+	lock := NewDistributed(zap.NewNop(), redis, key, 100*time.Millisecond)
+
+	lease, err := lock.Lock(ctx)
+	require.Nil(t, err)
+
+	redis.Fail(errors.New("connection refused"))
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("lease context not cancelled after refresh failures")
+	case <-lease.Context().Done():
+	}
+	require.Nil(t, ctx.Err())
 }
 
 // --- mocks ---
 
+func newRedisMock() *redisMock {
+	return &redisMock{lock: new(sync.RWMutex)}
+}
+
 type redisMock struct {
 	lock       *sync.RWMutex
-	val        interface{}
+	owner      string
 	expiration time.Time
+	fencing    int64
+	err        error
 
 	attempted  int32
 	acquired   int32
 	maintained int32
 }
 
-func (r *redisMock) SetNX(
+func (r *redisMock) Eval(
 	ctx context.Context,
-	key string,
-	val interface{},
-	exp time.Duration,
-) (bool, error) {
+	script string,
+	keys []string,
+	args ...interface{},
+) (interface{}, error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.attempted++
 
-	if time.Now().UnixNano() > r.expiration.UnixNano() {
-		r.val = nil
-	}
-	if r.val != nil {
-		return false, nil
+	if time.Now().After(r.expiration) {
+		r.owner = ""
 	}
 
-	r.val = val
-	r.expiration = time.Now().Add(exp)
-	r.acquired++
-
-	return true, nil
+	switch script {
+	case acquireScript:
+		r.attempted++
+		if r.owner != "" {
+			return int64(0), nil
+		}
+		r.owner = args[0].(string)
+		r.expiration = time.Now().Add(time.Duration(args[1].(int64)) * time.Millisecond)
+		r.acquired++
+		r.fencing++
+		return r.fencing, nil
+
+	case refreshScript:
+		if r.err != nil {
+			return nil, r.err
+		}
+		if r.owner != args[0].(string) {
+			return int64(0), nil
+		}
+		r.expiration = time.Now().Add(time.Duration(args[1].(int64)) * time.Millisecond)
+		r.maintained++
+		return int64(1), nil
+
+	case releaseScript:
+		if r.owner != args[0].(string) {
+			return int64(0), nil
+		}
+		r.owner = ""
+		return int64(1), nil
+	}
+	return nil, errors.New("unexpected script")
 }
 
-func (r *redisMock) SetXX(
-	ctx context.Context,
-	key string,
-	val interface{},
-	exp time.Duration,
-) (bool, error) {
+// Steal sets the owner of the lock, regardless of the current owner. An empty
+// owner releases the lock.
+func (r *redisMock) Steal(owner string) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
+	r.owner = owner
+	r.expiration = time.Now().Add(time.Hour)
+}
 
-	if time.Now().UnixNano() > r.expiration.UnixNano() {
-		r.val = nil
-	}
-	if r.val == nil {
-		return false, nil
-	}
-	r.val = val
-	r.expiration = time.Now().Add(exp)
+// Fail causes subsequent refreshes to fail with err.
+func (r *redisMock) Fail(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.err = err
+}
 
-	r.maintained++
-	return true, nil
+func (r *redisMock) Owner() string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.owner
 }
 
 func (r *redisMock) Acquired() int {
@@ -144,3 +279,9 @@ func (r *redisMock) Attempted() int {
 	defer r.lock.RUnlock()
 	return int(r.attempted)
 }
+
+func (r *redisMock) Maintained() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return int(r.maintained)
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/tjper/rustcron/cmd/cronman/controller"
 	"github.com/tjper/rustcron/cmd/cronman/db"
 	"github.com/tjper/rustcron/cmd/cronman/director"
+	"github.com/tjper/rustcron/cmd/cronman/model"
 	"github.com/tjper/rustcron/cmd/cronman/rcon"
+	"github.com/tjper/rustcron/cmd/cronman/reconciler"
 	"github.com/tjper/rustcron/cmd/cronman/redis"
 	"github.com/tjper/rustcron/cmd/cronman/rest"
+	"github.com/tjper/rustcron/cmd/cronman/runner"
 	"github.com/tjper/rustcron/cmd/cronman/server"
 	"github.com/tjper/rustcron/cmd/cronman/stream"
 	"github.com/tjper/rustcron/internal/healthz"
@@ -40,7 +43,12 @@ func main() {
 	store := newDBConnection(logger)
 	migrateDB(logger, store)
 
-	serverDirector := newServerDirector(context.Background(), logger)
+	serverManagers := newServerManagers(context.Background(), logger)
+	serverDirector := controller.NewServerDirector(
+		serverManagers[model.RegionUsEast],
+		serverManagers[model.RegionUsWest],
+		serverManagers[model.RegionEuCentral],
+	)
 
 	redisClient := newRedisClient(context.Background(), logger)
 	streamClient := newStreamClient(context.Background(), logger, redisClient)
@@ -113,8 +121,79 @@ func main() {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := rconHub.Launch(ctx)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			logger.Error("[Startup] Failed to manage RCON connections.", zap.Error(err))
+			cancel()
+		}
+	}()
+
+	runner := runner.New(
+		logger,
+		store,
+		ctrl,
+		runner.WithPollInterval(config.JobPollInterval()),
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := runner.Run(ctx)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			logger.Error("[Startup] Failed to run jobs.", zap.Error(err))
+			cancel()
+		}
+	}()
+
+	if config.ReconcileEnabled() {
+		managers := make(map[model.Region]reconciler.IServerManager, len(serverManagers))
+		for region, manager := range serverManagers {
+			managers[region] = manager
+		}
+		reconciler := reconciler.New(
+			logger,
+			store,
+			managers,
+			reconciler.WithInterval(config.ReconcileInterval()),
+			reconciler.WithDryRun(config.ReconcileDryRun()),
+		)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := reconciler.Run(ctx)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			if err != nil {
+				logger.Error("[Startup] Failed to reconcile cloud resources.", zap.Error(err))
+				cancel()
+			}
+		}()
+	}
+
 	if config.DirectorEnabled() {
-		director := director.New(logger, redis.New(redisClient), store, ctrl)
+		director := director.New(
+			logger,
+			redis.New(redisClient),
+			store,
+			ctrl,
+			director.WithSaveInterval(config.SaveInterval()),
+			director.WithAdminReconcileInterval(config.AdminReconcileInterval()),
+			director.WithCatchUpLookback(config.CatchUpLookback()),
+		)
 
 		// Launch director.WatchAndDirect in separate goroutine. When goroutine
 		// closes decrement WaitGroup. If director.WatchAndDirect returns an
@@ -205,7 +284,7 @@ func newStreamClient(ctx context.Context, logger *zap.Logger, redisClient *redis
 	return streamClient
 }
 
-func newServerDirector(ctx context.Context, logger *zap.Logger) *controller.ServerDirector {
+func newServerManagers(ctx context.Context, logger *zap.Logger) map[model.Region]*server.Manager {
 	awscfg, err := awsconfig.LoadDefaultConfig(ctx)
 	if err != nil {
 		logger.Panic("[Startup] Failed to acquire AWS config.")
@@ -223,9 +302,9 @@ func newServerDirector(ctx context.Context, logger *zap.Logger) *controller.Serv
 	})
 	logger.Info("[Startup] Loaded eu-central-1 client.")
 
-	return controller.NewServerDirector(
-		server.NewManager(logger, usEastEC2),
-		server.NewManager(logger, usWestEC2),
-		server.NewManager(logger, euCentralEC2),
-	)
+	return map[model.Region]*server.Manager{
+		model.RegionUsEast:    server.NewManager(logger, usEastEC2),
+		model.RegionUsWest:    server.NewManager(logger, usWestEC2),
+		model.RegionEuCentral: server.NewManager(logger, euCentralEC2),
+	}
 }
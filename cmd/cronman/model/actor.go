@@ -0,0 +1,115 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ActorKind is the kind of Actor that performed an action.
+type ActorKind string
+
+const (
+	// ActorKindUser is a session user acting through the API.
+	ActorKindUser ActorKind = "user"
+	// ActorKindDirector is the director acting on a scheduled event.
+	ActorKindDirector ActorKind = "director"
+	// ActorKindSystem is cronman acting on its own, e.g. recovering servers
+	// at startup.
+	ActorKindSystem ActorKind = "system"
+)
+
+// Actor is who, or what, performed an action.
+type Actor struct {
+	Kind ActorKind `json:"kind"`
+	// ID identifies the Actor; the session user's ID, or the ID of the event
+	// being directed.
+	ID string `json:"id,omitempty"`
+	// Name is a human readable description of the Actor, e.g. the session
+	// user's email.
+	Name string `json:"name,omitempty"`
+	// FencingToken is the fencing token of the director lock held while the
+	// Actor performed the action, if any.
+	FencingToken int64 `json:"fencingToken,omitempty"`
+}
+
+// SystemActor is the Actor used when an action has no other Actor.
+var SystemActor = Actor{Kind: ActorKindSystem}
+
+// NewUserActor creates an Actor for the session user specified.
+func NewUserActor(id uuid.UUID, email string) Actor {
+	return Actor{Kind: ActorKindUser, ID: id.String(), Name: email}
+}
+
+// NewDirectorActor creates an Actor for the director directing the event
+// specified.
+func NewDirectorActor(event Event) Actor {
+	return Actor{
+		Kind: ActorKindDirector,
+		ID:   event.ID.String(),
+		Name: fmt.Sprintf("%s event", event.Kind),
+	}
+}
+
+// Value implements the driver.Valuer interface. Actor is stored as JSON.
+func (a Actor) Value() (driver.Value, error) {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling actor: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface. Actor is stored as JSON.
+func (a *Actor) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*a = Actor{}
+		return nil
+	default:
+		return fmt.Errorf("unexpected actor type %T", value)
+	}
+
+	if err := json.Unmarshal(b, a); err != nil {
+		return fmt.Errorf("while unmarshalling actor: %w", err)
+	}
+	return nil
+}
+
+type actorKey struct{}
+
+// WithActor creates a context that carries the Actor performing the actions
+// made with the context.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext retrieves the Actor carried by ctx, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+type fencingTokenKey struct{}
+
+// WithFencingToken creates a context that carries the fencing token of the
+// director lock held while performing the actions made with the context.
+// Server lifecycle transitions made with a fencing token are rejected if the
+// server has been transitioned with a greater fencing token.
+func WithFencingToken(ctx context.Context, token int64) context.Context {
+	return context.WithValue(ctx, fencingTokenKey{}, token)
+}
+
+// FencingTokenFromContext retrieves the fencing token carried by ctx, if any.
+func FencingTokenFromContext(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(int64)
+	return token, ok
+}
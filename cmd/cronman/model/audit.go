@@ -0,0 +1,264 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is the kind of action recorded by an AuditEntry.
+type AuditAction string
+
+const (
+	AuditActionCreateServer           AuditAction = "createServer"
+	AuditActionUpdateServer           AuditAction = "updateServer"
+	AuditActionArchiveServer          AuditAction = "archiveServer"
+	AuditActionDecommissionServer     AuditAction = "decommissionServer"
+	AuditActionUnarchiveServer        AuditAction = "unarchiveServer"
+	AuditActionStartServer            AuditAction = "startServer"
+	AuditActionMakeServerLive         AuditAction = "makeServerLive"
+	AuditActionStopServer             AuditAction = "stopServer"
+	AuditActionWipeServer             AuditAction = "wipeServer"
+	AuditActionRecoverServer          AuditAction = "recoverServer"
+	AuditActionAddServerTags          AuditAction = "addServerTags"
+	AuditActionRemoveServerTags       AuditAction = "removeServerTags"
+	AuditActionAddServerEvents        AuditAction = "addServerEvents"
+	AuditActionRemoveServerEvents     AuditAction = "removeServerEvents"
+	AuditActionAddServerBlackouts     AuditAction = "addServerBlackouts"
+	AuditActionRemoveServerBlackouts  AuditAction = "removeServerBlackouts"
+	AuditActionAddServerModerators    AuditAction = "addServerModerators"
+	AuditActionRemoveServerModerators AuditAction = "removeServerModerators"
+	AuditActionAddServerOwners        AuditAction = "addServerOwners"
+	AuditActionRemoveServerOwners     AuditAction = "removeServerOwners"
+	AuditActionAddServerBans          AuditAction = "addServerBans"
+	AuditActionRemoveServerBans       AuditAction = "removeServerBans"
+	AuditActionEnableServerPlugins    AuditAction = "enableServerPlugins"
+	AuditActionDisableServerPlugins   AuditAction = "disableServerPlugins"
+	AuditActionUpdateServerCountdown  AuditAction = "updateServerCountdown"
+	AuditActionKickServerPlayer       AuditAction = "kickServerPlayer"
+	AuditActionExecServerRcon         AuditAction = "execServerRcon"
+	AuditActionReconcileServerAdmins  AuditAction = "reconcileServerAdmins"
+)
+
+// AuditActions is every AuditAction.
+var AuditActions = []AuditAction{
+	AuditActionCreateServer,
+	AuditActionUpdateServer,
+	AuditActionArchiveServer,
+	AuditActionDecommissionServer,
+	AuditActionUnarchiveServer,
+	AuditActionStartServer,
+	AuditActionMakeServerLive,
+	AuditActionStopServer,
+	AuditActionWipeServer,
+	AuditActionRecoverServer,
+	AuditActionAddServerTags,
+	AuditActionRemoveServerTags,
+	AuditActionAddServerEvents,
+	AuditActionRemoveServerEvents,
+	AuditActionAddServerBlackouts,
+	AuditActionRemoveServerBlackouts,
+	AuditActionAddServerModerators,
+	AuditActionRemoveServerModerators,
+	AuditActionAddServerOwners,
+	AuditActionRemoveServerOwners,
+	AuditActionAddServerBans,
+	AuditActionRemoveServerBans,
+	AuditActionEnableServerPlugins,
+	AuditActionDisableServerPlugins,
+	AuditActionUpdateServerCountdown,
+	AuditActionKickServerPlayer,
+	AuditActionExecServerRcon,
+	AuditActionReconcileServerAdmins,
+}
+
+// IsValid reports if the AuditAction is known.
+func (a AuditAction) IsValid() bool {
+	for _, action := range AuditActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditOutcome is the outcome of an audited action.
+type AuditOutcome string
+
+const (
+	AuditOutcomeSucceeded AuditOutcome = "succeeded"
+	AuditOutcomeFailed    AuditOutcome = "failed"
+)
+
+// AuditEntry records an action performed on a server. AuditEntries are
+// append-only, they are never updated or deleted.
+type AuditEntry struct {
+	ID       uuid.UUID `gorm:"default:gen_random_uuid()"`
+	ServerID uuid.UUID
+	Actor    Actor
+	Action   AuditAction
+	// Changes are the changes the action made to the server.
+	Changes AuditChanges
+	Outcome AuditOutcome
+	// Error is the error the action failed with, if any.
+	Error     string
+	CreatedAt time.Time
+}
+
+// NewAuditEntry creates an AuditEntry recording that actor performed action
+// on the server specified by serverID. A nil err indicates the action
+// succeeded.
+func NewAuditEntry(
+	serverID uuid.UUID,
+	actor Actor,
+	action AuditAction,
+	changes AuditChanges,
+	err error,
+) *AuditEntry {
+	entry := &AuditEntry{
+		ServerID: serverID,
+		Actor:    actor,
+		Action:   action,
+		Changes:  changes,
+		Outcome:  AuditOutcomeSucceeded,
+	}
+	if err != nil {
+		entry.Outcome = AuditOutcomeFailed
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// Scrub removes unpredictable data from the AuditEntry.
+func (e *AuditEntry) Scrub() {
+	e.ID = uuid.Nil
+	e.CreatedAt = time.Time{}
+}
+
+// AuditChange is the value of a server attribute before and after an
+// action.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges are the changes made to a server's attributes, keyed by
+// attribute.
+type AuditChanges map[string]AuditChange
+
+// Value implements the driver.Valuer interface. AuditChanges are stored as
+// JSON.
+func (c AuditChanges) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling audit changes: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface. AuditChanges are stored as JSON.
+func (c *AuditChanges) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = AuditChanges{}
+		return nil
+	default:
+		return fmt.Errorf("unexpected audit changes type %T", value)
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("while unmarshalling audit changes: %w", err)
+	}
+	return nil
+}
+
+// redacted is recorded in place of the values of sensitive attributes.
+const redacted = "[redacted]"
+
+// sensitiveAttributes are server attributes whose values are never recorded,
+// only that they changed.
+var sensitiveAttributes = map[string]struct{}{
+	"rconPassword": {},
+}
+
+// AuditSnapshot is the state of a server's audited attributes at a point in
+// time, keyed by attribute.
+type AuditSnapshot map[string]interface{}
+
+// AuditSnapshot captures the Server's audited attributes; its configuration,
+// state, lifecycle, cloud resources and bans.
+func (s Server) AuditSnapshot() (AuditSnapshot, error) {
+	b, err := json.Marshal(s.Config())
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling server config: %w", err)
+	}
+
+	var snapshot AuditSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, fmt.Errorf("while unmarshalling server config: %w", err)
+	}
+
+	bans := make([]interface{}, 0, len(s.Bans))
+	for _, ban := range s.Bans {
+		bans = append(bans, ban.SteamID)
+	}
+
+	snapshot["state"] = s.StateType
+	snapshot["lifecycle"] = string(s.Lifecycle)
+	snapshot["lifecycleReason"] = s.LifecycleReason
+	snapshot["instanceId"] = s.InstanceID
+	snapshot["allocationId"] = s.AllocationID
+	snapshot["elasticIp"] = s.ElasticIP
+	snapshot["bans"] = bans
+	return snapshot, nil
+}
+
+// Diff determines the changes between the AuditSnapshot and the AuditSnapshot
+// after. A nil AuditSnapshot represents a server that does not exist. The
+// values of sensitive attributes are redacted.
+func (s AuditSnapshot) Diff(after AuditSnapshot) AuditChanges {
+	changes := make(AuditChanges)
+
+	diff := func(attribute string) {
+		before, beforeOk := s[attribute]
+		afterValue, afterOk := after[attribute]
+		if beforeOk == afterOk && reflect.DeepEqual(before, afterValue) {
+			return
+		}
+
+		change := AuditChange{Before: before, After: afterValue}
+		if _, ok := sensitiveAttributes[attribute]; ok {
+			change = AuditChange{Before: redactedValue(beforeOk), After: redactedValue(afterOk)}
+		}
+		changes[attribute] = change
+	}
+
+	for attribute := range s {
+		diff(attribute)
+	}
+	for attribute := range after {
+		if _, ok := s[attribute]; ok {
+			continue
+		}
+		diff(attribute)
+	}
+	return changes
+}
+
+// redactedValue is the value recorded for a sensitive attribute; redacted if
+// the attribute exists, otherwise nil.
+func redactedValue(exists bool) interface{} {
+	if !exists {
+		return nil
+	}
+	return redacted
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSnapshotDiff(t *testing.T) {
+	t.Parallel()
+
+	server := Server{
+		Model:        model.Model{ID: uuid.New()},
+		StateType:    DormantServerState,
+		Name:         "server",
+		RconPassword: "before-password",
+		Lifecycle:    LifecycleIdle,
+		Owners:       Owners{{SteamID: "owner"}},
+		Bans:         Bans{{SteamID: "banned"}},
+		Countdown:    DefaultCountdown.Clone(),
+	}
+
+	snapshot := func(server Server) AuditSnapshot {
+		snapshot, err := server.AuditSnapshot()
+		require.Nil(t, err)
+		return snapshot
+	}
+
+	tests := map[string]struct {
+		before AuditSnapshot
+		after  AuditSnapshot
+		exp    AuditChanges
+	}{
+		"unchanged": {
+			before: snapshot(server),
+			after:  snapshot(server),
+			exp:    AuditChanges{},
+		},
+		"owners and state": {
+			before: snapshot(server),
+			after: snapshot(func() Server {
+				changed := *server.Clone()
+				changed.Owners = append(changed.Owners, Owner{SteamID: "co-owner"})
+				changed.StateType = LiveServerState
+				return changed
+			}()),
+			exp: AuditChanges{
+				"owners": {
+					Before: []interface{}{"owner"},
+					After:  []interface{}{"owner", "co-owner"},
+				},
+				"state": {Before: DormantServerState, After: LiveServerState},
+			},
+		},
+		"rcon password redacted": {
+			before: snapshot(server),
+			after: snapshot(func() Server {
+				changed := *server.Clone()
+				changed.RconPassword = "after-password"
+				return changed
+			}()),
+			exp: AuditChanges{
+				"rconPassword": {Before: redacted, After: redacted},
+			},
+		},
+		"created": {
+			before: nil,
+			after:  AuditSnapshot{"name": "server", "rconPassword": "password"},
+			exp: AuditChanges{
+				"name":         {Before: nil, After: "server"},
+				"rconPassword": {Before: nil, After: redacted},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, test.exp, test.before.Diff(test.after))
+		})
+	}
+}
+
+func TestNewAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	actor := NewUserActor(uuid.New(), "admin@rustpm.com")
+
+	entry := NewAuditEntry(serverID, actor, AuditActionStopServer, AuditChanges{}, nil)
+	require.Equal(t, AuditOutcomeSucceeded, entry.Outcome)
+	require.Empty(t, entry.Error)
+
+	entry = NewAuditEntry(serverID, actor, AuditActionStopServer, AuditChanges{}, errors.New("rcon unavailable"))
+	require.Equal(t, AuditOutcomeFailed, entry.Outcome)
+	require.Equal(t, "rcon unavailable", entry.Error)
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"github.com/tjper/rustcron/cmd/cronman/userdata"
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type Bans []Ban
+
+func (dbs Bans) Clone() Bans {
+	cloned := make(Bans, 0, len(dbs))
+	for _, db := range dbs {
+		cloned = append(cloned, db.Clone())
+	}
+	return cloned
+}
+
+func (dbs Bans) Scrub() {
+	for i := range dbs {
+		dbs[i].Scrub()
+	}
+}
+
+func (dbs Bans) SteamIDs() []string {
+	steamIDs := make([]string, 0, len(dbs))
+	for _, ban := range dbs {
+		steamIDs = append(steamIDs, ban.SteamID)
+	}
+	return steamIDs
+}
+
+// Userdata converts the Bans into the form expected by userdata.WithBansCfg.
+func (dbs Bans) Userdata() []userdata.Ban {
+	bans := make([]userdata.Ban, 0, len(dbs))
+	for _, ban := range dbs {
+		bans = append(bans, userdata.Ban{SteamID: ban.SteamID, Reason: ban.Reason})
+	}
+	return bans
+}
+
+// Ban is a steam ID that is not permitted to join a server.
+type Ban struct {
+	model.Model
+	SteamID  string
+	Reason   string
+	ServerID uuid.UUID
+}
+
+func (db Ban) Clone() Ban {
+	return db
+}
+
+func (db *Ban) Scrub() {
+	db.Model.Scrub()
+	db.ServerID = uuid.Nil
+}
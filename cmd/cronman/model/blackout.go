@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// Blackouts is a slice of Blackout instances.
+type Blackouts []Blackout
+
+// Suppresses checks if the occurrence of the Event at the specified time is
+// suppressed by any of the Blackouts. One-off Events are never suppressed.
+func (bs Blackouts) Suppresses(e Event, at time.Time) bool {
+	_, ok := bs.suppressing(e, at)
+	return ok
+}
+
+// suppressing retrieves the Blackout that suppresses the occurrence of the
+// Event at the specified time, if any.
+func (bs Blackouts) suppressing(e Event, at time.Time) (Blackout, bool) {
+	if e.IsOneOff() {
+		return Blackout{}, false
+	}
+	for _, b := range bs {
+		if b.Matches(e, at) {
+			return b, true
+		}
+	}
+	return Blackout{}, false
+}
+
+func (bs Blackouts) Clone() Blackouts {
+	cloned := make(Blackouts, 0, len(bs))
+	cloned = append(cloned, bs...)
+	return cloned
+}
+
+func (bs Blackouts) Scrub() {
+	for i := range bs {
+		bs[i].Scrub()
+	}
+}
+
+// Blackout is a window of time during which a server's recurring Events do
+// not occur, e.g. skipping a wipe on a holiday. The window starts at StartsAt
+// and ends prior to EndsAt. A Blackout with a Kind only suppresses Events of
+// that kind, otherwise Events of every kind are suppressed.
+type Blackout struct {
+	model.Model
+	ServerID uuid.UUID
+	StartsAt time.Time
+	EndsAt   time.Time
+	Kind     EventKind
+	Reason   string
+}
+
+// Matches checks if the Blackout suppresses the occurrence of the Event at
+// the specified time.
+func (b Blackout) Matches(e Event, at time.Time) bool {
+	if b.Kind != "" && b.Kind != e.Kind {
+		return false
+	}
+	return !at.Before(b.StartsAt) && at.Before(b.EndsAt)
+}
+
+func (b *Blackout) Scrub() {
+	b.Model.Scrub()
+	b.ServerID = uuid.Nil
+}
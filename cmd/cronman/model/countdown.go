@@ -0,0 +1,189 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultCountdown is the Countdown used by servers that have not configured
+// their own. Players are warned 10, 5 and 1 minute(s) prior, followed by a
+// final 10 second countdown.
+var DefaultCountdown = Countdown{
+	Warnings:        []uint32{600, 300, 60},
+	FinalSeconds:    10,
+	WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}. Visit rustpm.com for more scheduling information.",
+	FinalTemplate:   "{{.Server}} will be {{.Action}} in {{.Remaining}}...",
+}
+
+// Countdown is a server's pre-shutdown warning schedule. Countdown warnings
+// are broadcast to players prior to stop and wipe events.
+type Countdown struct {
+	// Warnings are the number of seconds prior to an event at which a warning
+	// is broadcast.
+	Warnings []uint32 `json:"warnings"`
+	// FinalSeconds is the number of seconds prior to an event at which a
+	// broadcast is made every second. Zero disables the final countdown.
+	FinalSeconds uint32 `json:"finalSeconds"`
+	// WarningTemplate is the text/template used to build each warning.
+	WarningTemplate string `json:"warningTemplate"`
+	// FinalTemplate is the text/template used to build each second of the
+	// final countdown.
+	FinalTemplate string `json:"finalTemplate"`
+}
+
+// CountdownData is the data a Countdown's templates are executed with.
+type CountdownData struct {
+	// Server is the name of the server.
+	Server string
+	// Action describes the event, e.g. "going offline".
+	Action string
+	// Remaining is the human readable time remaining, e.g. "5 minutes".
+	Remaining string
+	// Seconds is the number of seconds remaining.
+	Seconds uint32
+}
+
+// Broadcast is a message to be broadcast to a server's players at a point in
+// time.
+type Broadcast struct {
+	At      time.Time
+	Message string
+}
+
+var errCountdownEventKind = errors.New("countdown event kind invalid")
+
+// Broadcasts builds the Broadcasts that make up the Countdown to the event of
+// the specified kind occurring at the specified time on the server specified
+// by name. Broadcasts are ordered by time.
+func (c Countdown) Broadcasts(name string, kind EventKind, at time.Time) ([]Broadcast, error) {
+	var action string
+	switch kind {
+	case EventKindStop:
+		action = "going offline"
+	case EventKindMapWipe, EventKindFullWipe:
+		action = "wiping"
+	default:
+		return nil, fmt.Errorf("%w: %s", errCountdownEventKind, kind)
+	}
+
+	warning, err := template.New("warning").Parse(c.WarningTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing countdown warning template: %w", err)
+	}
+	final, err := template.New("final").Parse(c.FinalTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing countdown final template: %w", err)
+	}
+
+	seconds := make(map[uint32]*template.Template)
+	for _, warn := range c.Warnings {
+		if warn > c.FinalSeconds {
+			seconds[warn] = warning
+		}
+	}
+	for i := uint32(1); i <= c.FinalSeconds; i++ {
+		seconds[i] = final
+	}
+
+	broadcasts := make([]Broadcast, 0, len(seconds))
+	for s, tmpl := range seconds {
+		data := CountdownData{
+			Server:    name,
+			Action:    action,
+			Remaining: remaining(s),
+			Seconds:   s,
+		}
+
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("while executing countdown template: %w", err)
+		}
+
+		broadcasts = append(broadcasts, Broadcast{
+			At:      at.Add(-time.Duration(s) * time.Second),
+			Message: b.String(),
+		})
+	}
+
+	sort.Slice(broadcasts, func(i, j int) bool {
+		return broadcasts[i].At.Before(broadcasts[j].At)
+	})
+	return broadcasts, nil
+}
+
+// Validate ensures the Countdown's templates may be parsed and executed.
+func (c Countdown) Validate() error {
+	_, err := c.Broadcasts("server", EventKindStop, time.Time{})
+	return err
+}
+
+// Value implements the driver.Valuer interface. Countdown is stored as JSON.
+func (c Countdown) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling countdown: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface. Countdown is stored as JSON.
+func (c *Countdown) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = Countdown{}
+		return nil
+	default:
+		return fmt.Errorf("unexpected countdown type %T", value)
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("while unmarshalling countdown: %w", err)
+	}
+	return nil
+}
+
+// Clone creates a deep copy of the Countdown.
+func (c Countdown) Clone() Countdown {
+	cloned := c
+	cloned.Warnings = append([]uint32(nil), c.Warnings...)
+	return cloned
+}
+
+// remaining formats the number of seconds as human readable time remaining,
+// e.g. "1 hour and 5 minutes", "10 minutes", "1 minute", "10 seconds".
+func remaining(seconds uint32) string {
+	plural := func(n uint32, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	hours := seconds / 3600
+	minutes := seconds % 3600 / 60
+	secs := seconds % 60
+
+	parts := make([]string, 0, 3)
+	if hours > 0 {
+		parts = append(parts, plural(hours, "hour"))
+	}
+	if minutes > 0 {
+		parts = append(parts, plural(minutes, "minute"))
+	}
+	if secs > 0 || len(parts) == 0 {
+		parts = append(parts, plural(secs, "second"))
+	}
+	return strings.Join(parts, " and ")
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountdownBroadcasts(t *testing.T) {
+	at := time.Date(2022, time.May, 26, 20, 0, 0, 0, time.UTC)
+
+	type expected struct {
+		broadcasts []Broadcast
+		err        error
+	}
+	tests := map[string]struct {
+		countdown Countdown
+		kind      EventKind
+		exp       expected
+	}{
+		"stop": {
+			countdown: Countdown{
+				Warnings:        []uint32{600, 60},
+				FinalSeconds:    2,
+				WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}.",
+				FinalTemplate:   "{{.Seconds}}...",
+			},
+			kind: EventKindStop,
+			exp: expected{
+				broadcasts: []Broadcast{
+					{At: at.Add(-10 * time.Minute), Message: "test-server will be going offline in 10 minutes."},
+					{At: at.Add(-time.Minute), Message: "test-server will be going offline in 1 minute."},
+					{At: at.Add(-2 * time.Second), Message: "2..."},
+					{At: at.Add(-time.Second), Message: "1..."},
+				},
+			},
+		},
+		"wipe": {
+			countdown: Countdown{
+				Warnings:        []uint32{3900},
+				WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}.",
+			},
+			kind: EventKindMapWipe,
+			exp: expected{
+				broadcasts: []Broadcast{
+					{At: at.Add(-65 * time.Minute), Message: "test-server will be wiping in 1 hour and 5 minutes."},
+				},
+			},
+		},
+		"warnings within final countdown are dropped": {
+			countdown: Countdown{
+				Warnings:        []uint32{60, 60, 5},
+				FinalSeconds:    5,
+				WarningTemplate: "warning {{.Remaining}}",
+				FinalTemplate:   "final {{.Seconds}}",
+			},
+			kind: EventKindFullWipe,
+			exp: expected{
+				broadcasts: []Broadcast{
+					{At: at.Add(-time.Minute), Message: "warning 1 minute"},
+					{At: at.Add(-5 * time.Second), Message: "final 5"},
+					{At: at.Add(-4 * time.Second), Message: "final 4"},
+					{At: at.Add(-3 * time.Second), Message: "final 3"},
+					{At: at.Add(-2 * time.Second), Message: "final 2"},
+					{At: at.Add(-time.Second), Message: "final 1"},
+				},
+			},
+		},
+		"start": {
+			countdown: DefaultCountdown,
+			kind:      EventKindStart,
+			exp:       expected{err: errCountdownEventKind},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			broadcasts, err := test.countdown.Broadcasts("test-server", test.kind, at)
+			require.True(t, errors.Is(err, test.exp.err), "unexpected error: %v", err)
+			require.Equal(t, test.exp.broadcasts, broadcasts)
+		})
+	}
+}
+
+func TestCountdownValidate(t *testing.T) {
+	tests := map[string]struct {
+		countdown Countdown
+		valid     bool
+	}{
+		"default": {countdown: DefaultCountdown, valid: true},
+		"unparsable": {
+			countdown: Countdown{Warnings: []uint32{60}, WarningTemplate: "{{.Server"},
+		},
+		"unknown field": {
+			countdown: Countdown{Warnings: []uint32{60}, WarningTemplate: "{{.Unknown}}"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.countdown.Validate()
+			require.Equal(t, test.valid, err == nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	tests := map[uint32]string{
+		0:    "0 seconds",
+		1:    "1 second",
+		10:   "10 seconds",
+		60:   "1 minute",
+		90:   "1 minute and 30 seconds",
+		300:  "5 minutes",
+		3600: "1 hour",
+		7260: "2 hours and 1 minute",
+	}
+
+	for seconds, exp := range tests {
+		require.Equal(t, exp, remaining(seconds))
+	}
+}
@@ -13,43 +13,43 @@ import (
 
 type Events []Event
 
-var errNoFutureEvent = errors.New("no future event occurrence")
+// ErrNoFutureEvent indicates that an Event does not occur after the time
+// specified, e.g. a one-off Event that has already occurred.
+var ErrNoFutureEvent = errors.New("no future event occurrence")
 
 // NextEvent retrieves the next Event to occur after t of type kind. The first
 // return value is the next Event instance. The second return value is the
 // next time at which the event occurs. The third return value is a non-nil
-// error if a problem occurred determining the next event.
-func (es Events) NextEvent(t time.Time, kind EventKind) (*Event, *time.Time, error) {
-	var next Event
-	for _, e := range es {
+// error if a problem occurred determining the next event. Events are
+// evaluated in the location of t, see Event.Next. Occurrences suppressed by
+// the blackouts are skipped.
+func (es Events) NextEvent(t time.Time, kind EventKind, blackouts Blackouts) (*Event, *time.Time, error) {
+	var next *Event
+	var at time.Time
+	for i := range es {
+		e := es[i]
 		if e.Kind != kind {
 			continue
 		}
-		if (next == Event{}) {
-			next = e
+
+		potential, err := e.NextExcept(t, blackouts)
+		if errors.Is(err, ErrNoFutureEvent) {
 			continue
 		}
-
-		potential, err := e.Next(t)
 		if err != nil {
 			return nil, nil, fmt.Errorf("while determining next potential event: %w", err)
 		}
-		current, err := next.Next(t)
-		if err != nil {
-			return nil, nil, fmt.Errorf("while determining current event: %w", err)
-		}
 
-		if potential.Before(current) {
-			next = e
+		if next == nil || potential.Before(at) {
+			next = &e
+			at = potential
 		}
 	}
 
-	if (next == Event{}) {
-		return nil, nil, errNoFutureEvent
+	if next == nil {
+		return nil, nil, ErrNoFutureEvent
 	}
-
-	at, err := next.Next(t)
-	return &next, &at, err
+	return next, &at, nil
 }
 
 func (es Events) Clone() Events {
@@ -66,15 +66,36 @@ func (es Events) Scrub() {
 	}
 }
 
+// Event is a server event. An Event either recurs on its Schedule and
+// Weekday, or is a one-off Event that occurs once at RunAt.
 type Event struct {
 	model.Model
 	Schedule string
 	Weekday  *time.Weekday
+	RunAt    *time.Time
 	Kind     EventKind
 	ServerID uuid.UUID
 }
 
+// IsOneOff checks if the Event occurs once at RunAt, rather than recurring.
+func (e Event) IsOneOff() bool {
+	return e.RunAt != nil
+}
+
+// Next retrieves the next time the Event occurs after the specified time. The
+// Event's Schedule and Weekday are evaluated in the location of after, e.g.
+// "0 20 * * *" occurs at 20:00 in after's location regardless of daylight
+// saving time. The returned time is in the location of after. A one-off
+// Event occurs at RunAt, ErrNoFutureEvent is returned if RunAt is not after
+// the specified time.
 func (e Event) Next(after time.Time) (time.Time, error) {
+	if e.IsOneOff() {
+		if !e.RunAt.After(after) {
+			return time.Time{}, ErrNoFutureEvent
+		}
+		return e.RunAt.In(after.Location()), nil
+	}
+
 	schedule, err := cron.ParseStandard(e.Schedule)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("parse schedule; id: %s, error: %w", e.ID, err)
@@ -91,10 +112,34 @@ func (e Event) Next(after time.Time) (time.Time, error) {
 	return e.Next(potential)
 }
 
+// NextExcept retrieves the next time the Event occurs after the specified
+// time, skipping occurrences suppressed by the blackouts. See Event.Next.
+func (e Event) NextExcept(after time.Time, blackouts Blackouts) (time.Time, error) {
+	for {
+		next, err := e.Next(after)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		blackout, ok := blackouts.suppressing(e, next)
+		if !ok {
+			return next, nil
+		}
+		// Resume after the blackout, occurrences at EndsAt are not suppressed.
+		after = blackout.EndsAt.Add(-time.Nanosecond).In(after.Location())
+	}
+}
+
+// Occurrences retrieves each time the Event occurs after the specified time
+// and until the specified time. Like Next, the Event is evaluated in the
+// location of after.
 func (e Event) Occurrences(after, until time.Time) ([]time.Time, error) {
 	occurrences := make([]time.Time, 0)
 	for {
 		next, err := e.Next(after)
+		if errors.Is(err, ErrNoFutureEvent) {
+			return occurrences, nil
+		}
 		if err != nil {
 			return nil, fmt.Errorf("occurrences; id: %s, error: %w", e.ID, err)
 		}
@@ -124,6 +169,55 @@ func (e *Event) Scrub() {
 	e.ServerID = uuid.Nil
 }
 
+// ZonedEvent is an Event along with the IANA time zone of its server.
+type ZonedEvent struct {
+	Event
+	TimeZone string
+}
+
+// Location retrieves the location of the ZonedEvent's time zone.
+func (e ZonedEvent) Location() *time.Location {
+	return LoadLocation(e.TimeZone)
+}
+
+// Spec retrieves the ZonedEvent's Schedule as a cron spec in the time zone of
+// its server.
+func (e ZonedEvent) Spec() string {
+	return fmt.Sprintf("CRON_TZ=%s %s", e.Location(), e.Schedule)
+}
+
+// Next retrieves the next time the ZonedEvent occurs after the specified
+// time, evaluated in the time zone of its server.
+func (e ZonedEvent) Next(after time.Time) (time.Time, error) {
+	return e.Event.Next(after.In(e.Location()))
+}
+
+// NextExcept retrieves the next time the ZonedEvent occurs after the
+// specified time, evaluated in the time zone of its server, skipping
+// occurrences suppressed by the blackouts.
+func (e ZonedEvent) NextExcept(after time.Time, blackouts Blackouts) (time.Time, error) {
+	return e.Event.NextExcept(after.In(e.Location()), blackouts)
+}
+
+// IsWeekDay checks if t falls on the ZonedEvent's Weekday in the time zone of
+// its server.
+func (e ZonedEvent) IsWeekDay(t time.Time) bool {
+	return e.Event.IsWeekDay(t.In(e.Location()))
+}
+
+// LoadLocation retrieves the location of the IANA time zone specified. UTC is
+// returned if the time zone is empty or unknown.
+func LoadLocation(timeZone string) *time.Location {
+	if timeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 type EventKind string
 
 const (
@@ -133,3 +227,12 @@ const (
 	EventKindFullWipe EventKind = "fullWipe"
 	EventKindMapWipe  EventKind = "mapWipe"
 )
+
+// IsValid reports if the EventKind is known.
+func (k EventKind) IsValid() bool {
+	switch k {
+	case EventKindStart, EventKindStop, EventKindLive, EventKindFullWipe, EventKindMapWipe:
+		return true
+	}
+	return false
+}
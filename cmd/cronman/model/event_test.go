@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,10 +14,11 @@ func TestEventsNextEvent(t *testing.T) {
 		when  time.Time
 	}
 	tests := map[string]struct {
-		dt     time.Time
-		kind   EventKind
-		events Events
-		exp    expected
+		dt        time.Time
+		kind      EventKind
+		events    Events
+		blackouts Blackouts
+		exp       expected
 	}{
 		"daily start stop": {
 			dt:   time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
@@ -126,10 +128,106 @@ func TestEventsNextEvent(t *testing.T) {
 				when:  time.Date(2020, time.November, 5, 18, 0, 0, 0, time.UTC),
 			},
 		},
+		"one-off start before daily start": {
+			dt:   time.Date(2020, time.December, 24, 12, 0, 0, 0, time.UTC),
+			kind: EventKindStart,
+			events: Events{
+				{Schedule: "0 20 * * *", Kind: EventKindStart},
+				{RunAt: at(time.Date(2020, time.December, 24, 16, 0, 0, 0, time.UTC)), Kind: EventKindStart},
+			},
+			exp: expected{
+				event: Event{RunAt: at(time.Date(2020, time.December, 24, 16, 0, 0, 0, time.UTC)), Kind: EventKindStart},
+				when:  time.Date(2020, time.December, 24, 16, 0, 0, 0, time.UTC),
+			},
+		},
+		"past one-off start": {
+			dt:   time.Date(2020, time.December, 24, 17, 0, 0, 0, time.UTC),
+			kind: EventKindStart,
+			events: Events{
+				{RunAt: at(time.Date(2020, time.December, 24, 16, 0, 0, 0, time.UTC)), Kind: EventKindStart},
+				{Schedule: "0 20 * * *", Kind: EventKindStart},
+			},
+			exp: expected{
+				event: Event{Schedule: "0 20 * * *", Kind: EventKindStart},
+				when:  time.Date(2020, time.December, 24, 20, 0, 0, 0, time.UTC),
+			},
+		},
+		"blackout skips mapwipe": {
+			dt:   time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
+			kind: EventKindMapWipe,
+			events: Events{
+				{Schedule: "0 18 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+			},
+			blackouts: Blackouts{
+				{
+					StartsAt: time.Date(2020, time.September, 17, 0, 0, 0, 0, time.UTC),
+					EndsAt:   time.Date(2020, time.September, 18, 0, 0, 0, 0, time.UTC),
+					Kind:     EventKindMapWipe,
+				},
+			},
+			exp: expected{
+				event: Event{Schedule: "0 18 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+				when:  time.Date(2020, time.September, 24, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		"blackout of other kind": {
+			dt:   time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
+			kind: EventKindStop,
+			events: Events{
+				{Schedule: "0 6 * * *", Kind: EventKindStop},
+			},
+			blackouts: Blackouts{
+				{
+					StartsAt: time.Date(2020, time.September, 17, 0, 0, 0, 0, time.UTC),
+					EndsAt:   time.Date(2020, time.September, 18, 0, 0, 0, 0, time.UTC),
+					Kind:     EventKindMapWipe,
+				},
+			},
+			exp: expected{
+				event: Event{Schedule: "0 6 * * *", Kind: EventKindStop},
+				when:  time.Date(2020, time.September, 17, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		"blackout ends at occurrence": {
+			dt:   time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
+			kind: EventKindStop,
+			events: Events{
+				{Schedule: "0 6 * * *", Kind: EventKindStop},
+			},
+			blackouts: Blackouts{
+				{
+					StartsAt: time.Date(2020, time.September, 16, 20, 0, 0, 0, time.UTC),
+					EndsAt:   time.Date(2020, time.September, 18, 6, 0, 0, 0, time.UTC),
+				},
+			},
+			exp: expected{
+				event: Event{Schedule: "0 6 * * *", Kind: EventKindStop},
+				when:  time.Date(2020, time.September, 18, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		"one-off stop during blackout": {
+			dt:   time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
+			kind: EventKindStop,
+			events: Events{
+				{Schedule: "0 6 * * *", Kind: EventKindStop},
+				{RunAt: at(time.Date(2020, time.September, 17, 12, 0, 0, 0, time.UTC)), Kind: EventKindStop},
+			},
+			blackouts: Blackouts{
+				{
+					StartsAt: time.Date(2020, time.September, 17, 0, 0, 0, 0, time.UTC),
+					EndsAt:   time.Date(2020, time.September, 18, 0, 0, 0, 0, time.UTC),
+					Kind:     EventKindStop,
+				},
+			},
+			exp: expected{
+				event: Event{RunAt: at(time.Date(2020, time.September, 17, 12, 0, 0, 0, time.UTC)), Kind: EventKindStop},
+				when:  time.Date(2020, time.September, 17, 12, 0, 0, 0, time.UTC),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			event, when, err := test.events.NextEvent(test.dt, test.kind)
+			event, when, err := test.events.NextEvent(test.dt, test.kind, test.blackouts)
 			require.Nil(t, err)
 			require.Equal(t, test.exp.event, *event)
 			require.Equal(t, test.exp.when, *when)
@@ -244,6 +342,16 @@ func TestEventOccurrences(t *testing.T) {
 				},
 			},
 		},
+		"one-off": {
+			event: Event{RunAt: at(time.Date(2020, time.September, 18, 16, 0, 0, 0, time.UTC)), Kind: EventKindStart},
+			after: time.Date(2020, time.September, 16, 19, 0, 0, 0, time.UTC),
+			until: time.Date(2020, time.September, 23, 19, 0, 0, 0, time.UTC),
+			exp: expected{
+				occurrences: []time.Time{
+					time.Date(2020, time.September, 18, 16, 0, 0, 0, time.UTC),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -255,6 +363,112 @@ func TestEventOccurrences(t *testing.T) {
 	}
 }
 
+func TestEventTimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.Nil(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.Nil(t, err)
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.Nil(t, err)
+
+	tests := map[string]struct {
+		event Event
+		after time.Time
+		until time.Time
+		exp   []time.Time
+	}{
+		"spring forward": {
+			event: Event{Schedule: "0 20 * * *", Kind: EventKindStart},
+			after: time.Date(2022, time.March, 11, 12, 0, 0, 0, newYork),
+			until: time.Date(2022, time.March, 14, 23, 0, 0, 0, newYork),
+			exp: []time.Time{
+				time.Date(2022, time.March, 12, 1, 0, 0, 0, time.UTC),
+				time.Date(2022, time.March, 13, 1, 0, 0, 0, time.UTC),
+				time.Date(2022, time.March, 14, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"fall back": {
+			event: Event{Schedule: "0 18 * * *", Kind: EventKindStop},
+			after: time.Date(2022, time.October, 29, 12, 0, 0, 0, london),
+			until: time.Date(2022, time.October, 31, 23, 0, 0, 0, london),
+			exp: []time.Time{
+				time.Date(2022, time.October, 29, 17, 0, 0, 0, time.UTC),
+				time.Date(2022, time.October, 30, 18, 0, 0, 0, time.UTC),
+				time.Date(2022, time.October, 31, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		"weekday across utc midnight": {
+			event: Event{Schedule: "0 20 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+			after: time.Date(2022, time.November, 1, 0, 0, 0, 0, losAngeles),
+			until: time.Date(2022, time.November, 14, 0, 0, 0, 0, losAngeles),
+			exp: []time.Time{
+				// Daylight saving time ends November 6th.
+				time.Date(2022, time.November, 4, 3, 0, 0, 0, time.UTC),
+				time.Date(2022, time.November, 11, 4, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			occurrences, err := test.event.Occurrences(test.after, test.until)
+			require.Nil(t, err)
+
+			utc := make([]time.Time, 0, len(occurrences))
+			for _, occurrence := range occurrences {
+				utc = append(utc, occurrence.UTC())
+			}
+			require.Equal(t, test.exp, utc)
+		})
+	}
+}
+
+func TestServerNextEvent(t *testing.T) {
+	server := Server{
+		TimeZone: "Europe/Berlin",
+		Events: Events{
+			{Schedule: "0 20 * * *", Kind: EventKindStart},
+			{Schedule: "0 6 * * *", Kind: EventKindStop},
+		},
+	}
+
+	// 2022-03-27 is the first day of central european summer time.
+	_, when, err := server.NextEvent(time.Date(2022, time.March, 26, 20, 0, 0, 0, time.UTC), EventKindStop)
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2022, time.March, 27, 4, 0, 0, 0, time.UTC), when.UTC())
+
+	server.TimeZone = ""
+	_, when, err = server.NextEvent(time.Date(2022, time.March, 26, 20, 0, 0, 0, time.UTC), EventKindStop)
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2022, time.March, 27, 6, 0, 0, 0, time.UTC), when.UTC())
+}
+
+func TestZonedEvent(t *testing.T) {
+	event := ZonedEvent{
+		Event:    Event{Schedule: "0 20 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+		TimeZone: "America/New_York",
+	}
+	require.Equal(t, "CRON_TZ=America/New_York 0 20 * * *", event.Spec())
+
+	schedule, err := cron.ParseStandard(event.Spec())
+	require.Nil(t, err)
+
+	after := time.Date(2022, time.March, 10, 12, 0, 0, 0, time.UTC)
+	next, err := event.Next(after)
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2022, time.March, 11, 1, 0, 0, 0, time.UTC), next.UTC())
+	require.Equal(t, next.UTC(), schedule.Next(after).UTC())
+
+	// The event occurs on Thursday in New York, which is Friday in UTC.
+	require.True(t, event.IsWeekDay(next))
+	require.False(t, event.Event.IsWeekDay(next.UTC()))
+
+	require.Equal(t, time.UTC, ZonedEvent{TimeZone: "Mars/Olympus_Mons"}.Location())
+}
+
 // --- helpers ---
 
 func weekday(v time.Weekday) *time.Weekday { return &v }
+
+func at(v time.Time) *time.Time { return &v }
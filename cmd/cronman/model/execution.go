@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExecutionOutcome is the outcome of an Execution.
+type ExecutionOutcome string
+
+const (
+	// ExecutionOutcomeRunning indicates the Execution has not finished.
+	ExecutionOutcomeRunning ExecutionOutcome = "running"
+	// ExecutionOutcomeSucceeded indicates the Execution finished successfully.
+	ExecutionOutcomeSucceeded ExecutionOutcome = "succeeded"
+	// ExecutionOutcomeFailed indicates the Execution failed. The Execution's
+	// Error describes the failure.
+	ExecutionOutcomeFailed ExecutionOutcome = "failed"
+)
+
+// IsValid reports if the ExecutionOutcome is known.
+func (o ExecutionOutcome) IsValid() bool {
+	switch o {
+	case ExecutionOutcomeRunning, ExecutionOutcomeSucceeded, ExecutionOutcomeFailed:
+		return true
+	}
+	return false
+}
+
+// Execution records the director executing an occurrence of an Event.
+type Execution struct {
+	ID       uuid.UUID `gorm:"default:gen_random_uuid()"`
+	EventID  uuid.UUID
+	ServerID uuid.UUID
+	Kind     EventKind
+	// Actor is who, or what, executed the Event; the director, or the user
+	// that retried a failed Execution.
+	Actor Actor
+	// ScheduledAt is when the occurrence of the Event was scheduled.
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Outcome     ExecutionOutcome `gorm:"default:running"`
+	// Error is the error the Execution failed with, if any.
+	Error string
+	// RetryOf is the ID of the failed Execution this Execution retries, if
+	// any.
+	RetryOf   *uuid.UUID
+	CreatedAt time.Time
+}
+
+// NewExecution creates a running Execution of the occurrence of the event
+// scheduled at the time specified.
+func NewExecution(event Event, scheduledAt time.Time, actor Actor, startedAt time.Time) *Execution {
+	return &Execution{
+		EventID:     event.ID,
+		ServerID:    event.ServerID,
+		Kind:        event.Kind,
+		Actor:       actor,
+		ScheduledAt: scheduledAt,
+		StartedAt:   startedAt,
+		Outcome:     ExecutionOutcomeRunning,
+	}
+}
+
+// Event retrieves the Event executed by the Execution. Only the Event's ID,
+// ServerID and Kind are populated.
+func (e Execution) Event() Event {
+	event := Event{ServerID: e.ServerID, Kind: e.Kind}
+	event.ID = e.EventID
+	return event
+}
+
+// Finish records the Execution as finished at the time specified. A nil err
+// indicates the Execution succeeded.
+func (e *Execution) Finish(at time.Time, err error) {
+	e.FinishedAt = &at
+	e.Outcome = ExecutionOutcomeSucceeded
+	e.Error = ""
+	if err != nil {
+		e.Outcome = ExecutionOutcomeFailed
+		e.Error = err.Error()
+	}
+}
+
+// Scrub removes unpredictable data from the Execution.
+func (e *Execution) Scrub() {
+	e.ID = uuid.Nil
+	e.StartedAt = time.Time{}
+	e.FinishedAt = nil
+	e.CreatedAt = time.Time{}
+}
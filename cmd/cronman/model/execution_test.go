@@ -0,0 +1,42 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionFinish(t *testing.T) {
+	event := Event{Kind: EventKindStart, ServerID: uuid.New()}
+	event.ID = uuid.New()
+	at := time.Date(2022, time.June, 23, 18, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		err     error
+		outcome ExecutionOutcome
+	}{
+		"succeeded": {outcome: ExecutionOutcomeSucceeded},
+		"failed":    {err: errors.New("instance failed to start"), outcome: ExecutionOutcomeFailed},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			execution := NewExecution(event, at, NewDirectorActor(event), at.Add(time.Second))
+			require.Equal(t, ExecutionOutcomeRunning, execution.Outcome)
+			require.Equal(t, event, execution.Event())
+
+			execution.Finish(at.Add(time.Minute), test.err)
+			require.Equal(t, test.outcome, execution.Outcome)
+			require.True(t, at.Add(time.Minute).Equal(*execution.FinishedAt))
+			if test.err != nil {
+				require.Equal(t, test.err.Error(), execution.Error)
+			} else {
+				require.Empty(t, execution.Error)
+			}
+		})
+	}
+}
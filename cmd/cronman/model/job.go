@@ -0,0 +1,197 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// JobKind is the kind of long-running server operation a Job performs.
+type JobKind string
+
+const (
+	// JobKindCreateServer creates a server and its instance.
+	JobKindCreateServer JobKind = "createServer"
+	// JobKindStartServer starts a dormant server and makes it live.
+	JobKindStartServer JobKind = "startServer"
+	// JobKindStopServer stops a live server.
+	JobKindStopServer JobKind = "stopServer"
+	// JobKindWipeServer stops a live server, wipes it, and makes it live
+	// again.
+	JobKindWipeServer JobKind = "wipeServer"
+	// JobKindDecommissionServer releases an archived server's cloud
+	// resources.
+	JobKindDecommissionServer JobKind = "decommissionServer"
+	// JobKindUnarchiveServer returns an archived server to dormant.
+	JobKindUnarchiveServer JobKind = "unarchiveServer"
+	// JobKindRetryExecution re-runs the event of a failed Execution.
+	JobKindRetryExecution JobKind = "retryExecution"
+)
+
+// JobKinds is every JobKind.
+var JobKinds = []JobKind{
+	JobKindCreateServer,
+	JobKindStartServer,
+	JobKindStopServer,
+	JobKindWipeServer,
+	JobKindDecommissionServer,
+	JobKindUnarchiveServer,
+	JobKindRetryExecution,
+}
+
+// JobStatus is the status of a Job.
+type JobStatus string
+
+const (
+	// JobStatusPending indicates the Job is waiting to be run.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning indicates the Job is being run.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded indicates the Job has completed successfully.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed indicates the Job has failed. The Job's Error describes
+	// the failure.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// Job is a durable, long-running operation performed on a server. Jobs are
+// persisted so that they survive process restarts.
+type Job struct {
+	model.Model
+	Kind     JobKind
+	ServerID uuid.UUID
+	// Payload is the JSON encoded input of the Job, e.g. the Server to be
+	// created.
+	Payload datatypes.JSON
+	Status  JobStatus `gorm:"default:pending"`
+	// Actor is who, or what, enqueued the Job. The Job's actions are
+	// performed on behalf of the Actor.
+	Actor Actor
+
+	// Step describes the step the Job is currently performing.
+	Step           string
+	StepsCompleted uint8
+	Steps          uint8
+
+	// Error is the error the Job failed with, if any.
+	Error string
+	// Attempts is the number of times the Job has been run. A Job is run
+	// more than once if the process running it is interrupted.
+	Attempts uint8
+
+	// HeartbeatAt is when the process running the Job last indicated it was
+	// still running.
+	HeartbeatAt *time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// Scrub removes unpredictable data from the Job.
+func (j *Job) Scrub() {
+	j.Model.Scrub()
+	j.HeartbeatAt = nil
+	j.StartedAt = nil
+	j.FinishedAt = nil
+}
+
+// NewCreateServerJob creates a Job that creates the specified server.
+func NewCreateServerJob(server Server) (*Job, error) {
+	payload, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling create server job payload: %w", err)
+	}
+	return &Job{
+		Kind:     JobKindCreateServer,
+		ServerID: server.ID,
+		Payload:  payload,
+		Steps:    1,
+	}, nil
+}
+
+// NewStartServerJob creates a Job that starts the server specified by id and
+// makes it live.
+func NewStartServerJob(id uuid.UUID) *Job {
+	return &Job{
+		Kind:     JobKindStartServer,
+		ServerID: id,
+		Payload:  datatypes.JSON("{}"),
+		Steps:    2,
+	}
+}
+
+// NewStopServerJob creates a Job that stops the server specified by id.
+func NewStopServerJob(id uuid.UUID) *Job {
+	return &Job{
+		Kind:     JobKindStopServer,
+		ServerID: id,
+		Payload:  datatypes.JSON("{}"),
+		Steps:    1,
+	}
+}
+
+// NewWipeServerJob creates a Job that stops the live server specified by id,
+// applies the wipe, and makes the server live again. The wipe is assigned an
+// ID, if it does not have one, so an attempt that resumes the Job does not
+// apply the wipe again.
+func NewWipeServerJob(id uuid.UUID, wipe Wipe) (*Job, error) {
+	if wipe.ID == uuid.Nil {
+		wipe.ID = uuid.New()
+	}
+	payload, err := json.Marshal(wipe)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling wipe server job payload: %w", err)
+	}
+	return &Job{
+		Kind:     JobKindWipeServer,
+		ServerID: id,
+		Payload:  payload,
+		Steps:    4,
+	}, nil
+}
+
+// NewDecommissionServerJob creates a Job that releases the cloud resources of
+// the archived server specified by id.
+func NewDecommissionServerJob(id uuid.UUID) *Job {
+	return &Job{
+		Kind:     JobKindDecommissionServer,
+		ServerID: id,
+		Payload:  datatypes.JSON("{}"),
+		Steps:    1,
+	}
+}
+
+// NewUnarchiveServerJob creates a Job that returns the archived server
+// specified by id to dormant.
+func NewUnarchiveServerJob(id uuid.UUID) *Job {
+	return &Job{
+		Kind:     JobKindUnarchiveServer,
+		ServerID: id,
+		Payload:  datatypes.JSON("{}"),
+		Steps:    1,
+	}
+}
+
+// RetryExecutionPayload is the payload of a retry execution Job.
+type RetryExecutionPayload struct {
+	ExecutionID uuid.UUID `json:"executionId"`
+}
+
+// NewRetryExecutionJob creates a Job that re-runs the event of the failed
+// Execution specified.
+func NewRetryExecutionJob(execution Execution) (*Job, error) {
+	payload, err := json.Marshal(RetryExecutionPayload{ExecutionID: execution.ID})
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling retry execution job payload: %w", err)
+	}
+	return &Job{
+		Kind:     JobKindRetryExecution,
+		ServerID: execution.ServerID,
+		Payload:  payload,
+		Steps:    1,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package model
+
+// Lifecycle is the stage of a server's lifecycle. A server's StateType records
+// where a server has settled (live, dormant, archived), its Lifecycle
+// records whether it is currently transitioning between settled states.
+type Lifecycle string
+
+const (
+	// LifecycleIdle indicates the server has settled into its StateType.
+	LifecycleIdle Lifecycle = "idle"
+	// LifecycleCreating indicates the server's instance is being created.
+	LifecycleCreating Lifecycle = "creating"
+	// LifecycleStarting indicates the server's instance is booting.
+	LifecycleStarting Lifecycle = "starting"
+	// LifecycleGoingLive indicates the server is being exposed to players.
+	LifecycleGoingLive Lifecycle = "goingLive"
+	// LifecycleStopping indicates the server is being shutdown.
+	LifecycleStopping Lifecycle = "stopping"
+	// LifecycleWiping indicates a wipe is being applied to the server.
+	LifecycleWiping Lifecycle = "wiping"
+	// LifecycleDecommissioning indicates the archived server's cloud
+	// resources are being released.
+	LifecycleDecommissioning Lifecycle = "decommissioning"
+	// LifecycleUnarchiving indicates the archived server is being returned to
+	// dormant, provisioning an instance if necessary.
+	LifecycleUnarchiving Lifecycle = "unarchiving"
+	// LifecycleFailed indicates the server's last transition failed. The
+	// server's LifecycleReason describes the failure.
+	LifecycleFailed Lifecycle = "failed"
+)
+
+// transitions maps each Lifecycle to the Lifecycles it may transition to.
+var transitions = map[Lifecycle][]Lifecycle{
+	LifecycleIdle: {
+		LifecycleStarting,
+		LifecycleGoingLive,
+		LifecycleStopping,
+		LifecycleWiping,
+		LifecycleDecommissioning,
+		LifecycleUnarchiving,
+	},
+	LifecycleCreating:        {LifecycleIdle, LifecycleFailed},
+	LifecycleStarting:        {LifecycleIdle, LifecycleFailed},
+	LifecycleGoingLive:       {LifecycleIdle, LifecycleFailed},
+	LifecycleStopping:        {LifecycleIdle, LifecycleFailed},
+	LifecycleWiping:          {LifecycleIdle, LifecycleFailed},
+	LifecycleDecommissioning: {LifecycleIdle, LifecycleFailed},
+	LifecycleUnarchiving:     {LifecycleIdle, LifecycleFailed},
+	LifecycleFailed: {
+		LifecycleIdle,
+		LifecycleStarting,
+		LifecycleGoingLive,
+		LifecycleStopping,
+		LifecycleWiping,
+		LifecycleDecommissioning,
+		LifecycleUnarchiving,
+	},
+}
+
+// CanTransition reports if the Lifecycle may transition to the specified
+// Lifecycle.
+func (l Lifecycle) CanTransition(to Lifecycle) bool {
+	for _, valid := range transitions[l] {
+		if valid == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransitional reports if the Lifecycle indicates the server is between
+// settled states.
+func (l Lifecycle) IsTransitional() bool {
+	for _, transitional := range TransitionalLifecycles {
+		if l == transitional {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionalLifecycles are the Lifecycles that indicate a server is between
+// settled states.
+var TransitionalLifecycles = []Lifecycle{
+	LifecycleCreating,
+	LifecycleStarting,
+	LifecycleGoingLive,
+	LifecycleStopping,
+	LifecycleWiping,
+	LifecycleDecommissioning,
+	LifecycleUnarchiving,
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleCanTransition(t *testing.T) {
+	tests := map[string]struct {
+		from, to Lifecycle
+		exp      bool
+	}{
+		"idle to starting":        {from: LifecycleIdle, to: LifecycleStarting, exp: true},
+		"idle to stopping":        {from: LifecycleIdle, to: LifecycleStopping, exp: true},
+		"idle to failed":          {from: LifecycleIdle, to: LifecycleFailed, exp: false},
+		"idle to idle":            {from: LifecycleIdle, to: LifecycleIdle, exp: false},
+		"creating to idle":        {from: LifecycleCreating, to: LifecycleIdle, exp: true},
+		"starting to idle":        {from: LifecycleStarting, to: LifecycleIdle, exp: true},
+		"starting to failed":      {from: LifecycleStarting, to: LifecycleFailed, exp: true},
+		"starting to stopping":    {from: LifecycleStarting, to: LifecycleStopping, exp: false},
+		"going live to starting":  {from: LifecycleGoingLive, to: LifecycleStarting, exp: false},
+		"stopping to wiping":      {from: LifecycleStopping, to: LifecycleWiping, exp: false},
+		"wiping to idle":          {from: LifecycleWiping, to: LifecycleIdle, exp: true},
+		"failed to starting":      {from: LifecycleFailed, to: LifecycleStarting, exp: true},
+		"failed to idle":          {from: LifecycleFailed, to: LifecycleIdle, exp: true},
+		"failed to creating":      {from: LifecycleFailed, to: LifecycleCreating, exp: false},
+		"idle to creating":        {from: LifecycleIdle, to: LifecycleCreating, exp: false},
+		"idle to decommissioning": {from: LifecycleIdle, to: LifecycleDecommissioning, exp: true},
+		"failed to unarchiving":   {from: LifecycleFailed, to: LifecycleUnarchiving, exp: true},
+		"unarchiving to starting": {from: LifecycleUnarchiving, to: LifecycleStarting, exp: false},
+		"decommissioning to idle": {from: LifecycleDecommissioning, to: LifecycleIdle, exp: true},
+		"unknown to starting":     {from: Lifecycle("unknown"), to: LifecycleStarting, exp: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.exp, test.from.CanTransition(test.to))
+		})
+	}
+}
+
+func TestLifecycleIsTransitional(t *testing.T) {
+	tests := map[Lifecycle]bool{
+		LifecycleIdle:      false,
+		LifecycleCreating:  true,
+		LifecycleStarting:  true,
+		LifecycleGoingLive: true,
+		LifecycleStopping:  true,
+		LifecycleWiping:    true,
+		LifecycleFailed:    false,
+
+		LifecycleDecommissioning: true,
+		LifecycleUnarchiving:     true,
+	}
+
+	for lifecycle, exp := range tests {
+		require.Equal(t, exp, lifecycle.IsTransitional(), lifecycle)
+	}
+}
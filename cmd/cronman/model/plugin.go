@@ -0,0 +1,151 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tjper/rustcron/cmd/cronman/userdata"
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// Plugins is a slice of Plugin instances.
+type Plugins []Plugin
+
+// IDs retrieves the Plugins set of IDs.
+func (ps Plugins) IDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(ps))
+	for _, plugin := range ps {
+		ids = append(ids, plugin.ID)
+	}
+	return ids
+}
+
+// Plugin is an Oxide plugin in the plugin catalog. Servers enable plugins from
+// the catalog via ServerPlugin.
+type Plugin struct {
+	model.Model
+	Name string
+	// URL is the location the plugin's source file is downloaded from. The
+	// plugin is downloaded as-is, the URL determines the version installed.
+	URL string
+	// Version is the version of the plugin served at URL, as recorded in the
+	// catalog, e.g. "latest". It is display-only metadata; it does not
+	// select the version downloaded.
+	Version string
+	// Default indicates the plugin is enabled on newly created servers.
+	Default bool
+	Grants  PluginGrants
+}
+
+func (p Plugin) Clone() Plugin {
+	cloned := p
+	cloned.Grants = p.Grants.Clone()
+	return cloned
+}
+
+func (p *Plugin) Scrub() {
+	p.Model.Scrub()
+	p.Grants.Scrub()
+}
+
+// Userdata converts the Plugin into the form expected by userdata.WithPlugins.
+func (p Plugin) Userdata() userdata.Plugin {
+	grants := make([]string, 0, len(p.Grants))
+	for _, grant := range p.Grants {
+		grants = append(grants, grant.String())
+	}
+	return userdata.Plugin{Name: p.Name, URL: p.URL, Grants: grants}
+}
+
+// PluginGrants is a slice of PluginGrant instances.
+type PluginGrants []PluginGrant
+
+func (pgs PluginGrants) Clone() PluginGrants {
+	cloned := make(PluginGrants, 0, len(pgs))
+	cloned = append(cloned, pgs...)
+	return cloned
+}
+
+func (pgs PluginGrants) Scrub() {
+	for i := range pgs {
+		pgs[i].Scrub()
+	}
+}
+
+// PluginGrant is a permission granted to an Oxide group when a plugin is
+// enabled.
+type PluginGrant struct {
+	model.Model
+	PluginID   uuid.UUID
+	Group      string
+	Permission string
+}
+
+// String formats the PluginGrant as the arguments of an oxide.grant command.
+func (pg PluginGrant) String() string {
+	return fmt.Sprintf("group %s %s", pg.Group, pg.Permission)
+}
+
+func (pg *PluginGrant) Scrub() {
+	pg.Model.Scrub()
+	pg.PluginID = uuid.Nil
+}
+
+// ServerPlugins is a slice of ServerPlugin instances.
+type ServerPlugins []ServerPlugin
+
+func (sps ServerPlugins) Clone() ServerPlugins {
+	cloned := make(ServerPlugins, 0, len(sps))
+	for _, sp := range sps {
+		cloned = append(cloned, sp.Clone())
+	}
+	return cloned
+}
+
+func (sps ServerPlugins) Scrub() {
+	for i := range sps {
+		sps[i].Scrub()
+	}
+}
+
+// Enabled reports if the plugin specified by pluginID is enabled.
+func (sps ServerPlugins) Enabled(pluginID uuid.UUID) bool {
+	for _, sp := range sps {
+		if sp.PluginID == pluginID {
+			return true
+		}
+	}
+	return false
+}
+
+// Userdata converts the ServerPlugins into the form expected by
+// userdata.WithPlugins and userdata.WithServerCfg.
+func (sps ServerPlugins) Userdata() []userdata.Plugin {
+	plugins := make([]userdata.Plugin, 0, len(sps))
+	for _, sp := range sps {
+		plugins = append(plugins, sp.Plugin.Userdata())
+	}
+	return plugins
+}
+
+// ServerPlugin is a catalog Plugin enabled on a server.
+type ServerPlugin struct {
+	model.Model
+	ServerID uuid.UUID
+	PluginID uuid.UUID
+	Plugin   Plugin
+}
+
+func (sp ServerPlugin) Clone() ServerPlugin {
+	cloned := sp
+	cloned.Plugin = sp.Plugin.Clone()
+	return cloned
+}
+
+func (sp *ServerPlugin) Scrub() {
+	sp.Model.Scrub()
+	sp.ServerID = uuid.Nil
+	sp.PluginID = uuid.Nil
+	sp.Plugin.Scrub()
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// RconCommands is a slice of RconCommand instances.
+type RconCommands []RconCommand
+
+// Scrub removes unpredictable data from the RconCommands.
+func (rcs RconCommands) Scrub() {
+	for i := range rcs {
+		rcs[i].Scrub()
+	}
+}
+
+// RconCommand is an audit record of a console command executed against a
+// server via RCON.
+type RconCommand struct {
+	model.Model
+	ServerID uuid.UUID
+	// UserID is the ID of the session user that executed the command.
+	UserID  uuid.UUID
+	Command string
+	// Response is the Rust server's response to the command.
+	Response string
+	// ResponseType is the WebRcon type of the Rust server's response, e.g.
+	// Generic, Warning, or Error.
+	ResponseType string
+	// Error is the error encountered while executing the command, if any.
+	Error string
+}
+
+// Scrub removes unpredictable data from the RconCommand.
+func (rc *RconCommand) Scrub() {
+	rc.Model.Scrub()
+}
@@ -0,0 +1,178 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// scheduleLookback is how far prior to the start of a Schedule Events are
+// expanded to determine if the server is online when the Schedule starts.
+// Weekday schedules repeat weekly, so a week of history is sufficient.
+const scheduleLookback = 7 * 24 * time.Hour
+
+// Schedule is the expansion of a Server's Events over a period of time.
+type Schedule struct {
+	From time.Time
+	To   time.Time
+	// Occurrences is each occurrence of the Server's Events within the
+	// Schedule ordered by time. Occurrences suppressed by the Server's
+	// Blackouts are excluded.
+	Occurrences []Occurrence
+	// OnlineWindows are the periods within the Schedule during which the
+	// server is expected to be live, i.e. from a live event until the next
+	// stop event.
+	OnlineWindows []Window
+	// NextWipe is the next map or full wipe after the start of the Schedule,
+	// it may occur after the end of the Schedule. NextWipe is nil if the
+	// Server does not have a future wipe.
+	NextWipe *Occurrence
+}
+
+// Occurrence is an occurrence of an Event at a specific time.
+type Occurrence struct {
+	Event Event
+	At    time.Time
+}
+
+// Window is a period of time starting at StartsAt and ending at EndsAt.
+type Window struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Schedule expands the Server's Events over the period of time after from
+// and until to, evaluated in the Server's time zone. Times within the
+// Schedule are in the Server's time zone.
+func (s Server) Schedule(from, to time.Time) (*Schedule, error) {
+	loc := s.Location()
+	from, to = from.In(loc), to.In(loc)
+
+	// Events are expanded from prior to the Schedule so that a server that
+	// is already online when the Schedule starts has an online window.
+	occurrences := make([]Occurrence, 0)
+	for _, event := range s.Events {
+		ats, err := event.Occurrences(from.Add(-scheduleLookback), to)
+		if err != nil {
+			return nil, fmt.Errorf("while expanding server schedule: %w", err)
+		}
+		for _, at := range ats {
+			if s.Blackouts.Suppresses(event, at) {
+				continue
+			}
+			occurrences = append(occurrences, Occurrence{Event: event, At: at})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].At.Before(occurrences[j].At)
+	})
+
+	schedule := &Schedule{
+		From:          from,
+		To:            to,
+		Occurrences:   make([]Occurrence, 0, len(occurrences)),
+		OnlineWindows: onlineWindows(occurrences, from, to),
+	}
+	for _, occurrence := range occurrences {
+		if occurrence.At.Before(from) {
+			continue
+		}
+		schedule.Occurrences = append(schedule.Occurrences, occurrence)
+	}
+
+	nextWipe, err := s.nextWipe(from)
+	if err != nil {
+		return nil, err
+	}
+	schedule.NextWipe = nextWipe
+
+	return schedule, nil
+}
+
+// LastStateOccurrence retrieves the most recent occurrence of the Server's
+// start, live and stop Events after the time specified and not after at,
+// evaluated in the Server's time zone. Occurrences suppressed by the Server's
+// Blackouts are skipped. If no such occurrence exists, nil is returned.
+func (s Server) LastStateOccurrence(after, at time.Time) (*Occurrence, error) {
+	loc := s.Location()
+
+	var last *Occurrence
+	for _, event := range s.Events {
+		switch event.Kind {
+		case EventKindStart, EventKindLive, EventKindStop:
+		default:
+			continue
+		}
+
+		ats, err := event.Occurrences(after.In(loc), at.In(loc))
+		if err != nil {
+			return nil, fmt.Errorf("while determining last state occurrence: %w", err)
+		}
+		for i := len(ats) - 1; i >= 0; i-- {
+			if s.Blackouts.Suppresses(event, ats[i]) {
+				continue
+			}
+			if last == nil || ats[i].After(last.At) {
+				last = &Occurrence{Event: event, At: ats[i]}
+			}
+			break
+		}
+	}
+	return last, nil
+}
+
+// nextWipe retrieves the next map or full wipe to occur after t.
+func (s Server) nextWipe(t time.Time) (*Occurrence, error) {
+	var next *Occurrence
+	for _, kind := range []EventKind{EventKindMapWipe, EventKindFullWipe} {
+		event, at, err := s.NextEvent(t, kind)
+		if errors.Is(err, ErrNoFutureEvent) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while determining next wipe: %w", err)
+		}
+		if next == nil || at.Before(next.At) {
+			next = &Occurrence{Event: *event, At: *at}
+		}
+	}
+	return next, nil
+}
+
+// onlineWindows derives the periods between from and to during which the
+// server is live from the time ordered occurrences. A server is live from a
+// live event until the next stop event. Wipes restart a live server and do not
+// end its online window.
+func onlineWindows(occurrences []Occurrence, from, to time.Time) []Window {
+	windows := make([]Window, 0)
+	appendWindow := func(startsAt, endsAt time.Time) {
+		if !endsAt.After(from) {
+			return
+		}
+		if startsAt.Before(from) {
+			startsAt = from
+		}
+		windows = append(windows, Window{StartsAt: startsAt, EndsAt: endsAt})
+	}
+
+	var since *time.Time
+	for i := range occurrences {
+		occurrence := occurrences[i]
+		switch occurrence.Event.Kind {
+		case EventKindLive:
+			if since == nil {
+				since = &occurrence.At
+			}
+		case EventKindStop:
+			if since != nil {
+				appendWindow(*since, occurrence.At)
+				since = nil
+			}
+		}
+	}
+	if since != nil {
+		appendWindow(*since, to)
+	}
+	return windows
+}
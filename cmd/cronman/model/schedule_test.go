@@ -0,0 +1,219 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSchedule(t *testing.T) {
+	start := Event{Schedule: "0 17 * * *", Kind: EventKindStart}
+	live := Event{Schedule: "0 18 * * *", Kind: EventKindLive}
+	stop := Event{Schedule: "0 2 * * *", Kind: EventKindStop}
+	wipe := Event{Schedule: "0 20 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe}
+
+	day := func(d, hour int) time.Time {
+		return time.Date(2020, time.September, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	type expected struct {
+		occurrences []Occurrence
+		windows     []Window
+		nextWipe    *Occurrence
+	}
+	tests := map[string]struct {
+		server Server
+		from   time.Time
+		to     time.Time
+		exp    expected
+	}{
+		"daily": {
+			server: Server{TimeZone: "UTC", Events: Events{start, live, stop, wipe}},
+			from:   day(16, 12),
+			to:     day(17, 12),
+			exp: expected{
+				occurrences: []Occurrence{
+					{Event: start, At: day(16, 17)},
+					{Event: live, At: day(16, 18)},
+					{Event: stop, At: day(17, 2)},
+				},
+				windows: []Window{
+					{StartsAt: day(16, 18), EndsAt: day(17, 2)},
+				},
+				nextWipe: &Occurrence{Event: wipe, At: day(17, 20)},
+			},
+		},
+		"online at from": {
+			server: Server{TimeZone: "UTC", Events: Events{start, live, stop}},
+			from:   day(16, 20),
+			to:     day(17, 20),
+			exp: expected{
+				occurrences: []Occurrence{
+					{Event: stop, At: day(17, 2)},
+					{Event: start, At: day(17, 17)},
+					{Event: live, At: day(17, 18)},
+				},
+				windows: []Window{
+					{StartsAt: day(16, 20), EndsAt: day(17, 2)},
+					{StartsAt: day(17, 18), EndsAt: day(17, 20)},
+				},
+			},
+		},
+		"wipe does not end window": {
+			server: Server{TimeZone: "UTC", Events: Events{live, stop, wipe}},
+			from:   day(17, 12),
+			to:     day(18, 12),
+			exp: expected{
+				occurrences: []Occurrence{
+					{Event: live, At: day(17, 18)},
+					{Event: wipe, At: day(17, 20)},
+					{Event: stop, At: day(18, 2)},
+				},
+				windows: []Window{
+					{StartsAt: day(17, 18), EndsAt: day(18, 2)},
+				},
+				nextWipe: &Occurrence{Event: wipe, At: day(17, 20)},
+			},
+		},
+		"blackout": {
+			server: Server{
+				TimeZone: "UTC",
+				Events:   Events{live, stop},
+				Blackouts: Blackouts{
+					{StartsAt: day(16, 0), EndsAt: day(17, 0), Kind: EventKindLive},
+				},
+			},
+			from: day(16, 0),
+			to:   day(18, 0),
+			exp: expected{
+				occurrences: []Occurrence{
+					{Event: stop, At: day(16, 2)},
+					{Event: stop, At: day(17, 2)},
+					{Event: live, At: day(17, 18)},
+				},
+				windows: []Window{
+					{StartsAt: day(16, 0), EndsAt: day(16, 2)},
+					{StartsAt: day(17, 18), EndsAt: day(18, 0)},
+				},
+			},
+		},
+		"time zone": {
+			server: Server{TimeZone: "America/New_York", Events: Events{live, stop}},
+			from:   day(16, 12),
+			to:     day(17, 12),
+			exp: expected{
+				occurrences: []Occurrence{
+					{Event: live, At: day(16, 22)},
+					{Event: stop, At: day(17, 6)},
+				},
+				windows: []Window{
+					{StartsAt: day(16, 22), EndsAt: day(17, 6)},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			schedule, err := test.server.Schedule(test.from, test.to)
+			require.Nil(t, err)
+
+			require.Len(t, schedule.Occurrences, len(test.exp.occurrences))
+			for i, occurrence := range schedule.Occurrences {
+				require.Equal(t, test.exp.occurrences[i].Event, occurrence.Event)
+				require.True(t, test.exp.occurrences[i].At.Equal(occurrence.At))
+			}
+
+			require.Len(t, schedule.OnlineWindows, len(test.exp.windows))
+			for i, window := range schedule.OnlineWindows {
+				require.True(t, test.exp.windows[i].StartsAt.Equal(window.StartsAt))
+				require.True(t, test.exp.windows[i].EndsAt.Equal(window.EndsAt))
+			}
+
+			if test.exp.nextWipe == nil {
+				require.Nil(t, schedule.NextWipe)
+				return
+			}
+			require.NotNil(t, schedule.NextWipe)
+			require.Equal(t, test.exp.nextWipe.Event, schedule.NextWipe.Event)
+			require.True(t, test.exp.nextWipe.At.Equal(schedule.NextWipe.At))
+		})
+	}
+}
+
+func TestServerLastStateOccurrence(t *testing.T) {
+	start := Event{Schedule: "0 17 * * *", Kind: EventKindStart}
+	live := Event{Schedule: "0 18 * * *", Kind: EventKindLive}
+	stop := Event{Schedule: "0 2 * * *", Kind: EventKindStop}
+	wipe := Event{Schedule: "0 19 * * *", Kind: EventKindMapWipe}
+
+	day := func(d, hour int) time.Time {
+		return time.Date(2020, time.September, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := map[string]struct {
+		server Server
+		after  time.Time
+		at     time.Time
+		exp    *Occurrence
+	}{
+		"live": {
+			server: Server{TimeZone: "UTC", Events: Events{start, live, stop, wipe}},
+			after:  day(16, 12),
+			at:     day(16, 20),
+			exp:    &Occurrence{Event: live, At: day(16, 18)},
+		},
+		"at occurrence": {
+			server: Server{TimeZone: "UTC", Events: Events{start, live, stop}},
+			after:  day(16, 12),
+			at:     day(16, 17),
+			exp:    &Occurrence{Event: start, At: day(16, 17)},
+		},
+		"none within lookback": {
+			server: Server{TimeZone: "UTC", Events: Events{start, live, stop, wipe}},
+			after:  day(16, 3),
+			at:     day(16, 12),
+		},
+		"past one-off": {
+			server: Server{
+				TimeZone: "UTC",
+				Events: Events{
+					stop,
+					{RunAt: at(day(16, 10)), Kind: EventKindStart},
+				},
+			},
+			after: day(16, 0),
+			at:    day(16, 12),
+			exp:   &Occurrence{Event: Event{RunAt: at(day(16, 10)), Kind: EventKindStart}, At: day(16, 10)},
+		},
+		"blackout": {
+			server: Server{
+				TimeZone: "UTC",
+				Events:   Events{start, live, stop},
+				Blackouts: Blackouts{
+					{StartsAt: day(16, 0), EndsAt: day(17, 0), Kind: EventKindLive},
+				},
+			},
+			after: day(16, 0),
+			at:    day(16, 20),
+			exp:   &Occurrence{Event: start, At: day(16, 17)},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			last, err := test.server.LastStateOccurrence(test.after, test.at)
+			require.Nil(t, err)
+			if test.exp == nil {
+				require.Nil(t, last)
+				return
+			}
+			require.NotNil(t, last)
+			require.Equal(t, test.exp.Event, last.Event)
+			require.True(t, test.exp.At.Equal(last.At))
+		})
+	}
+}
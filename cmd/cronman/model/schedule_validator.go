@@ -0,0 +1,263 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// scheduleValidationWindow is the period of time over which a schedule is
+// simulated when validated. Weekday schedules repeat weekly, so two weeks
+// covers each weekday twice.
+const scheduleValidationWindow = 14 * 24 * time.Hour
+
+// ViolationSeverity is the severity of a ScheduleViolation.
+type ViolationSeverity string
+
+const (
+	// ViolationSeverityError indicates the schedule should be rejected.
+	ViolationSeverityError ViolationSeverity = "error"
+	// ViolationSeverityWarning indicates the schedule is valid, but likely
+	// does not behave as intended.
+	ViolationSeverityWarning ViolationSeverity = "warning"
+)
+
+// ViolationKind is the kind of problem found with a schedule.
+type ViolationKind string
+
+const (
+	// ViolationKindOutOfOrder indicates start, live and stop events do not
+	// occur in that order, e.g. a server is made live before it is started.
+	ViolationKindOutOfOrder ViolationKind = "outOfOrder"
+	// ViolationKindOverlap indicates multiple events occur at the same time.
+	ViolationKindOverlap ViolationKind = "overlap"
+	// ViolationKindNoStop indicates a server is started but never stopped.
+	ViolationKindNoStop ViolationKind = "noStop"
+	// ViolationKindWipeWhileRunning indicates a wipe occurs while the server
+	// is running. The director restarts the server to apply the wipe.
+	ViolationKindWipeWhileRunning ViolationKind = "wipeWhileRunning"
+)
+
+// ScheduleViolation is a problem found with a schedule.
+type ScheduleViolation struct {
+	Kind     ViolationKind
+	Severity ViolationSeverity
+	// At is the first time at which the violation occurs, if the violation
+	// is specific to an occurrence.
+	At *time.Time
+	// Events are the Events responsible for the violation.
+	Events  Events
+	Message string
+}
+
+// ScheduleViolations is a slice of ScheduleViolation instances.
+type ScheduleViolations []ScheduleViolation
+
+// Errors retrieves the ScheduleViolations with an error severity.
+func (vs ScheduleViolations) Errors() ScheduleViolations {
+	return vs.filter(ViolationSeverityError)
+}
+
+// Warnings retrieves the ScheduleViolations with a warning severity.
+func (vs ScheduleViolations) Warnings() ScheduleViolations {
+	return vs.filter(ViolationSeverityWarning)
+}
+
+func (vs ScheduleViolations) filter(severity ViolationSeverity) ScheduleViolations {
+	filtered := make(ScheduleViolations, 0)
+	for _, v := range vs {
+		if v.Severity == severity {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// Err retrieves a *ScheduleError if any of the ScheduleViolations have an
+// error severity, otherwise nil is returned.
+func (vs ScheduleViolations) Err() error {
+	errs := vs.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ScheduleError{Violations: errs}
+}
+
+// ScheduleError is an error indicating a schedule has violations with an
+// error severity.
+type ScheduleError struct {
+	Violations ScheduleViolations
+}
+
+func (e ScheduleError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Message)
+	}
+	return fmt.Sprintf("invalid schedule: %s", strings.Join(msgs, "; "))
+}
+
+// runState is the state of a server while its schedule is simulated.
+type runState int
+
+const (
+	runStateUnknown runState = iota
+	runStateStopped
+	runStateStarted
+	runStateLive
+)
+
+// ValidateSchedule simulates the Server's Events for two weeks after the
+// specified time and reports where the schedule is inconsistent. The server
+// is expected to be started, made live and stopped in that order, events are
+// not expected to occur at the same time, and a server that is started is
+// expected to be stopped. The state of the server prior to the first
+// occurrence is unknown, so the first occurrence is always accepted.
+func (s Server) ValidateSchedule(at time.Time) (ScheduleViolations, error) {
+	schedule, err := s.Schedule(at, at.Add(scheduleValidationWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	violations := &scheduleViolations{seen: make(map[string]struct{})}
+	violations.noStop(s.Events)
+
+	var (
+		state runState
+		prev  *Occurrence
+	)
+	for i := range schedule.Occurrences {
+		occurrence := schedule.Occurrences[i]
+
+		if i > 0 && schedule.Occurrences[i-1].At.Equal(occurrence.At) {
+			violations.add(ScheduleViolation{
+				Kind:     ViolationKindOverlap,
+				Severity: ViolationSeverityError,
+				At:       &occurrence.At,
+				Events:   Events{schedule.Occurrences[i-1].Event, occurrence.Event},
+				Message: fmt.Sprintf(
+					"%s and %s events both occur at %s",
+					schedule.Occurrences[i-1].Event.Kind,
+					occurrence.Event.Kind,
+					occurrence.At.Format(time.RFC3339),
+				),
+			})
+		}
+
+		var reason string
+		switch occurrence.Event.Kind {
+		case EventKindStart:
+			if state == runStateStarted || state == runStateLive {
+				reason = "while the server is running"
+			}
+			state = runStateStarted
+		case EventKindLive:
+			if state == runStateStopped {
+				reason = "before the server is started"
+			}
+			if state == runStateLive {
+				reason = "while the server is live"
+			}
+			state = runStateLive
+		case EventKindStop:
+			if state == runStateStopped {
+				reason = "while the server is stopped"
+			}
+			state = runStateStopped
+		case EventKindMapWipe, EventKindFullWipe:
+			if state == runStateStarted || state == runStateLive {
+				violations.add(ScheduleViolation{
+					Kind:     ViolationKindWipeWhileRunning,
+					Severity: ViolationSeverityWarning,
+					At:       &occurrence.At,
+					Events:   Events{occurrence.Event},
+					Message: fmt.Sprintf(
+						"%s event occurs at %s while the server is running, the server will be restarted",
+						occurrence.Event.Kind,
+						occurrence.At.Format(time.RFC3339),
+					),
+				})
+			}
+			continue
+		}
+
+		if reason != "" {
+			events := Events{occurrence.Event}
+			if prev != nil {
+				events = Events{prev.Event, occurrence.Event}
+			}
+			violations.add(ScheduleViolation{
+				Kind:     ViolationKindOutOfOrder,
+				Severity: ViolationSeverityError,
+				At:       &occurrence.At,
+				Events:   events,
+				Message: fmt.Sprintf(
+					"%s event occurs at %s %s",
+					occurrence.Event.Kind,
+					occurrence.At.Format(time.RFC3339),
+					reason,
+				),
+			})
+		}
+		prev = &occurrence
+	}
+
+	return violations.list, nil
+}
+
+// scheduleViolations collects ScheduleViolations, ignoring repeated
+// violations of the same kind by the same Events.
+type scheduleViolations struct {
+	list ScheduleViolations
+	seen map[string]struct{}
+}
+
+func (vs *scheduleViolations) add(v ScheduleViolation) {
+	keys := make([]string, 0, len(v.Events)+1)
+	keys = append(keys, string(v.Kind))
+	for _, e := range v.Events {
+		keys = append(keys, e.key())
+	}
+	key := strings.Join(keys, " ")
+
+	if _, ok := vs.seen[key]; ok {
+		return
+	}
+	vs.seen[key] = struct{}{}
+	vs.list = append(vs.list, v)
+}
+
+// noStop adds a violation if the Events start or make a server live, but
+// never stop it.
+func (vs *scheduleViolations) noStop(events Events) {
+	running := make(Events, 0)
+	for _, e := range events {
+		switch e.Kind {
+		case EventKindStop:
+			return
+		case EventKindStart, EventKindLive:
+			running = append(running, e)
+		}
+	}
+	if len(running) == 0 {
+		return
+	}
+	vs.add(ScheduleViolation{
+		Kind:     ViolationKindNoStop,
+		Severity: ViolationSeverityError,
+		Events:   running,
+		Message:  "the server is started but never stopped",
+	})
+}
+
+// key identifies the Event, including Events that have not been created.
+func (e Event) key() string {
+	var weekday, runAt string
+	if e.Weekday != nil {
+		weekday = e.Weekday.String()
+	}
+	if e.RunAt != nil {
+		runAt = e.RunAt.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s", e.ID, e.Schedule, weekday, runAt, e.Kind)
+}
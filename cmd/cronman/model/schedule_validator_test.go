@@ -0,0 +1,114 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerValidateSchedule(t *testing.T) {
+	at := time.Date(2020, time.September, 16, 12, 0, 0, 0, time.UTC)
+
+	start := Event{Schedule: "0 17 * * *", Kind: EventKindStart}
+	live := Event{Schedule: "0 18 * * *", Kind: EventKindLive}
+	stop := Event{Schedule: "0 2 * * *", Kind: EventKindStop}
+
+	type expected struct {
+		kinds    []ViolationKind
+		hasError bool
+	}
+	tests := map[string]struct {
+		events    Events
+		blackouts Blackouts
+		exp       expected
+	}{
+		"valid": {
+			events: Events{
+				start,
+				live,
+				stop,
+				{Schedule: "0 10 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+			},
+			exp: expected{kinds: []ViolationKind{}},
+		},
+		"no events": {
+			events: Events{},
+			exp:    expected{kinds: []ViolationKind{}},
+		},
+		"live before start": {
+			events: Events{
+				{Schedule: "0 17 * * *", Kind: EventKindLive},
+				{Schedule: "0 18 * * *", Kind: EventKindStart},
+				stop,
+			},
+			exp: expected{
+				kinds:    []ViolationKind{ViolationKindOutOfOrder, ViolationKindOutOfOrder},
+				hasError: true,
+			},
+		},
+		"no stop": {
+			events: Events{start, live},
+			exp: expected{
+				kinds:    []ViolationKind{ViolationKindNoStop, ViolationKindOutOfOrder},
+				hasError: true,
+			},
+		},
+		"overlap": {
+			events: Events{
+				start,
+				{Schedule: "0 17 * * *", Kind: EventKindLive},
+				stop,
+			},
+			exp: expected{
+				kinds:    []ViolationKind{ViolationKindOverlap},
+				hasError: true,
+			},
+		},
+		"wipe while running": {
+			events: Events{
+				start,
+				live,
+				stop,
+				{Schedule: "0 20 * * *", Weekday: weekday(time.Thursday), Kind: EventKindMapWipe},
+			},
+			exp: expected{kinds: []ViolationKind{ViolationKindWipeWhileRunning}},
+		},
+		"blackout suppresses start": {
+			events: Events{start, live, stop},
+			blackouts: Blackouts{
+				{StartsAt: at.Add(12 * time.Hour), EndsAt: at.Add(36 * time.Hour), Kind: EventKindStart},
+			},
+			exp: expected{
+				kinds:    []ViolationKind{ViolationKindOutOfOrder},
+				hasError: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			server := Server{TimeZone: "UTC", Events: test.events, Blackouts: test.blackouts}
+
+			violations, err := server.ValidateSchedule(at)
+			require.Nil(t, err)
+
+			kinds := make([]ViolationKind, 0, len(violations))
+			for _, violation := range violations {
+				kinds = append(kinds, violation.Kind)
+			}
+			require.Equal(t, test.exp.kinds, kinds)
+
+			err = violations.Err()
+			if !test.exp.hasError {
+				require.Nil(t, err)
+				return
+			}
+			var scheduleErr *ScheduleError
+			require.True(t, errors.As(err, &scheduleErr))
+			require.Equal(t, violations.Errors(), scheduleErr.Violations)
+		})
+	}
+}
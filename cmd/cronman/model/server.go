@@ -40,14 +40,33 @@ type Server struct {
 	URL          string
 	BannerURL    string
 	Region       Region
+	TimeZone     string            `gorm:"default:UTC"`
 	Options      datatypes.JSONMap `gorm:"default:'{}'::JSONB"`
+	LastSavedAt  *time.Time
+	Countdown    Countdown
+	// InstanceStartedAt is when the server's instance was started, it is
+	// nil if the instance is stopped.
+	InstanceStartedAt *time.Time
+
+	Lifecycle          Lifecycle `gorm:"default:idle"`
+	LifecycleChangedAt *time.Time
+	LifecycleReason    string
+	// LifecycleHeartbeatAt is when the process performing the server's
+	// transition last indicated the transition is still running.
+	LifecycleHeartbeatAt *time.Time
+	// FencingToken is the greatest director lock fencing token the server
+	// has been transitioned with.
+	FencingToken int64
 
 	Wipes      Wipes
 	Tags       Tags
 	Events     Events
+	Blackouts  Blackouts
 	Moderators Moderators
 	Owners     Owners
 	Vips       Vips
+	Bans       Bans
+	Plugins    ServerPlugins
 }
 
 // Create creates a Server in the specified db. Non empty relationships will
@@ -68,6 +87,19 @@ func (s *Server) First(ctx context.Context, db *gorm.DB) error {
 	return nil
 }
 
+// Location retrieves the location of the Server's IANA time zone. The
+// Server's Events are scheduled in this location.
+func (s Server) Location() *time.Location {
+	return LoadLocation(s.TimeZone)
+}
+
+// NextEvent retrieves the next Event of type kind to occur after t, evaluated
+// in the Server's time zone and skipping occurrences suppressed by the
+// Server's Blackouts. See Events.NextEvent.
+func (s Server) NextEvent(t time.Time, kind EventKind) (*Event, *time.Time, error) {
+	return s.Events.NextEvent(t.In(s.Location()), kind, s.Blackouts)
+}
+
 func (s *Server) ActiveVips() Vips {
 	var vips Vips
 	for _, vip := range vips {
@@ -113,9 +145,13 @@ func (s Server) Clone() *Server {
 	cloned.Wipes = s.Wipes.Clone()
 	cloned.Tags = s.Tags.Clone()
 	cloned.Events = s.Events.Clone()
+	cloned.Blackouts = s.Blackouts.Clone()
 	cloned.Moderators = s.Moderators.Clone()
 	cloned.Owners = s.Owners.Clone()
 	cloned.Vips = s.Vips.Clone()
+	cloned.Bans = s.Bans.Clone()
+	cloned.Plugins = s.Plugins.Clone()
+	cloned.Countdown = s.Countdown.Clone()
 	return &cloned
 }
 
@@ -124,13 +160,19 @@ func (s *Server) Scrub() {
 	s.InstanceID = "instance-ID"
 	s.AllocationID = "allocation-ID"
 	s.ElasticIP = "elastic-IP"
+	s.LifecycleChangedAt = nil
+	s.LifecycleHeartbeatAt = nil
+	s.InstanceStartedAt = nil
 
 	s.Wipes.Scrub()
 	s.Tags.Scrub()
 	s.Events.Scrub()
+	s.Blackouts.Scrub()
 	s.Moderators.Scrub()
 	s.Owners.Scrub()
 	s.Vips.Scrub()
+	s.Bans.Scrub()
+	s.Plugins.Scrub()
 }
 
 type LiveServers []LiveServer
@@ -156,6 +198,7 @@ func (s *LiveServers) Find(ctx context.Context, db *gorm.DB) error {
 		Preload("Server.Wipes").
 		Preload("Server.Tags").
 		Preload("Server.Events").
+		Preload("Server.Blackouts").
 		Preload("Server.Moderators").
 		Order("created_at DESC").
 		Find(s); res.Error != nil {
@@ -261,6 +304,16 @@ type ArchivedServer struct {
 	model.Model
 
 	Server Server `json:"server" gorm:"polymorphic:State"`
+
+	// DecommissionedAt is when the server's cloud resources were released.
+	// Nil if the server has not been decommissioned.
+	DecommissionedAt *time.Time
+}
+
+// IsDecommissioned reports if the archived server's cloud resources have been
+// released.
+func (s ArchivedServer) IsDecommissioned() bool {
+	return s.DecommissionedAt != nil
 }
 
 func (s ArchivedServer) Clone() ArchivedServer {
@@ -270,6 +323,7 @@ func (s ArchivedServer) Clone() ArchivedServer {
 func (s *ArchivedServer) Scrub() {
 	s.Model.Scrub()
 	s.Server.Scrub()
+	s.DecommissionedAt = nil
 }
 
 type InstanceKind string
@@ -0,0 +1,291 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ServerTemplate is a named ServerConfig from which servers may be created.
+type ServerTemplate struct {
+	model.Model
+	Name   string
+	Config ServerConfig
+}
+
+// Clone creates a deep copy of the ServerTemplate.
+func (t ServerTemplate) Clone() ServerTemplate {
+	cloned := t
+	cloned.Config = t.Config.Clone()
+	return cloned
+}
+
+// Scrub removes unpredictable data from the ServerTemplate.
+func (t *ServerTemplate) Scrub() {
+	t.Model.Scrub()
+}
+
+// ServerConfig is the configuration of a server, everything required to
+// create a server excluding its identity. Cloud resources, lifecycle state,
+// wipe history, vips and bans are not part of a server's configuration.
+type ServerConfig struct {
+	Name         string                 `json:"name"`
+	InstanceKind InstanceKind           `json:"instanceKind"`
+	MaxPlayers   uint16                 `json:"maxPlayers"`
+	MapSize      MapSizeKind            `json:"mapSize"`
+	MapSeed      uint32                 `json:"mapSeed"`
+	MapSalt      uint32                 `json:"mapSalt"`
+	TickRate     uint8                  `json:"tickRate"`
+	RconPassword string                 `json:"rconPassword"`
+	Description  string                 `json:"description"`
+	URL          string                 `json:"url"`
+	Background   BackgroundKind         `json:"background"`
+	BannerURL    string                 `json:"bannerURL"`
+	Region       Region                 `json:"region"`
+	TimeZone     string                 `json:"timeZone"`
+	Options      map[string]interface{} `json:"options"`
+
+	Events     []EventConfig    `json:"events"`
+	Blackouts  []BlackoutConfig `json:"blackouts"`
+	Moderators []string         `json:"moderators"`
+	Owners     []string         `json:"owners"`
+	Tags       []TagConfig      `json:"tags"`
+	PluginIDs  []uuid.UUID      `json:"pluginIds"`
+	Countdown  Countdown        `json:"countdown"`
+}
+
+// EventConfig is the configuration of a server Event.
+type EventConfig struct {
+	Schedule string        `json:"schedule"`
+	Weekday  *time.Weekday `json:"weekday"`
+	RunAt    *time.Time    `json:"runAt,omitempty"`
+	Kind     EventKind     `json:"kind"`
+}
+
+// BlackoutConfig is the configuration of a server Blackout.
+type BlackoutConfig struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	Kind     EventKind `json:"kind,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// TagConfig is the configuration of a server Tag.
+type TagConfig struct {
+	Description string   `json:"description"`
+	Icon        IconKind `json:"icon"`
+	Value       string   `json:"value"`
+}
+
+// Config retrieves the Server's configuration. The Server is cloned, the
+// ServerConfig does not share memory with the Server.
+func (s Server) Config() ServerConfig {
+	cloned := s.Clone()
+
+	options := make(map[string]interface{}, len(cloned.Options))
+	for key, value := range cloned.Options {
+		options[key] = value
+	}
+
+	config := ServerConfig{
+		Name:         cloned.Name,
+		InstanceKind: cloned.InstanceKind,
+		MaxPlayers:   cloned.MaxPlayers,
+		MapSize:      cloned.MapSize,
+		MapSeed:      cloned.Wipes.CurrentWipe().MapSeed,
+		MapSalt:      cloned.Wipes.CurrentWipe().MapSalt,
+		TickRate:     cloned.TickRate,
+		RconPassword: cloned.RconPassword,
+		Description:  cloned.Description,
+		URL:          cloned.URL,
+		Background:   cloned.Background,
+		BannerURL:    cloned.BannerURL,
+		Region:       cloned.Region,
+		TimeZone:     cloned.TimeZone,
+		Options:      options,
+		Events:       make([]EventConfig, 0, len(cloned.Events)),
+		Blackouts:    make([]BlackoutConfig, 0, len(cloned.Blackouts)),
+		Moderators:   make([]string, 0, len(cloned.Moderators)),
+		Owners:       make([]string, 0, len(cloned.Owners)),
+		Tags:         make([]TagConfig, 0, len(cloned.Tags)),
+		PluginIDs:    make([]uuid.UUID, 0, len(cloned.Plugins)),
+		Countdown:    cloned.Countdown,
+	}
+	for _, event := range cloned.Events {
+		var weekday *time.Weekday
+		if event.Weekday != nil {
+			day := *event.Weekday
+			weekday = &day
+		}
+		var runAt *time.Time
+		if event.RunAt != nil {
+			at := *event.RunAt
+			runAt = &at
+		}
+		config.Events = append(config.Events, EventConfig{
+			Schedule: event.Schedule,
+			Weekday:  weekday,
+			RunAt:    runAt,
+			Kind:     event.Kind,
+		})
+	}
+	for _, blackout := range cloned.Blackouts {
+		config.Blackouts = append(config.Blackouts, BlackoutConfig{
+			StartsAt: blackout.StartsAt,
+			EndsAt:   blackout.EndsAt,
+			Kind:     blackout.Kind,
+			Reason:   blackout.Reason,
+		})
+	}
+	for _, moderator := range cloned.Moderators {
+		config.Moderators = append(config.Moderators, moderator.SteamID)
+	}
+	for _, owner := range cloned.Owners {
+		config.Owners = append(config.Owners, owner.SteamID)
+	}
+	for _, tag := range cloned.Tags {
+		config.Tags = append(config.Tags, TagConfig{
+			Description: tag.Description,
+			Icon:        tag.Icon,
+			Value:       tag.Value,
+		})
+	}
+	for _, plugin := range cloned.Plugins {
+		config.PluginIDs = append(config.PluginIDs, plugin.PluginID)
+	}
+	return config
+}
+
+// Server creates a Server with the specified id from the ServerConfig. The
+// Server starts with a single full wipe using the configured map seed and
+// salt.
+func (c ServerConfig) Server(id uuid.UUID) Server {
+	cloned := c.Clone()
+
+	server := Server{
+		Model:        model.Model{ID: id},
+		Name:         cloned.Name,
+		InstanceKind: cloned.InstanceKind,
+		MaxPlayers:   cloned.MaxPlayers,
+		MapSize:      cloned.MapSize,
+		TickRate:     cloned.TickRate,
+		RconPassword: cloned.RconPassword,
+		Description:  cloned.Description,
+		URL:          cloned.URL,
+		Background:   cloned.Background,
+		BannerURL:    cloned.BannerURL,
+		Region:       cloned.Region,
+		TimeZone:     cloned.TimeZone,
+		Options:      cloned.Options,
+		Wipes: Wipes{
+			{Kind: WipeKindFull, MapSeed: cloned.MapSeed, MapSalt: cloned.MapSalt},
+		},
+		Events:     make(Events, 0, len(cloned.Events)),
+		Blackouts:  make(Blackouts, 0, len(cloned.Blackouts)),
+		Moderators: make(Moderators, 0, len(cloned.Moderators)),
+		Owners:     make(Owners, 0, len(cloned.Owners)),
+		Tags:       make(Tags, 0, len(cloned.Tags)),
+		Plugins:    make(ServerPlugins, 0, len(cloned.PluginIDs)),
+		Countdown:  cloned.Countdown,
+	}
+	for _, event := range cloned.Events {
+		server.Events = append(server.Events, Event{
+			Schedule: event.Schedule,
+			Weekday:  event.Weekday,
+			RunAt:    event.RunAt,
+			Kind:     event.Kind,
+		})
+	}
+	for _, blackout := range cloned.Blackouts {
+		server.Blackouts = append(server.Blackouts, Blackout{
+			StartsAt: blackout.StartsAt,
+			EndsAt:   blackout.EndsAt,
+			Kind:     blackout.Kind,
+			Reason:   blackout.Reason,
+		})
+	}
+	for _, steamID := range cloned.Moderators {
+		server.Moderators = append(server.Moderators, Moderator{SteamID: steamID})
+	}
+	for _, steamID := range cloned.Owners {
+		server.Owners = append(server.Owners, Owner{SteamID: steamID})
+	}
+	for _, tag := range cloned.Tags {
+		server.Tags = append(server.Tags, Tag{
+			Description: tag.Description,
+			Icon:        tag.Icon,
+			Value:       tag.Value,
+		})
+	}
+	for _, pluginID := range cloned.PluginIDs {
+		server.Plugins = append(server.Plugins, ServerPlugin{PluginID: pluginID})
+	}
+	return server
+}
+
+// Clone creates a deep copy of the ServerConfig.
+func (c ServerConfig) Clone() ServerConfig {
+	cloned := c
+
+	cloned.Options = make(map[string]interface{}, len(c.Options))
+	for key, value := range c.Options {
+		cloned.Options[key] = value
+	}
+
+	cloned.Events = make([]EventConfig, 0, len(c.Events))
+	for _, event := range c.Events {
+		if event.Weekday != nil {
+			weekday := *event.Weekday
+			event.Weekday = &weekday
+		}
+		if event.RunAt != nil {
+			runAt := *event.RunAt
+			event.RunAt = &runAt
+		}
+		cloned.Events = append(cloned.Events, event)
+	}
+	cloned.Blackouts = append([]BlackoutConfig(nil), c.Blackouts...)
+
+	cloned.Moderators = append([]string(nil), c.Moderators...)
+	cloned.Owners = append([]string(nil), c.Owners...)
+	cloned.Tags = append([]TagConfig(nil), c.Tags...)
+	cloned.PluginIDs = append([]uuid.UUID(nil), c.PluginIDs...)
+	cloned.Countdown = c.Countdown.Clone()
+	return cloned
+}
+
+// Value implements the driver.Valuer interface. ServerConfig is stored as
+// JSON.
+func (c ServerConfig) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling server config: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface. ServerConfig is stored as JSON.
+func (c *ServerConfig) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = ServerConfig{}
+		return nil
+	default:
+		return fmt.Errorf("unexpected server config type %T", value)
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("while unmarshalling server config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfig(t *testing.T) {
+	sourceID := uuid.New()
+	pluginID := uuid.New()
+	wednesday := time.Wednesday
+	at := time.Date(2022, time.June, 14, 18, 0, 0, 0, time.UTC)
+
+	source := Server{
+		Model:        model.Model{ID: sourceID, At: model.At{CreatedAt: at}},
+		StateID:      uuid.New(),
+		StateType:    LiveServerState,
+		Name:         "source",
+		InstanceID:   "i-source",
+		InstanceKind: InstanceKindStandard,
+		AllocationID: "eipalloc-source",
+		ElasticIP:    "127.0.0.1",
+		MaxPlayers:   200,
+		MapSize:      MapSizeLarge,
+		TickRate:     30,
+		RconPassword: "rcon-password",
+		Description:  "source description",
+		Background:   BackgroundKindAirport,
+		URL:          "https://rustpm.com",
+		BannerURL:    "https://rustpm.com/banner.png",
+		Region:       RegionUsEast,
+		TimeZone:     "Europe/Berlin",
+		Options:      map[string]interface{}{"server.pve": true},
+		LastSavedAt:  &at,
+		Countdown:    DefaultCountdown.Clone(),
+		Lifecycle:    LifecycleFailed,
+		Wipes: Wipes{
+			{Model: model.Model{ID: uuid.New(), At: model.At{CreatedAt: at.Add(-time.Hour)}}, Kind: WipeKindFull, MapSeed: 1, MapSalt: 2, ServerID: sourceID},
+			{Model: model.Model{ID: uuid.New(), At: model.At{CreatedAt: at}}, Kind: WipeKindMap, MapSeed: 3, MapSalt: 4, ServerID: sourceID, AppliedAt: sql.NullTime{Time: at, Valid: true}},
+		},
+		Events: Events{
+			{Model: model.Model{ID: uuid.New()}, Schedule: "0 20 * * *", Kind: EventKindStart, ServerID: sourceID},
+			{Model: model.Model{ID: uuid.New()}, Schedule: "0 18 * * *", Weekday: &wednesday, Kind: EventKindMapWipe, ServerID: sourceID},
+			{Model: model.Model{ID: uuid.New()}, RunAt: &at, Kind: EventKindStart, ServerID: sourceID},
+		},
+		Blackouts: Blackouts{
+			{Model: model.Model{ID: uuid.New()}, StartsAt: at, EndsAt: at.Add(24 * time.Hour), Kind: EventKindStop, Reason: "holiday", ServerID: sourceID},
+		},
+		Moderators: Moderators{{Model: model.Model{ID: uuid.New()}, SteamID: "moderator", ServerID: sourceID}},
+		Owners:     Owners{{Model: model.Model{ID: uuid.New()}, SteamID: "owner", ServerID: sourceID}},
+		Tags:       Tags{{Model: model.Model{ID: uuid.New()}, Description: "Players", Icon: IconKindUserGroup, Value: "200", ServerID: sourceID}},
+		Vips:       Vips{{Model: model.Model{ID: uuid.New()}, SteamID: "vip", ServerID: sourceID, ExpiresAt: at}},
+		Bans:       Bans{{Model: model.Model{ID: uuid.New()}, SteamID: "banned", ServerID: sourceID}},
+		Plugins:    ServerPlugins{{Model: model.Model{ID: uuid.New()}, PluginID: pluginID, ServerID: sourceID}},
+	}
+
+	config := source.Config()
+	require.Equal(t, uint32(3), config.MapSeed)
+	require.Equal(t, uint32(4), config.MapSalt)
+	require.Equal(t, []string{"moderator"}, config.Moderators)
+	require.Equal(t, []string{"owner"}, config.Owners)
+	require.Equal(t, []uuid.UUID{pluginID}, config.PluginIDs)
+
+	id := uuid.New()
+	cloned := config.Server(id)
+
+	exp := Server{
+		Model:        model.Model{ID: id},
+		Name:         "source",
+		InstanceKind: InstanceKindStandard,
+		MaxPlayers:   200,
+		MapSize:      MapSizeLarge,
+		TickRate:     30,
+		RconPassword: "rcon-password",
+		Description:  "source description",
+		Background:   BackgroundKindAirport,
+		URL:          "https://rustpm.com",
+		BannerURL:    "https://rustpm.com/banner.png",
+		Region:       RegionUsEast,
+		TimeZone:     "Europe/Berlin",
+		Options:      map[string]interface{}{"server.pve": true},
+		Countdown:    DefaultCountdown.Clone(),
+		Wipes:        Wipes{{Kind: WipeKindFull, MapSeed: 3, MapSalt: 4}},
+		Events: Events{
+			{Schedule: "0 20 * * *", Kind: EventKindStart},
+			{Schedule: "0 18 * * *", Weekday: &wednesday, Kind: EventKindMapWipe},
+			{RunAt: &at, Kind: EventKindStart},
+		},
+		Blackouts: Blackouts{
+			{StartsAt: at, EndsAt: at.Add(24 * time.Hour), Kind: EventKindStop, Reason: "holiday"},
+		},
+		Moderators: Moderators{{SteamID: "moderator"}},
+		Owners:     Owners{{SteamID: "owner"}},
+		Tags:       Tags{{Description: "Players", Icon: IconKindUserGroup, Value: "200"}},
+		Plugins:    ServerPlugins{{PluginID: pluginID}},
+	}
+	require.Equal(t, exp, cloned)
+
+	t.Run("independent of source", func(t *testing.T) {
+		config := source.Config()
+		config.Options["server.pve"] = false
+		*config.Events[1].Weekday = time.Friday
+		config.Countdown.Warnings[0] = 1
+
+		require.Equal(t, true, source.Options["server.pve"])
+		require.Equal(t, time.Wednesday, *source.Events[1].Weekday)
+		require.Equal(t, DefaultCountdown.Warnings[0], source.Countdown.Warnings[0])
+	})
+
+	t.Run("json round trip", func(t *testing.T) {
+		value, err := config.Value()
+		require.Nil(t, err)
+
+		var scanned ServerConfig
+		err = scanned.Scan(value)
+		require.Nil(t, err)
+		require.Equal(t, config.Server(id), scanned.Server(id))
+	})
+}
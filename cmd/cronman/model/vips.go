@@ -1,6 +1,7 @@
 package model
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/tjper/rustcron/internal/model"
@@ -66,6 +67,9 @@ type Vip struct {
 	ServerID  uuid.UUID
 	SteamID   string
 	ExpiresAt time.Time
+	// RevokedAt is when the Vip's privileges were revoked from its live server
+	// after it expired. Refreshing the Vip clears RevokedAt.
+	RevokedAt sql.NullTime
 }
 
 // Equal checks if the Vip instance is equal to the passed Vip instance.
@@ -75,6 +79,8 @@ func (vip Vip) Equal(vip2 Vip) bool {
 	equal = equal && vip.ExpiresAt.Equal(vip2.ExpiresAt)
 	equal = equal && vip.ServerID == vip2.ServerID
 	equal = equal && vip.SteamID == vip2.SteamID
+	equal = equal && vip.RevokedAt.Valid == vip2.RevokedAt.Valid
+	equal = equal && vip.RevokedAt.Time.Equal(vip2.RevokedAt.Time)
 	return equal
 }
 
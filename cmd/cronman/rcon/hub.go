@@ -2,7 +2,10 @@ package rcon
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -12,37 +15,680 @@ import (
 type IRcon interface {
 	Close()
 	Quit(context.Context) error
+	Save(context.Context) error
+	WriteCfg(context.Context) error
 	Say(context.Context, string) error
 	AddModerator(context.Context, string) error
 	RemoveModerator(context.Context, string) error
 	AddOwner(context.Context, string) error
 	RemoveOwner(context.Context, string) error
 	GrantPermission(context.Context, string, string) error
+	GrantGroupPermission(context.Context, string, string) error
 	RevokePermission(context.Context, string, string) error
 	CreateGroup(context.Context, string) error
 	AddToGroup(context.Context, string, string) error
+	RemoveFromGroup(context.Context, string, string) error
 	ServerInfo(context.Context) (*ServerInfo, error)
+	PlayerList(context.Context) ([]Player, error)
+	Kick(context.Context, string, string) error
+	Ban(context.Context, string, string) error
+	Unban(context.Context, string) error
+	ReloadPlugin(context.Context, string) error
+	UnloadPlugin(context.Context, string) error
+	Subscribe(context.Context, Filter) (<-chan Inbound, error)
+	Exec(context.Context, string) (*Inbound, error)
+}
+
+var (
+	// ErrConnDNE indicates that the Hub does not have a pooled connection for
+	// the specified url and password.
+	ErrConnDNE = errors.New("rcon hub connection does not exist")
+
+	// ErrConnReconnecting indicates that the pooled connection has been lost
+	// and the Hub is in the process of reconnecting.
+	ErrConnReconnecting = errors.New("rcon hub connection reconnecting")
+
+	// errConnClosed indicates that the pooled connection was closed while
+	// being used.
+	errConnClosed = errors.New("rcon hub connection closed")
+)
+
+const (
+	// defaultIdleTimeout is the default period of time a pooled connection
+	// may go unused before it is closed.
+	defaultIdleTimeout = 10 * time.Minute
+
+	// defaultMinBackoff is the default initial wait between reconnect
+	// attempts.
+	defaultMinBackoff = time.Second
+
+	// defaultMaxBackoff is the default maximum wait between reconnect
+	// attempts.
+	defaultMaxBackoff = time.Minute
+
+	// reconnectTimeout is the time allowed for a single reconnect attempt.
+	reconnectTimeout = 30 * time.Second
+)
+
+// HubOption mutates a Hub instance. Typically used with NewHub to configure
+// a Hub instance.
+type HubOption func(*Hub)
+
+// WithIdleTimeout is a HubOption that configures the period of time a pooled
+// connection may go unused before it is closed.
+func WithIdleTimeout(timeout time.Duration) HubOption {
+	return func(h *Hub) {
+		h.idleTimeout = timeout
+	}
+}
+
+// WithReconnectBackoff is a HubOption that configures the minimum and
+// maximum wait between attempts to reconnect a dropped connection. The wait
+// doubles after each failed attempt.
+func WithReconnectBackoff(min, max time.Duration) HubOption {
+	return func(h *Hub) {
+		h.minBackoff = min
+		h.maxBackoff = max
+	}
 }
 
 // NewHub creates a Hub instance.
-func NewHub(logger *zap.Logger) *Hub {
-	return &Hub{
-		logger: logger,
+func NewHub(logger *zap.Logger, options ...HubOption) *Hub {
+	h := &Hub{
+		logger:      logger,
+		idleTimeout: defaultIdleTimeout,
+		minBackoff:  defaultMinBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		mutex:       new(sync.Mutex),
+		conns:       make(map[string]*hubConn),
+	}
+
+	for _, option := range options {
+		option(h)
 	}
+	return h
 }
 
 // Hub is responsible for managing access to many cronman servers' Rcon
 // functionality. Enclosing this functionality into a type allows for simple
 // mocking.
+//
+// Hub maintains a single long-lived connection per url and password. Dropped
+// connections are reconnected with backoff, and connections that go unused
+// are closed by Hub.Launch.
 type Hub struct {
 	logger *zap.Logger
+
+	idleTimeout time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	mutex *sync.Mutex
+	conns map[string]*hubConn
 }
 
 // Dial creates an IRcon implementation using the specified url and password.
-func (h Hub) Dial(ctx context.Context, url, password string) (IRcon, error) {
-	return Dial(
-		ctx,
-		zap.NewExample(),
-		fmt.Sprintf("ws://%s/%s", url, password),
-	)
+// The IRcon shares the Hub's pooled connection for the url and password;
+// calling IRcon.Close releases the IRcon without closing the connection.
+func (h *Hub) Dial(ctx context.Context, url, password string) (IRcon, error) {
+	conn := h.conn(url, password)
+	if _, err := conn.client(ctx); err != nil {
+		if conn.health().ConnectedAt.IsZero() {
+			// The connection has never been established, do not pool it.
+			h.remove(conn)
+		}
+		return nil, err
+	}
+
+	return &pooledClient{
+		hub:      h,
+		url:      url,
+		password: password,
+	}, nil
+}
+
+// Launch closes pooled connections that have been idle longer than the
+// Hub's idle timeout. Launch blocks until the context is cancelled, at which
+// point all pooled connections are closed.
+func (h *Hub) Launch(ctx context.Context) error {
+	ticker := time.NewTicker(h.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.Close()
+			return ctx.Err()
+		case <-ticker.C:
+			h.closeIdle()
+		}
+	}
+}
+
+// Close closes all of the Hub's pooled connections.
+func (h *Hub) Close() {
+	h.mutex.Lock()
+	conns := h.conns
+	h.conns = make(map[string]*hubConn)
+	h.mutex.Unlock()
+
+	for _, conn := range conns {
+		conn.close()
+	}
+}
+
+// Health retrieves the health of the pooled connection for the specified url
+// and password. If the Hub does not have a pooled connection, ErrConnDNE is
+// returned.
+func (h *Hub) Health(url, password string) (*ConnHealth, error) {
+	h.mutex.Lock()
+	conn, ok := h.conns[connKey(url, password)]
+	h.mutex.Unlock()
+	if !ok {
+		return nil, ErrConnDNE
+	}
+
+	health := conn.health()
+	return &health, nil
+}
+
+// ConnHealth describes the state of a Hub pooled connection.
+type ConnHealth struct {
+	// Connected indicates if the connection is currently established.
+	Connected bool
+	// Reconnecting indicates if the connection has been lost and is being
+	// reconnected.
+	Reconnecting bool
+	// Reconnects is the number of times the connection has been
+	// re-established.
+	Reconnects int
+	// ConnectedAt is when the connection was most recently established.
+	ConnectedAt time.Time
+	// LastUsed is when the connection was most recently used.
+	LastUsed time.Time
+	// LastError is the most recent error encountered while connecting.
+	LastError error
+}
+
+// --- private ---
+
+// connKey creates the key by which a pooled connection is identified.
+func connKey(url, password string) string {
+	return fmt.Sprintf("ws://%s/%s", url, password)
+}
+
+// conn retrieves the pooled connection for the url and password, creating it
+// if it does not exist.
+func (h *Hub) conn(url, password string) *hubConn {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	key := connKey(url, password)
+	if conn, ok := h.conns[key]; ok {
+		return conn
+	}
+
+	conn := &hubConn{
+		logger:    h.logger.With(zap.String("rcon-url", url)),
+		hub:       h,
+		key:       key,
+		dialMutex: new(sync.Mutex),
+		mutex:     new(sync.Mutex),
+		done:      make(chan struct{}),
+		closeOnce: new(sync.Once),
+	}
+	h.conns[key] = conn
+	return conn
+}
+
+// client retrieves the connected Client for the url and password.
+func (h *Hub) client(ctx context.Context, url, password string) (*Client, error) {
+	return h.conn(url, password).client(ctx)
+}
+
+// remove closes and removes the pooled connection from the Hub.
+func (h *Hub) remove(conn *hubConn) {
+	h.mutex.Lock()
+	if h.conns[conn.key] == conn {
+		delete(h.conns, conn.key)
+	}
+	h.mutex.Unlock()
+
+	conn.close()
+}
+
+// closeIdle closes all pooled connections that have exceeded the Hub's idle
+// timeout.
+func (h *Hub) closeIdle() {
+	h.mutex.Lock()
+	idle := make([]*hubConn, 0)
+	for _, conn := range h.conns {
+		if conn.idle() {
+			idle = append(idle, conn)
+		}
+	}
+	h.mutex.Unlock()
+
+	for _, conn := range idle {
+		conn.logger.Debug("closing idle rcon connection")
+		h.remove(conn)
+	}
+}
+
+// hubConn is a long-lived connection pooled by a Hub.
+type hubConn struct {
+	logger *zap.Logger
+	hub    *Hub
+	key    string
+
+	// dialMutex ensures only one dial is in progress for the hubConn.
+	dialMutex *sync.Mutex
+
+	mutex        *sync.Mutex
+	current      *Client
+	reconnecting bool
+	reconnects   int
+	connectedAt  time.Time
+	lastUsed     time.Time
+	lastErr      error
+
+	done      chan struct{}
+	closeOnce *sync.Once
+}
+
+// client retrieves the hubConn's connected Client. If the hubConn has not
+// yet connected, a connection is dialed. If the hubConn is reconnecting,
+// ErrConnReconnecting is returned.
+func (c *hubConn) client(ctx context.Context) (*Client, error) {
+	c.mutex.Lock()
+	c.lastUsed = time.Now()
+	current, reconnecting, lastErr := c.current, c.reconnecting, c.lastErr
+	c.mutex.Unlock()
+
+	if current != nil {
+		return current, nil
+	}
+	if reconnecting {
+		return nil, fmt.Errorf("%w; %s", ErrConnReconnecting, lastErr)
+	}
+
+	c.dialMutex.Lock()
+	defer c.dialMutex.Unlock()
+
+	// Another process may have connected while waiting on dialMutex.
+	c.mutex.Lock()
+	current = c.current
+	c.mutex.Unlock()
+	if current != nil {
+		return current, nil
+	}
+
+	select {
+	case <-c.done:
+		return nil, errConnClosed
+	default:
+	}
+
+	client, err := Dial(ctx, c.logger, c.key)
+	if err != nil {
+		c.mutex.Lock()
+		c.lastErr = err
+		c.mutex.Unlock()
+		return nil, err
+	}
+	c.connected(client)
+	return client, nil
+}
+
+// connected sets the hubConn's current Client and watches for it to close.
+func (c *hubConn) connected(client *Client) {
+	c.mutex.Lock()
+	c.current = client
+	c.reconnecting = false
+	c.connectedAt = time.Now()
+	c.lastErr = nil
+	c.mutex.Unlock()
+
+	go c.watch(client)
+}
+
+// watch waits for the Client to close. If the hubConn has not been closed,
+// the hubConn begins reconnecting.
+func (c *hubConn) watch(client *Client) {
+	select {
+	case <-c.done:
+		client.Close()
+		return
+	case <-client.closed:
+	}
+
+	c.mutex.Lock()
+	if c.current == client {
+		c.current = nil
+	}
+	c.reconnecting = true
+	c.mutex.Unlock()
+
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	c.logger.Warn("rcon connection lost, reconnecting")
+	c.reconnect()
+}
+
+// reconnect attempts to re-establish the hubConn's connection with
+// exponential backoff. Reconnecting stops if the hubConn is closed or has
+// been idle longer than the Hub's idle timeout.
+func (c *hubConn) reconnect() {
+	backoff := c.hub.minBackoff
+	for {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-c.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if c.idle() {
+			c.logger.Debug("rcon connection idle, abandoning reconnect")
+			c.hub.remove(c)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), reconnectTimeout)
+		c.dialMutex.Lock()
+		client, err := Dial(ctx, c.logger, c.key)
+		c.dialMutex.Unlock()
+		cancel()
+
+		if err == nil {
+			c.mutex.Lock()
+			c.reconnects++
+			c.mutex.Unlock()
+
+			c.logger.Info("rcon connection re-established")
+			c.connected(client)
+			return
+		}
+
+		c.mutex.Lock()
+		c.lastErr = err
+		c.mutex.Unlock()
+		c.logger.Warn("while reconnecting rcon", zap.Duration("backoff", backoff), zap.Error(err))
+
+		backoff *= 2
+		if backoff > c.hub.maxBackoff {
+			backoff = c.hub.maxBackoff
+		}
+	}
+}
+
+// idle checks if the hubConn has been unused longer than the Hub's idle
+// timeout.
+func (c *hubConn) idle() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return time.Since(c.lastUsed) > c.hub.idleTimeout
+}
+
+// health retrieves the hubConn's ConnHealth.
+func (c *hubConn) health() ConnHealth {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return ConnHealth{
+		Connected:    c.current != nil,
+		Reconnecting: c.reconnecting,
+		Reconnects:   c.reconnects,
+		ConnectedAt:  c.connectedAt,
+		LastUsed:     c.lastUsed,
+		LastError:    c.lastErr,
+	}
+}
+
+// close closes the hubConn and its current Client. A closed hubConn does not
+// reconnect.
+func (c *hubConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+
+		c.mutex.Lock()
+		current := c.current
+		c.current = nil
+		c.reconnecting = false
+		c.mutex.Unlock()
+
+		if current != nil {
+			current.Close()
+		}
+	})
+}
+
+// pooledClient is the IRcon implementation returned by Hub.Dial. Each call
+// is made using the Hub's pooled connection.
+type pooledClient struct {
+	hub      *Hub
+	url      string
+	password string
+}
+
+// Close releases the pooledClient. The underlying pooled connection remains
+// open.
+func (c pooledClient) Close() {}
+
+// Quit calls Client.Quit. As the Rust server is shutting down, the pooled
+// connection is removed from the Hub rather than reconnected.
+func (c pooledClient) Quit(ctx context.Context) error {
+	conn := c.hub.conn(c.url, c.password)
+	client, err := conn.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.hub.remove(conn)
+
+	return client.Quit(ctx)
+}
+
+// Say calls Client.Say using the pooled connection.
+func (c pooledClient) Say(ctx context.Context, msg string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.Say(ctx, msg)
+}
+
+// AddModerator calls Client.AddModerator using the pooled connection.
+func (c pooledClient) AddModerator(ctx context.Context, id string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.AddModerator(ctx, id)
+}
+
+// RemoveModerator calls Client.RemoveModerator using the pooled connection.
+func (c pooledClient) RemoveModerator(ctx context.Context, id string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.RemoveModerator(ctx, id)
+}
+
+// AddOwner calls Client.AddOwner using the pooled connection.
+func (c pooledClient) AddOwner(ctx context.Context, id string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.AddOwner(ctx, id)
+}
+
+// RemoveOwner calls Client.RemoveOwner using the pooled connection.
+func (c pooledClient) RemoveOwner(ctx context.Context, id string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.RemoveOwner(ctx, id)
+}
+
+// GrantPermission calls Client.GrantPermission using the pooled connection.
+func (c pooledClient) GrantPermission(ctx context.Context, steamID, permission string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.GrantPermission(ctx, steamID, permission)
+}
+
+// GrantGroupPermission calls Client.GrantGroupPermission using the pooled
+// connection.
+func (c pooledClient) GrantGroupPermission(ctx context.Context, group, permission string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.GrantGroupPermission(ctx, group, permission)
+}
+
+// RevokePermission calls Client.RevokePermission using the pooled connection.
+func (c pooledClient) RevokePermission(ctx context.Context, steamID, permission string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.RevokePermission(ctx, steamID, permission)
+}
+
+// CreateGroup calls Client.CreateGroup using the pooled connection.
+func (c pooledClient) CreateGroup(ctx context.Context, group string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.CreateGroup(ctx, group)
+}
+
+// AddToGroup calls Client.AddToGroup using the pooled connection.
+func (c pooledClient) AddToGroup(ctx context.Context, steamID, group string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.AddToGroup(ctx, steamID, group)
+}
+
+// RemoveFromGroup calls Client.RemoveFromGroup using the pooled connection.
+func (c pooledClient) RemoveFromGroup(ctx context.Context, steamID, group string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.RemoveFromGroup(ctx, steamID, group)
+}
+
+// ServerInfo calls Client.ServerInfo using the pooled connection.
+func (c pooledClient) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return nil, err
+	}
+	return client.ServerInfo(ctx)
+}
+
+// PlayerList calls Client.PlayerList using the pooled connection.
+func (c pooledClient) PlayerList(ctx context.Context) ([]Player, error) {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return nil, err
+	}
+	return client.PlayerList(ctx)
+}
+
+// Kick calls Client.Kick using the pooled connection.
+func (c pooledClient) Kick(ctx context.Context, steamID, reason string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.Kick(ctx, steamID, reason)
+}
+
+// Ban calls Client.Ban using the pooled connection.
+func (c pooledClient) Ban(ctx context.Context, steamID, reason string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.Ban(ctx, steamID, reason)
+}
+
+// Unban calls Client.Unban using the pooled connection.
+func (c pooledClient) Unban(ctx context.Context, steamID string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.Unban(ctx, steamID)
+}
+
+// ReloadPlugin calls Client.ReloadPlugin using the pooled connection.
+func (c pooledClient) ReloadPlugin(ctx context.Context, name string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.ReloadPlugin(ctx, name)
+}
+
+// UnloadPlugin calls Client.UnloadPlugin using the pooled connection.
+func (c pooledClient) UnloadPlugin(ctx context.Context, name string) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.UnloadPlugin(ctx, name)
+}
+
+// Save calls Client.Save using the pooled connection.
+func (c pooledClient) Save(ctx context.Context) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.Save(ctx)
+}
+
+// WriteCfg calls Client.WriteCfg using the pooled connection.
+func (c pooledClient) WriteCfg(ctx context.Context) error {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return err
+	}
+	return client.WriteCfg(ctx)
+}
+
+// Subscribe calls Client.Subscribe using the pooled connection. The returned
+// channel is closed if the pooled connection is lost.
+func (c pooledClient) Subscribe(ctx context.Context, filter Filter) (<-chan Inbound, error) {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return nil, err
+	}
+	return client.Subscribe(ctx, filter)
+}
+
+// Exec calls Client.Exec using the pooled connection.
+func (c pooledClient) Exec(ctx context.Context, command string) (*Inbound, error) {
+	client, err := c.hub.client(ctx, c.url, c.password)
+	if err != nil {
+		return nil, err
+	}
+	return client.Exec(ctx, command)
 }
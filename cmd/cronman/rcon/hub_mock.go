@@ -29,10 +29,19 @@ func WithServerInfo(serverInfo ServerInfo) HubMockOption {
 	}
 }
 
+// WithPlayers is a HubMockOption that configures the HubMock's player list
+// response used for ClientMock.PlayerList calls.
+func WithPlayers(players []Player) HubMockOption {
+	return func(m *HubMock) {
+		m.players = players
+	}
+}
+
 // HubMock mocks a Hub instance for testing.
 type HubMock struct {
 	stack      []string
 	serverInfo ServerInfo
+	players    []Player
 }
 
 // Dial mocks the dialing and creation of a IRcon instance.
@@ -42,6 +51,7 @@ func (h *HubMock) Dial(ctx context.Context, url, password string) (IRcon, error)
 		password: password,
 		hub:      h,
 		msgc:     make(chan string, 128),
+		inboundc: make(chan Inbound, 128),
 	}, nil
 }
 
@@ -60,8 +70,9 @@ type ClientMock struct {
 	url      string
 	password string
 
-	hub  *HubMock
-	msgc chan string
+	hub      *HubMock
+	msgc     chan string
+	inboundc chan Inbound
 }
 
 // Close mocks Client.Close.
@@ -70,6 +81,20 @@ func (m ClientMock) Close() {}
 // Quit mocks Client.Quit.
 func (m ClientMock) Quit(_ context.Context) error { return nil }
 
+// Save mocks Client.Save. The save is pushed onto the HubMock's internal
+// stack.
+func (m ClientMock) Save(_ context.Context) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s server.save", m.url, m.password))
+	return nil
+}
+
+// WriteCfg mocks Client.WriteCfg. The writecfg is pushed onto the HubMock's
+// internal stack.
+func (m ClientMock) WriteCfg(_ context.Context) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s server.writecfg", m.url, m.password))
+	return nil
+}
+
 // Say mocks Client.Say.
 func (m ClientMock) Say(_ context.Context, msg string) error {
 	m.msgc <- msg
@@ -104,6 +129,9 @@ func (m ClientMock) GrantPermission(_ context.Context, steamID string, permissio
 	return nil
 }
 
+// GrantGroupPermission mocks Client.GrantGroupPermission.
+func (m ClientMock) GrantGroupPermission(_ context.Context, _ string, _ string) error { return nil }
+
 // RevokePermission mocks Client.RevokePermission.
 func (m ClientMock) RevokePermission(_ context.Context, _ string, _ string) error { return nil }
 
@@ -116,7 +144,91 @@ func (m ClientMock) AddToGroup(_ context.Context, steamID string, group string)
 	return nil
 }
 
+// RemoveFromGroup mocks Client.RemoveFromGroup. The removal is pushed onto
+// the HubMock's internal stack.
+func (m ClientMock) RemoveFromGroup(_ context.Context, steamID string, group string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s remove %s %s", m.url, m.password, steamID, group))
+	return nil
+}
+
 // ServerInfo mocks Client.ServerInfo.
 func (m ClientMock) ServerInfo(_ context.Context) (*ServerInfo, error) {
 	return &m.hub.serverInfo, nil
 }
+
+// PlayerList mocks Client.PlayerList.
+func (m ClientMock) PlayerList(_ context.Context) ([]Player, error) {
+	return m.hub.players, nil
+}
+
+// Kick mocks Client.Kick. The kick is pushed onto the HubMock's internal
+// stack.
+func (m ClientMock) Kick(_ context.Context, steamID string, _ string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s kick %s", m.url, m.password, steamID))
+	return nil
+}
+
+// Ban mocks Client.Ban. The ban is pushed onto the HubMock's internal stack.
+func (m ClientMock) Ban(_ context.Context, steamID string, _ string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s ban %s", m.url, m.password, steamID))
+	return nil
+}
+
+// Unban mocks Client.Unban. The unban is pushed onto the HubMock's internal
+// stack.
+func (m ClientMock) Unban(_ context.Context, steamID string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s unban %s", m.url, m.password, steamID))
+	return nil
+}
+
+// ReloadPlugin mocks Client.ReloadPlugin. The reload is pushed onto the
+// HubMock's internal stack.
+func (m ClientMock) ReloadPlugin(_ context.Context, name string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s oxide.reload %s", m.url, m.password, name))
+	return nil
+}
+
+// UnloadPlugin mocks Client.UnloadPlugin. The unload is pushed onto the
+// HubMock's internal stack.
+func (m ClientMock) UnloadPlugin(_ context.Context, name string) error {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s oxide.unload %s", m.url, m.password, name))
+	return nil
+}
+
+// Exec mocks Client.Exec. The command is pushed onto the HubMock's internal
+// stack and an empty Generic response is returned.
+func (m ClientMock) Exec(_ context.Context, command string) (*Inbound, error) {
+	m.hub.stack = append(m.hub.stack, fmt.Sprintf("%s %s %s", m.url, m.password, command))
+	return &Inbound{Type: "Generic"}, nil
+}
+
+// Subscribe mocks Client.Subscribe. Messages passed to Publish that pass the
+// filter are written to the returned channel.
+func (m ClientMock) Subscribe(ctx context.Context, filter Filter) (<-chan Inbound, error) {
+	subc := make(chan Inbound)
+	go func() {
+		defer close(subc)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in := <-m.inboundc:
+				if filter != nil && !filter(in) {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case subc <- in:
+				}
+			}
+		}
+	}()
+	return subc, nil
+}
+
+// Publish writes an unsolicited Inbound message to the ClientMock's
+// subscribers.
+func (m ClientMock) Publish(in Inbound) {
+	m.inboundc <- in
+}
@@ -0,0 +1,129 @@
+package rcon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHubDial(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	hub := NewHub(zap.NewNop())
+	defer hub.Close()
+
+	for i := 0; i < 3; i++ {
+		client, err := hub.Dial(ctx, server.addr(), "password")
+		require.Nil(t, err)
+
+		info, err := client.ServerInfo(ctx)
+		require.Nil(t, err)
+		require.Equal(t, "fake-server", info.Hostname)
+
+		client.Close()
+	}
+	require.Equal(t, 1, server.connections())
+
+	health, err := hub.Health(server.addr(), "password")
+	require.Nil(t, err)
+	require.True(t, health.Connected)
+	require.Equal(t, 0, health.Reconnects)
+
+	_, err = hub.Health(server.addr(), "other-password")
+	require.ErrorIs(t, err, ErrConnDNE)
+}
+
+func TestHubDialError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	addr := server.addr()
+	server.Close()
+
+	hub := NewHub(zap.NewNop())
+	defer hub.Close()
+
+	_, err := hub.Dial(ctx, addr, "password")
+	require.NotNil(t, err)
+
+	_, err = hub.Health(addr, "password")
+	require.ErrorIs(t, err, ErrConnDNE)
+}
+
+func TestHubReconnect(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	hub := NewHub(
+		zap.NewNop(),
+		WithReconnectBackoff(10*time.Millisecond, 100*time.Millisecond),
+	)
+	defer hub.Close()
+
+	client, err := hub.Dial(ctx, server.addr(), "password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	server.drop(t)
+
+	require.Eventually(t, func() bool {
+		health, err := hub.Health(server.addr(), "password")
+		require.Nil(t, err)
+		return health.Connected && health.Reconnects == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, server.connections())
+
+	// The IRcon retrieved prior to the reconnect uses the new connection.
+	info, err := client.ServerInfo(ctx)
+	require.Nil(t, err)
+	require.Equal(t, "fake-server", info.Hostname)
+}
+
+func TestHubIdle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	hub := NewHub(zap.NewNop(), WithIdleTimeout(50*time.Millisecond))
+	defer hub.Close()
+	go func() { _ = hub.Launch(ctx) }()
+
+	client, err := hub.Dial(ctx, server.addr(), "password")
+	require.Nil(t, err)
+	client.Close()
+
+	require.Eventually(t, func() bool {
+		_, err := hub.Health(server.addr(), "password")
+		return err == ErrConnDNE
+	}, time.Second, 10*time.Millisecond)
+
+	// Dialing after an idle close establishes a new connection.
+	client, err = hub.Dial(ctx, server.addr(), "password")
+	require.Nil(t, err)
+	defer client.Close()
+
+	_, err = client.ServerInfo(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 2, server.connections())
+}
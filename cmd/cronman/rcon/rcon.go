@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,6 +37,42 @@ var (
 	// already been granted for the specified user.
 	ErrPermissionAlreadyGranted = errors.New("permission has already been granted")
 
+	// ErrPlayerNotFound indicates that the player being kicked via Client.Kick
+	// is not connected to the Rust server.
+	ErrPlayerNotFound = errors.New("player not found")
+
+	// ErrBanExists indicates that the ban being created via Client.Ban already
+	// exists.
+	ErrBanExists = errors.New("ban already exists")
+
+	// ErrPluginNotLoaded indicates that the Oxide plugin being reloaded via
+	// Client.ReloadPlugin is not installed, or the plugin being unloaded via
+	// Client.UnloadPlugin is not loaded.
+	ErrPluginNotLoaded = errors.New("plugin not loaded")
+
+	// ErrPermissionNotGranted indicates that the permission being revoked via
+	// Client.RevokePermission has not been granted to the specified user.
+	ErrPermissionNotGranted = errors.New("permission has not been granted")
+
+	// ErrPermissionDNE indicates that the permission specified is not
+	// registered by any loaded Oxide plugin.
+	ErrPermissionDNE = errors.New("permission does not exist")
+
+	// ErrGroupExists indicates that the Oxide group being created via
+	// Client.CreateGroup already exists.
+	ErrGroupExists = errors.New("group already exists")
+
+	// ErrGroupDNE indicates that the Oxide group specified does not exist.
+	ErrGroupDNE = errors.New("group does not exist")
+
+	// ErrNotInGroup indicates that the steam ID being removed from an Oxide
+	// group via Client.RemoveFromGroup is not a member of the group.
+	ErrNotInGroup = errors.New("not in group")
+
+	// ErrBanDNE indicates that the ban being removed via Client.Unban does not
+	// exist.
+	ErrBanDNE = errors.New("ban does not exist")
+
 	// errRconClientUnexpectedClose may be returned when a process is interrupted
 	// due to an unexpected Client.Close().
 	errRconClientUnexpectedClose = errors.New("RCON client closing")
@@ -108,7 +145,7 @@ type Client struct {
 // Close closes the RCON client, releasing its resources.
 func (c *Client) Close() {
 	c.closeOnce.Do(func() {
-		close(c.router.Outboundc())
+		c.router.Close()
 		<-c.closed
 		c.conn.Close()
 	})
@@ -192,6 +229,67 @@ func (c Client) Quit(ctx context.Context) error {
 	}
 }
 
+// Save instructs the Rust server to save the world and waits for the save to
+// be confirmed. Rust logs save progress as console output, which may arrive
+// as the command's response or as unsolicited console messages, so both are
+// watched. Large worlds may take some time to save, callers should bound ctx
+// accordingly.
+func (c Client) Save(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	consolec, err := c.Subscribe(ctx, FilterKinds(InboundKindConsole))
+	if err != nil {
+		return fmt.Errorf("error subscribing to save output; %w", err)
+	}
+
+	out := NewOutbound("server.save")
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error requesting save; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	for {
+		var in Inbound
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for save confirmation; %w", ctx.Err())
+		case <-c.closed:
+			return errRconClientUnexpectedClose
+		case in = <-inboundc:
+		case in = <-consolec:
+		}
+
+		// Save output is interleaved with other console output, messages
+		// that are not save confirmations are skipped.
+		if _, err := saveResponses.classify(in.Message); err != nil {
+			continue
+		}
+		return nil
+	}
+}
+
+// WriteCfg instructs the Rust server to write its configuration, e.g. the
+// users.cfg and bans.cfg, to disk.
+func (c Client) WriteCfg(ctx context.Context) error {
+	out := NewOutbound("server.writecfg")
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error requesting writecfg; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(writeCfgResponses, in)
+}
+
 // AddModerator adds the moderator specified by the id to the Rust server.
 func (c Client) AddModerator(ctx context.Context, id string) error {
 	out := NewOutbound(fmt.Sprintf("global.moderatorid \"%s\"", id))
@@ -209,14 +307,7 @@ func (c Client) AddModerator(ctx context.Context, id string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-	if in.Message == fmt.Sprintf("User %s is already a Moderator", id) {
-		return ErrModeratorExists
-	}
-	if in.Message != fmt.Sprintf("Added moderator unnamed, steamid %s", id) {
-		return errUnexpectedInboundMessage
-	}
-
-	return nil
+	return c.outcome(addModeratorResponses, in, want("steamid", id))
 }
 
 // RemoveModerator removes the moderator specified by the id from the Rust
@@ -236,13 +327,7 @@ func (c Client) RemoveModerator(ctx context.Context, id string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-	if in.Message == fmt.Sprintf("User %s isn't a moderator", id) {
-		return ErrModeratorDNE
-	}
-	if in.Message != fmt.Sprintf("Removed Moderator: %s", id) {
-		return errUnexpectedInboundMessage
-	}
-	return nil
+	return c.outcome(removeModeratorResponses, in, want("steamid", id))
 }
 
 // AddOwner adds the owner specified by the id to the Rust server.
@@ -262,14 +347,7 @@ func (c Client) AddOwner(ctx context.Context, id string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-	if in.Message == fmt.Sprintf("User %s is already a Owner", id) {
-		return ErrOwnerExists
-	}
-	if in.Message != fmt.Sprintf("Added owner unnamed, steamid %s", id) {
-		return errUnexpectedInboundMessage
-	}
-
-	return nil
+	return c.outcome(addOwnerResponses, in, want("steamid", id))
 }
 
 // RemoveOwner removes the owner specified by the id from the Rust
@@ -289,13 +367,7 @@ func (c Client) RemoveOwner(ctx context.Context, id string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-	if in.Message == fmt.Sprintf("User %s isn't a owner", id) {
-		return ErrOwnerDNE
-	}
-	if in.Message != fmt.Sprintf("Removed Owner: %s", id) {
-		return errUnexpectedInboundMessage
-	}
-	return nil
+	return c.outcome(removeOwnerResponses, in, want("steamid", id))
 }
 
 // GrantPermission grants the passed permission to the specified steam ID.
@@ -322,23 +394,45 @@ func (c Client) GrantPermission(
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-	if in.Message == fmt.Sprintf(
-		"Player '%s' already has permission '%s'",
-		steamID,
-		permission,
-	) {
-		return ErrPermissionAlreadyGranted
-	}
-	if in.Message != fmt.Sprintf(
-		"Player '%s (%s)' granted permission '%s'",
-		steamID,
-		steamID,
-		permission,
-	) {
-		return fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, in.Message)
+	return c.outcome(
+		grantPermissionResponses,
+		in,
+		want("steamid", steamID),
+		want("permission", permission),
+	)
+}
+
+// GrantGroupPermission grants the passed permission to the specified Oxide
+// group.
+func (c Client) GrantGroupPermission(
+	ctx context.Context,
+	group, permission string,
+) error {
+	out := NewOutbound(fmt.Sprintf("oxide.grant group %s %s", group, permission))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf(
+			"error granting permission \"%s\" to group %s; %w",
+			permission,
+			group,
+			err,
+		)
 	}
+	defer c.router.CloseRoute(out.Identifier)
 
-	return nil
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(
+		grantGroupPermissionResponses,
+		in,
+		want("group", group),
+		want("permission", permission),
+	)
 }
 
 // RevokePermission revokes the passed permission from the specified steam ID.
@@ -365,16 +459,12 @@ func (c Client) RevokePermission(
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-
-	if in.Message != fmt.Sprintf(
-		"Player '%s (%s)' revoked permission '%s'",
-		steamID,
-		steamID,
-		permission,
-	) {
-		return fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, in.Message)
-	}
-	return nil
+	return c.outcome(
+		revokePermissionResponses,
+		in,
+		want("steamid", steamID),
+		want("permission", permission),
+	)
 }
 
 // CreateGroup creates the passed Oxide group.
@@ -397,11 +487,7 @@ func (c Client) CreateGroup(ctx context.Context, group string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
-
-	if in.Message != fmt.Sprintf("Group '%s' created", group) {
-		return fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, in.Message)
-	}
-	return nil
+	return c.outcome(createGroupResponses, in, want("group", group))
 }
 
 // AddToGroup adds the passed steamID to the passed Oxide group.
@@ -425,16 +511,203 @@ func (c Client) AddToGroup(ctx context.Context, steamID, group string) error {
 	if err := checkInbound(in, out.Identifier); err != nil {
 		return err
 	}
+	return c.outcome(
+		addToGroupResponses,
+		in,
+		want("steamid", steamID),
+		want("group", group),
+	)
+}
+
+// RemoveFromGroup removes the passed steamID from the passed Oxide group.
+func (c Client) RemoveFromGroup(ctx context.Context, steamID, group string) error {
+	out := NewOutbound(fmt.Sprintf("oxide.usergroup remove %s %s", steamID, group))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf(
+			"error removing %s from group \"%s\"; %w",
+			steamID,
+			group,
+			err,
+		)
+	}
+	defer c.router.CloseRoute(out.Identifier)
 
-	if in.Message != fmt.Sprintf(
-		"Player '%s' added to group: %s",
-		steamID,
-		group,
-	) {
-		return fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, in.Message)
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
 	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(
+		removeFromGroupResponses,
+		in,
+		want("steamid", steamID),
+		want("group", group),
+	)
+}
 
-	return nil
+// Player is a player connected to the Rust server, as reported by
+// global.playerlist.
+type Player struct {
+	SteamID          string
+	OwnerSteamID     string
+	DisplayName      string
+	Ping             int
+	Address          string
+	ConnectedSeconds int
+	VoiationLevel    float32
+	CurrentLevel     float32
+	UnspentXp        float32
+	Health           float32
+}
+
+// PlayerList requests the players connected to the Rust server.
+func (c Client) PlayerList(ctx context.Context) ([]Player, error) {
+	out := NewOutbound("global.playerlist")
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting playerlist; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return nil, err
+	}
+
+	var players []Player
+	if err := json.Unmarshal([]byte(in.Message), &players); err != nil {
+		return nil, fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, in.Message)
+	}
+	return players, nil
+}
+
+// Kick kicks the player specified by the steamID from the Rust server. The
+// reason is displayed to the player.
+func (c Client) Kick(ctx context.Context, steamID, reason string) error {
+	out := NewOutbound(fmt.Sprintf("global.kick \"%s\" \"%s\"", steamID, quote(reason)))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error writing kick; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(kickResponses, in)
+}
+
+// Ban bans the player specified by the steamID from the Rust server. The
+// player need not be connected. If the player is connected they are kicked.
+func (c Client) Ban(ctx context.Context, steamID, reason string) error {
+	out := NewOutbound(fmt.Sprintf("global.banid \"%s\" \"unnamed\" \"%s\"", steamID, quote(reason)))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error writing ban; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(banResponses, in, want("steamid", steamID))
+}
+
+// Unban removes the ban of the player specified by the steamID from the Rust
+// server.
+func (c Client) Unban(ctx context.Context, steamID string) error {
+	out := NewOutbound(fmt.Sprintf("global.unban \"%s\"", steamID))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error writing unban; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(unbanResponses, in, want("steamid", steamID))
+}
+
+// ReloadPlugin reloads the Oxide plugin specified by name. If the plugin is
+// installed but not loaded, it is loaded. Oxide compiles plugins
+// asynchronously, so a nil error indicates the reload has been initiated, not
+// that the plugin has been loaded.
+func (c Client) ReloadPlugin(ctx context.Context, name string) error {
+	out := NewOutbound(fmt.Sprintf("oxide.reload %s", name))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error writing reload plugin \"%s\"; %w", name, err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(reloadPluginResponses, in, want("name", name))
+}
+
+// UnloadPlugin unloads the Oxide plugin specified by name.
+func (c Client) UnloadPlugin(ctx context.Context, name string) error {
+	out := NewOutbound(fmt.Sprintf("oxide.unload %s", name))
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return fmt.Errorf("error writing unload plugin \"%s\"; %w", name, err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if err := checkInbound(in, out.Identifier); err != nil {
+		return err
+	}
+	return c.outcome(unloadPluginResponses, in, want("name", name))
+}
+
+// Exec executes the command on the Rust server and returns the server's
+// response. Unlike the Client's other methods, the response is not inspected,
+// it is up to the caller to interpret it.
+func (c Client) Exec(ctx context.Context, command string) (*Inbound, error) {
+	out := NewOutbound(command)
+	inboundc, err := c.router.Request(ctx, *out)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting exec; %w", err)
+	}
+	defer c.router.CloseRoute(out.Identifier)
+
+	in, err := c.waitForInbound(ctx, inboundc)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for inbound; %w", err)
+	}
+	if in.Identifier != out.Identifier {
+		return nil, errIdentifiersNotEqual
+	}
+
+	return in, nil
 }
 
 // NewOutbound is a constructor for the Outbound type. Typically used to
@@ -482,19 +755,18 @@ func (c Client) writePump() error {
 	t := time.NewTicker(pingPeriod)
 	defer func() {
 		t.Stop()
-		c.Close()
+		// Closing c.closed and the underlying connection ensures readPump exits
+		// and that any Client.Close calls waiting on c.closed return, even if
+		// writePump is exiting due to a write error.
+		close(c.closed)
+		c.conn.Close()
 	}()
 	for {
 		select {
-		case out, ok := <-c.router.Outboundc():
-			if !ok {
-				if err := c.write(websocket.CloseMessage, []byte{}); err != nil {
-					return err
-				}
-				close(c.closed)
-				return nil
-			}
+		case <-c.router.Done():
+			return c.write(websocket.CloseMessage, []byte{})
 
+		case out := <-c.router.Outboundc():
 			b, err := json.Marshal(out)
 			if err != nil {
 				return err
@@ -550,6 +822,7 @@ func (c Client) readPump() error {
 		var inbound Inbound
 		if err := json.Unmarshal(b, &inbound); err != nil {
 			c.logger.Error("unable to unmarshal inbound websocket message", zap.Error(err))
+			continue
 		}
 
 		err = c.router.Injest(c.closed, inbound)
@@ -577,6 +850,11 @@ func (c Client) waitForInbound(ctx context.Context, inboundc chan Inbound) (*Inb
 
 // --- helpers ---
 
+// quote escapes s so that it may be used as a quoted console command argument.
+func quote(s string) string {
+	return strings.ReplaceAll(s, "\"", "'")
+}
+
 func checkInbound(in *Inbound, expid int) error {
 	if in.Identifier != expid {
 		return errIdentifiersNotEqual
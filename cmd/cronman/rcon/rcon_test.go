@@ -0,0 +1,647 @@
+package rcon_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
+	"github.com/tjper/rustcron/cmd/cronman/rcon/rcontest"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	steamID      = "76561197962911631"
+	otherSteamID = "76561197962911632"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		err      error
+		commands []string
+	}
+	tests := map[string]struct {
+		options []rcontest.Option
+		call    func(context.Context, *rcon.Client) error
+		exp     expected
+	}{
+		"server info": {
+			options: []rcontest.Option{
+				rcontest.WithServerInfo(rcon.ServerInfo{Hostname: "Rustpm Test Server", Players: 42}),
+			},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				info, err := client.ServerInfo(ctx)
+				if err != nil {
+					return err
+				}
+				if info.Hostname != "Rustpm Test Server" || info.Players != 42 {
+					return errors.New("unexpected server info")
+				}
+				return nil
+			},
+			exp: expected{commands: []string{"global.serverinfo"}},
+		},
+		"add moderator": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.AddModerator(ctx, steamID)
+			},
+			exp: expected{commands: []string{`global.moderatorid "76561197962911631"`}},
+		},
+		"add connected moderator": {
+			options: []rcontest.Option{
+				rcontest.WithPlayers(rcon.Player{SteamID: steamID, DisplayName: "tjper"}),
+			},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.AddModerator(ctx, steamID)
+			},
+			exp: expected{commands: []string{`global.moderatorid "76561197962911631"`}},
+		},
+		"add existing moderator": {
+			options: []rcontest.Option{rcontest.WithModerators(steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.AddModerator(ctx, steamID)
+			},
+			exp: expected{
+				err:      rcon.ErrModeratorExists,
+				commands: []string{`global.moderatorid "76561197962911631"`},
+			},
+		},
+		"remove moderator": {
+			options: []rcontest.Option{rcontest.WithModerators(steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.RemoveModerator(ctx, steamID)
+			},
+			exp: expected{commands: []string{`global.removemoderator "76561197962911631"`}},
+		},
+		"remove non-existent moderator": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.RemoveModerator(ctx, steamID)
+			},
+			exp: expected{
+				err:      rcon.ErrModeratorDNE,
+				commands: []string{`global.removemoderator "76561197962911631"`},
+			},
+		},
+		"add owner": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.AddOwner(ctx, steamID)
+			},
+			exp: expected{commands: []string{`global.ownerid "76561197962911631"`}},
+		},
+		"add existing owner": {
+			options: []rcontest.Option{rcontest.WithOwners(steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.AddOwner(ctx, steamID)
+			},
+			exp: expected{
+				err:      rcon.ErrOwnerExists,
+				commands: []string{`global.ownerid "76561197962911631"`},
+			},
+		},
+		"grant permission": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.GrantPermission(ctx, steamID, rcon.BypassQueueAllow)
+			},
+			exp: expected{commands: []string{"oxide.grant user 76561197962911631 bypassqueue.allow"}},
+		},
+		"grant permission twice": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				if err := client.GrantPermission(ctx, steamID, rcon.BypassQueueAllow); err != nil {
+					return err
+				}
+				return client.GrantPermission(ctx, steamID, rcon.BypassQueueAllow)
+			},
+			exp: expected{
+				err: rcon.ErrPermissionAlreadyGranted,
+				commands: []string{
+					"oxide.grant user 76561197962911631 bypassqueue.allow",
+					"oxide.grant user 76561197962911631 bypassqueue.allow",
+				},
+			},
+		},
+		"grant group permission": {
+			options: []rcontest.Option{rcontest.WithGroup(rcon.VipGroup)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.GrantGroupPermission(ctx, rcon.VipGroup, rcon.BypassQueueAllow)
+			},
+			exp: expected{commands: []string{"oxide.grant group vip bypassqueue.allow"}},
+		},
+		"grant group permission twice": {
+			options: []rcontest.Option{rcontest.WithGroup(rcon.VipGroup)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				if err := client.GrantGroupPermission(ctx, rcon.VipGroup, rcon.BypassQueueAllow); err != nil {
+					return err
+				}
+				return client.GrantGroupPermission(ctx, rcon.VipGroup, rcon.BypassQueueAllow)
+			},
+			exp: expected{
+				err: rcon.ErrPermissionAlreadyGranted,
+				commands: []string{
+					"oxide.grant group vip bypassqueue.allow",
+					"oxide.grant group vip bypassqueue.allow",
+				},
+			},
+		},
+		"grant group permission group DNE": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.GrantGroupPermission(ctx, rcon.VipGroup, rcon.BypassQueueAllow)
+			},
+			exp: expected{
+				err:      rcon.ErrGroupDNE,
+				commands: []string{"oxide.grant group vip bypassqueue.allow"},
+			},
+		},
+		"add to group": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				if err := client.CreateGroup(ctx, rcon.VipGroup); err != nil {
+					return err
+				}
+				return client.AddToGroup(ctx, steamID, rcon.VipGroup)
+			},
+			exp: expected{
+				commands: []string{
+					"oxide.group add vip",
+					"oxide.usergroup add 76561197962911631 vip",
+				},
+			},
+		},
+		"remove from group": {
+			options: []rcontest.Option{rcontest.WithGroup(rcon.VipGroup, steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.RemoveFromGroup(ctx, steamID, rcon.VipGroup)
+			},
+			exp: expected{commands: []string{"oxide.usergroup remove 76561197962911631 vip"}},
+		},
+		"remove from group twice": {
+			options: []rcontest.Option{rcontest.WithGroup(rcon.VipGroup, steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				if err := client.RemoveFromGroup(ctx, steamID, rcon.VipGroup); err != nil {
+					return err
+				}
+				return client.RemoveFromGroup(ctx, steamID, rcon.VipGroup)
+			},
+			exp: expected{
+				err: rcon.ErrNotInGroup,
+				commands: []string{
+					"oxide.usergroup remove 76561197962911631 vip",
+					"oxide.usergroup remove 76561197962911631 vip",
+				},
+			},
+		},
+		"say": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				chatc, err := client.Subscribe(ctx, rcon.FilterKinds(rcon.InboundKindChat))
+				if err != nil {
+					return err
+				}
+				if err := client.Say(ctx, "hello rust world"); err != nil {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case in := <-chatc:
+					chat, err := in.Chat()
+					if err != nil {
+						return err
+					}
+					if chat.Message != "hello rust world" {
+						return errors.New("unexpected chat message")
+					}
+				}
+				return nil
+			},
+			exp: expected{commands: []string{"say hello rust world"}},
+		},
+		"save": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Save(ctx)
+			},
+			exp: expected{commands: []string{"server.save"}},
+		},
+		"writecfg": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.WriteCfg(ctx)
+			},
+			exp: expected{commands: []string{"server.writecfg"}},
+		},
+		"quit": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Quit(ctx)
+			},
+			exp: expected{commands: []string{"global.quit"}},
+		},
+		"player list": {
+			options: []rcontest.Option{
+				rcontest.WithPlayers(rcon.Player{SteamID: steamID, DisplayName: "tjper", Ping: 42}),
+			},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				players, err := client.PlayerList(ctx)
+				if err != nil {
+					return err
+				}
+				if len(players) != 1 || players[0].SteamID != steamID || players[0].Ping != 42 {
+					return errors.New("unexpected player list")
+				}
+				return nil
+			},
+			exp: expected{commands: []string{"global.playerlist"}},
+		},
+		"kick": {
+			options: []rcontest.Option{
+				rcontest.WithPlayers(rcon.Player{SteamID: steamID, DisplayName: "tjper"}),
+			},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Kick(ctx, steamID, "being \"rude\"")
+			},
+			exp: expected{commands: []string{`global.kick "76561197962911631" "being 'rude'"`}},
+		},
+		"kick disconnected player": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Kick(ctx, steamID, "being rude")
+			},
+			exp: expected{
+				err:      rcon.ErrPlayerNotFound,
+				commands: []string{`global.kick "76561197962911631" "being rude"`},
+			},
+		},
+		"ban": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Ban(ctx, steamID, "cheating")
+			},
+			exp: expected{commands: []string{`global.banid "76561197962911631" "unnamed" "cheating"`}},
+		},
+		"ban banned player": {
+			options: []rcontest.Option{rcontest.WithBans(steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Ban(ctx, steamID, "cheating")
+			},
+			exp: expected{
+				err:      rcon.ErrBanExists,
+				commands: []string{`global.banid "76561197962911631" "unnamed" "cheating"`},
+			},
+		},
+		"unban": {
+			options: []rcontest.Option{rcontest.WithBans(steamID)},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Unban(ctx, steamID)
+			},
+			exp: expected{commands: []string{`global.unban "76561197962911631"`}},
+		},
+		"unban player not banned": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.Unban(ctx, steamID)
+			},
+			exp: expected{
+				err:      rcon.ErrBanDNE,
+				commands: []string{`global.unban "76561197962911631"`},
+			},
+		},
+		"reload plugin": {
+			options: []rcontest.Option{rcontest.WithPlugins("Vanish")},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.ReloadPlugin(ctx, "Vanish")
+			},
+			exp: expected{commands: []string{"oxide.reload Vanish"}},
+		},
+		"reload plugin not installed": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.ReloadPlugin(ctx, "Vanish")
+			},
+			exp: expected{
+				err:      rcon.ErrPluginNotLoaded,
+				commands: []string{"oxide.reload Vanish"},
+			},
+		},
+		"unload plugin": {
+			options: []rcontest.Option{rcontest.WithPlugins("Vanish")},
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.UnloadPlugin(ctx, "Vanish")
+			},
+			exp: expected{commands: []string{"oxide.unload Vanish"}},
+		},
+		"unload plugin not loaded": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				return client.UnloadPlugin(ctx, "Vanish")
+			},
+			exp: expected{
+				err:      rcon.ErrPluginNotLoaded,
+				commands: []string{"oxide.unload Vanish"},
+			},
+		},
+		"exec": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				in, err := client.Exec(ctx, "global.serverinfo")
+				if err != nil {
+					return err
+				}
+				if in.Type != "Generic" {
+					return errors.New("unexpected exec response type")
+				}
+				return nil
+			},
+			exp: expected{commands: []string{"global.serverinfo"}},
+		},
+		"exec unknown command": {
+			call: func(ctx context.Context, client *rcon.Client) error {
+				in, err := client.Exec(ctx, "global.unknown")
+				if err != nil {
+					return err
+				}
+				if in.Type != "Warning" || in.Message != "Command 'global.unknown' not found" {
+					return errors.New("unexpected exec response")
+				}
+				return nil
+			},
+			exp: expected{commands: []string{"global.unknown"}},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			server := rcontest.NewServer(test.options...)
+			defer server.Close()
+
+			client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+			require.Nil(t, err)
+			defer client.Close()
+
+			err = test.call(ctx, client)
+			require.ErrorIs(t, err, test.exp.err)
+			require.Equal(t, test.exp.commands, server.Commands())
+		})
+	}
+}
+
+func TestClientScripted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unexpected response", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("global.moderatorid", rcontest.Response{Message: "Invalid SteamID"})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		err = client.AddModerator(ctx, steamID)
+		require.NotNil(t, err)
+
+		// Scripted responses are exhausted, the default response is used.
+		err = client.AddModerator(ctx, otherSteamID)
+		require.Nil(t, err)
+	})
+	t.Run("error type", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("oxide.usergroup", rcontest.Response{Message: "Oxide is not loaded", Type: "Error"})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		err = client.AddToGroup(ctx, steamID, rcon.VipGroup)
+		require.NotNil(t, err)
+	})
+	t.Run("silent", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("global.serverinfo", rcontest.Response{Silent: true})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		callCtx, callCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer callCancel()
+
+		_, err = client.ServerInfo(callCtx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("disconnect", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("global.ownerid", rcontest.Response{Disconnect: true})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		err = client.AddOwner(ctx, steamID)
+		require.NotNil(t, err)
+		require.NotErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("save confirmed by response", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("server.save", rcontest.Response{Message: "[Save] Saving complete"})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		err = client.Save(ctx)
+		require.Nil(t, err)
+	})
+	t.Run("save unconfirmed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer()
+		defer server.Close()
+		server.Script("server.save", rcontest.Response{Message: "Saving..."})
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		callCtx, callCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer callCancel()
+
+		err = client.Save(callCtx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := rcontest.NewServer(rcontest.WithLatency(200 * time.Millisecond))
+		defer server.Close()
+
+		client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+		require.Nil(t, err)
+		defer client.Close()
+
+		callCtx, callCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer callCancel()
+
+		_, err = client.ServerInfo(callCtx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		server.SetLatency(0)
+		_, err = client.ServerInfo(ctx)
+		require.Nil(t, err)
+	})
+}
+
+func TestDialPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := rcontest.NewServer(rcontest.WithPassword("secret"))
+	defer server.Close()
+
+	_, err := rcon.Dial(ctx, zap.NewNop(), "ws://"+server.Addr()+"/wrong")
+	require.NotNil(t, err)
+
+	client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+	require.Nil(t, err)
+	client.Close()
+}
+
+func TestHubReconnectRcontest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := rcontest.NewServer()
+	defer server.Close()
+
+	hub := rcon.NewHub(zap.NewNop(), rcon.WithReconnectBackoff(10*time.Millisecond, 100*time.Millisecond))
+	defer hub.Close()
+
+	client, err := hub.Dial(ctx, server.Addr(), server.Password())
+	require.Nil(t, err)
+	defer client.Close()
+
+	server.DropConnections()
+
+	require.Eventually(t, func() bool {
+		_, err := client.ServerInfo(ctx)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, server.Connections())
+}
+
+func TestWaiterUntilReady(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := rcontest.NewServer()
+	defer server.Close()
+
+	// The server is still loading for the first two ready checks.
+	server.Script(
+		"global.serverinfo",
+		rcontest.Response{Message: "Server is loading"},
+		rcontest.Response{Message: "Server is loading"},
+	)
+
+	waiter := rcon.NewWaiter(zap.NewNop(), 10*time.Millisecond)
+	err := waiter.UntilReady(ctx, server.URL())
+	require.Nil(t, err)
+
+	require.Equal(
+		t,
+		[]string{"global.serverinfo", "global.serverinfo", "global.serverinfo"},
+		server.Commands(),
+	)
+}
+
+func TestReloadInstalledPlugin(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := rcontest.NewServer()
+	defer server.Close()
+
+	client, err := rcon.Dial(ctx, zap.NewNop(), server.URL())
+	require.Nil(t, err)
+	defer client.Close()
+
+	server.InstallPlugin("AdminRadar")
+	require.False(t, server.PluginLoaded("AdminRadar"))
+
+	err = client.ReloadPlugin(ctx, "AdminRadar")
+	require.Nil(t, err)
+	require.True(t, server.PluginLoaded("AdminRadar"))
+
+	err = client.UnloadPlugin(ctx, "AdminRadar")
+	require.Nil(t, err)
+	require.False(t, server.PluginLoaded("AdminRadar"))
+}
+
+func TestSave(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := rcontest.NewServer()
+	defer server.Close()
+
+	hub := rcon.NewHub(zap.NewNop())
+	defer hub.Close()
+
+	client, err := hub.Dial(ctx, server.Addr(), server.Password())
+	require.Nil(t, err)
+	defer client.Close()
+
+	for i := 1; i <= 3; i++ {
+		err = client.Save(ctx)
+		require.Nil(t, err)
+		require.Equal(t, i, server.Saves())
+	}
+
+	err = client.WriteCfg(ctx)
+	require.Nil(t, err)
+}
@@ -0,0 +1,691 @@
+// Package rcontest provides an in-process Rust WebRcon server for testing.
+// Similar to net/http/httptest, a Server listens on a local port and may be
+// dialed by rcon.Client, rcon.Hub, and rcon.Waiter as if it were a Rust game
+// server.
+package rcontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultPassword is the RCON password a Server accepts if not configured
+	// with WithPassword.
+	DefaultPassword = "rcontest"
+
+	// DefaultHostname is the hostname a Server reports via global.serverinfo if
+	// not configured with WithServerInfo.
+	DefaultHostname = "rcontest"
+)
+
+// Option mutates a Server instance. Typically used with NewServer to
+// configure a Server instance.
+type Option func(*Server)
+
+// WithPassword is an Option that configures the RCON password the Server
+// accepts.
+func WithPassword(password string) Option {
+	return func(s *Server) {
+		s.password = password
+	}
+}
+
+// WithServerInfo is an Option that configures the Server's response to
+// global.serverinfo.
+func WithServerInfo(info rcon.ServerInfo) Option {
+	return func(s *Server) {
+		s.serverInfo = info
+	}
+}
+
+// WithLatency is an Option that configures the period of time the Server
+// waits before responding to each command.
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+// WithModerators is an Option that configures the steam IDs that are
+// moderators when the Server starts.
+func WithModerators(steamIDs ...string) Option {
+	return func(s *Server) {
+		for _, id := range steamIDs {
+			s.moderators[id] = struct{}{}
+		}
+	}
+}
+
+// WithOwners is an Option that configures the steam IDs that are owners when
+// the Server starts.
+func WithOwners(steamIDs ...string) Option {
+	return func(s *Server) {
+		for _, id := range steamIDs {
+			s.owners[id] = struct{}{}
+		}
+	}
+}
+
+// WithPlayers is an Option that configures the players that are connected
+// when the Server starts.
+func WithPlayers(players ...rcon.Player) Option {
+	return func(s *Server) {
+		s.players = append(s.players, players...)
+	}
+}
+
+// WithBans is an Option that configures the steam IDs that are banned when
+// the Server starts.
+func WithBans(steamIDs ...string) Option {
+	return func(s *Server) {
+		for _, id := range steamIDs {
+			s.bans[id] = struct{}{}
+		}
+	}
+}
+
+// WithPlugins is an Option that configures the Oxide plugins that are
+// installed and loaded when the Server starts.
+func WithPlugins(names ...string) Option {
+	return func(s *Server) {
+		for _, name := range names {
+			s.plugins[name] = true
+		}
+	}
+}
+
+// WithGroup is an Option that configures an Oxide group, and the steam IDs
+// that are members of the group, when the Server starts.
+func WithGroup(group string, steamIDs ...string) Option {
+	return func(s *Server) {
+		members := make(map[string]struct{}, len(steamIDs))
+		for _, id := range steamIDs {
+			members[id] = struct{}{}
+		}
+		s.groups[group] = members
+	}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		password: DefaultPassword,
+		serverInfo: rcon.ServerInfo{
+			Hostname:   DefaultHostname,
+			MaxPlayers: 200,
+			Map:        "Procedural Map",
+			Framerate:  60,
+		},
+		mutex:       new(sync.Mutex),
+		moderators:  make(map[string]struct{}),
+		owners:      make(map[string]struct{}),
+		players:     make([]rcon.Player, 0),
+		bans:        make(map[string]struct{}),
+		plugins:     make(map[string]bool),
+		permissions: make(map[string]map[string]struct{}),
+		groups:      make(map[string]map[string]struct{}),
+		grants:      make(map[string]map[string]struct{}),
+		scripts:     make(map[string][]Response),
+		commands:    make([]string, 0),
+		conns:       make(map[*conn]struct{}),
+		upgrader:    websocket.Upgrader{},
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Server is an in-process Rust WebRcon server. Server maintains moderator,
+// owner, player, ban, plugin, permission, group, and save state so that
+// responses mirror those of a Rust server.
+type Server struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	password   string
+	serverInfo rcon.ServerInfo
+	latency    time.Duration
+
+	mutex       *sync.Mutex
+	moderators  map[string]struct{}
+	owners      map[string]struct{}
+	players     []rcon.Player
+	bans        map[string]struct{}
+	plugins     map[string]bool
+	permissions map[string]map[string]struct{}
+	groups      map[string]map[string]struct{}
+	grants      map[string]map[string]struct{} // permissions by group
+	scripts     map[string][]Response
+	commands    []string
+	saves       int
+	conns       map[*conn]struct{}
+}
+
+// Response is a scripted Server response to a command.
+type Response struct {
+	// Message is the Inbound message the Server responds with.
+	Message string
+	// Type is the Inbound type the Server responds with. If empty, "Generic"
+	// is used.
+	Type string
+	// Silent indicates the Server should not respond to the command.
+	Silent bool
+	// Disconnect indicates the Server should close the connection rather than
+	// respond to the command.
+	Disconnect bool
+}
+
+// Close shuts down the Server and closes all connections.
+func (s *Server) Close() {
+	s.DropConnections()
+	s.server.Close()
+}
+
+// Addr is the Server's address in the form host:port. This is the form
+// expected by rcon.Hub.Dial.
+func (s *Server) Addr() string {
+	return strings.TrimPrefix(s.server.URL, "http://")
+}
+
+// Password is the RCON password the Server accepts.
+func (s *Server) Password() string {
+	return s.password
+}
+
+// URL is the Server's WebRcon URL, including the password. This is the form
+// expected by rcon.Dial and rcon.Waiter.UntilReady.
+func (s *Server) URL() string {
+	return fmt.Sprintf("ws://%s/%s", s.Addr(), s.password)
+}
+
+// SetLatency sets the period of time the Server waits before responding to
+// each command.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latency = latency
+}
+
+// Script queues responses for the specified command. The command is matched
+// against the first word of each command received, e.g. "global.serverinfo"
+// or "oxide.grant". Scripted responses are used in the order they were
+// queued; once exhausted, the Server returns to its default behaviour.
+func (s *Server) Script(command string, responses ...Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.scripts[command] = append(s.scripts[command], responses...)
+}
+
+// Commands retrieves all commands the Server has received, in the order they
+// were received.
+func (s *Server) Commands() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	commands := make([]string, len(s.commands))
+	copy(commands, s.commands)
+	return commands
+}
+
+// Players retrieves the players currently connected to the Server.
+func (s *Server) Players() []rcon.Player {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	players := make([]rcon.Player, len(s.players))
+	copy(players, s.players)
+	return players
+}
+
+// InstallPlugin installs the Oxide plugin specified by name on the Server.
+// The plugin is not loaded until it is reloaded.
+func (s *Server) InstallPlugin(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.plugins[name] = false
+}
+
+// PluginLoaded reports if the Oxide plugin specified by name is loaded.
+func (s *Server) PluginLoaded(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.plugins[name]
+}
+
+// Saves is the number of world saves the Server has completed.
+func (s *Server) Saves() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.saves
+}
+
+// Banned reports if the steam ID is banned from the Server.
+func (s *Server) Banned(steamID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.bans[steamID]
+	return ok
+}
+
+// Send writes the unsolicited Inbound message to all connected clients.
+func (s *Server) Send(in rcon.Inbound) {
+	for _, c := range s.connections() {
+		_ = c.write(in)
+	}
+}
+
+// Chat sends an in-game chat message from the specified player to all
+// connected clients.
+func (s *Server) Chat(steamID, username, message string) {
+	s.Send(chatInbound(steamID, username, message))
+}
+
+// DropConnections closes all client connections, similar to a network
+// interruption or server crash.
+func (s *Server) DropConnections() {
+	for _, c := range s.connections() {
+		c.close()
+	}
+}
+
+// Connections is the number of clients currently connected.
+func (s *Server) Connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.conns)
+}
+
+// --- private ---
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.TrimPrefix(r.URL.Path, "/") != s.password {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	wsconn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &conn{ws: wsconn, mutex: new(sync.Mutex), closeOnce: new(sync.Once)}
+	s.mutex.Lock()
+	s.conns[c] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, c)
+		s.mutex.Unlock()
+		c.close()
+	}()
+
+	for {
+		var out rcon.Outbound
+		if err := wsconn.ReadJSON(&out); err != nil {
+			return
+		}
+		if !s.handle(c, out) {
+			return
+		}
+	}
+}
+
+// handle processes the Outbound command, responding on the connection. If
+// the connection should be closed, false is returned.
+func (s *Server) handle(c *conn, out rcon.Outbound) bool {
+	fields := strings.Fields(out.Message)
+	if len(fields) == 0 {
+		return true
+	}
+	name := fields[0]
+	args := unquote(fields[1:])
+
+	s.mutex.Lock()
+	s.commands = append(s.commands, out.Message)
+	latency := s.latency
+	script, scripted := s.nextScript(name)
+	s.mutex.Unlock()
+
+	time.Sleep(latency)
+
+	if scripted {
+		switch {
+		case script.Disconnect:
+			return false
+		case script.Silent:
+			return true
+		}
+		typ := script.Type
+		if typ == "" {
+			typ = "Generic"
+		}
+		return c.write(rcon.Inbound{
+			Identifier: out.Identifier,
+			Message:    script.Message,
+			Type:       typ,
+		}) == nil
+	}
+
+	switch name {
+	case "global.quit", "quit":
+		// The Rust server closes the RCON connection as it shuts down.
+		return false
+	case "say", "global.say":
+		s.Send(chatInbound("0", "SERVER", strings.Join(fields[1:], " ")))
+		return true
+	case "server.save", "save":
+		// Like a Rust server, save progress is logged as unsolicited console
+		// output rather than the command's response.
+		s.mutex.Lock()
+		s.saves++
+		ents := len(s.players)*100 + 1000
+		s.mutex.Unlock()
+
+		if err := c.write(rcon.Inbound{
+			Identifier: out.Identifier,
+			Message:    "Saving...",
+			Type:       "Generic",
+		}); err != nil {
+			return false
+		}
+		s.Send(rcon.Inbound{
+			Message: fmt.Sprintf("Saved %d ents, cache(0.01), write(0.00), disk(0.00).", ents),
+			Type:    "Generic",
+		})
+		s.Send(rcon.Inbound{Message: "Saving complete", Type: "Generic"})
+		return true
+	}
+
+	message, typ := s.respond(name, args)
+	return c.write(rcon.Inbound{
+		Identifier: out.Identifier,
+		Message:    message,
+		Type:       typ,
+	}) == nil
+}
+
+// respond updates the Server's state based on the command and builds the
+// response a Rust server would return.
+func (s *Server) respond(name string, args []string) (string, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	switch name {
+	case "global.serverinfo", "serverinfo":
+		b, err := json.Marshal(s.serverInfo)
+		if err != nil {
+			return err.Error(), "Error"
+		}
+		return string(b), "Generic"
+
+	case "global.moderatorid", "moderatorid":
+		id := arg(0)
+		if _, ok := s.moderators[id]; ok {
+			return fmt.Sprintf("User %s is already a Moderator", id), "Generic"
+		}
+		s.moderators[id] = struct{}{}
+		return fmt.Sprintf("Added moderator %s, steamid %s", s.name(id), id), "Generic"
+
+	case "global.removemoderator", "removemoderator":
+		id := arg(0)
+		if _, ok := s.moderators[id]; !ok {
+			return fmt.Sprintf("User %s isn't a moderator", id), "Generic"
+		}
+		delete(s.moderators, id)
+		return fmt.Sprintf("Removed Moderator: %s", id), "Generic"
+
+	case "global.ownerid", "ownerid":
+		id := arg(0)
+		if _, ok := s.owners[id]; ok {
+			return fmt.Sprintf("User %s is already a Owner", id), "Generic"
+		}
+		s.owners[id] = struct{}{}
+		return fmt.Sprintf("Added owner %s, steamid %s", s.name(id), id), "Generic"
+
+	case "global.removeowner", "removeowner":
+		id := arg(0)
+		if _, ok := s.owners[id]; !ok {
+			return fmt.Sprintf("User %s isn't a owner", id), "Generic"
+		}
+		delete(s.owners, id)
+		return fmt.Sprintf("Removed Owner: %s", id), "Generic"
+
+	case "server.writecfg", "writecfg":
+		return "Config Saved", "Generic"
+
+	case "global.playerlist", "playerlist":
+		b, err := json.Marshal(s.players)
+		if err != nil {
+			return err.Error(), "Error"
+		}
+		return string(b), "Generic"
+
+	case "global.kick", "kick":
+		id := arg(0)
+		player, ok := s.disconnect(id)
+		if !ok {
+			return "Player not found", "Generic"
+		}
+		return fmt.Sprintf("Kicked: %s", player.DisplayName), "Generic"
+
+	case "global.banid", "banid":
+		// global.banid <steam ID> <username> <reason>
+		id, username := arg(0), arg(1)
+		var reason string
+		if len(args) > 2 {
+			reason = strings.Join(args[2:], " ")
+		}
+		if _, ok := s.bans[id]; ok {
+			return fmt.Sprintf("User %s is already banned", id), "Generic"
+		}
+		s.bans[id] = struct{}{}
+		s.disconnect(id)
+		return fmt.Sprintf("Banned User: %s - \"%s\" for \"%s\"", id, username, reason), "Generic"
+
+	case "global.unban", "unban":
+		id := arg(0)
+		if _, ok := s.bans[id]; !ok {
+			return fmt.Sprintf("User %s isn't banned", id), "Generic"
+		}
+		delete(s.bans, id)
+		return fmt.Sprintf("Unbanned User: %s", id), "Generic"
+
+	case "oxide.reload":
+		// oxide.reload <plugin>
+		name := arg(0)
+		if _, ok := s.plugins[name]; !ok {
+			return fmt.Sprintf("Plugin '%s' not loaded.", name), "Generic"
+		}
+		s.plugins[name] = true
+		return fmt.Sprintf("Loaded plugin %s v1.0.0 by rcontest", name), "Generic"
+
+	case "oxide.unload":
+		// oxide.unload <plugin>
+		name := arg(0)
+		if !s.plugins[name] {
+			return fmt.Sprintf("Plugin '%s' not loaded.", name), "Generic"
+		}
+		s.plugins[name] = false
+		return fmt.Sprintf("Unloaded plugin %s v1.0.0 by rcontest", name), "Generic"
+
+	case "oxide.grant":
+		// oxide.grant group <group> <permission>
+		if arg(0) == "group" {
+			group, permission := arg(1), arg(2)
+			if _, ok := s.groups[group]; !ok {
+				return fmt.Sprintf("Group '%s' doesn't exist", group), "Generic"
+			}
+			if _, ok := s.grants[group][permission]; ok {
+				return fmt.Sprintf("Group '%s' already has permission '%s'", group, permission), "Generic"
+			}
+			if _, ok := s.grants[group]; !ok {
+				s.grants[group] = make(map[string]struct{})
+			}
+			s.grants[group][permission] = struct{}{}
+			return fmt.Sprintf("Group '%s' granted permission '%s'", group, permission), "Generic"
+		}
+		// oxide.grant user <steam ID> <permission>
+		id, permission := arg(1), arg(2)
+		if _, ok := s.permissions[id][permission]; ok {
+			return fmt.Sprintf("Player '%s (%s)' already has permission '%s'", s.name(id), id, permission), "Generic"
+		}
+		if _, ok := s.permissions[id]; !ok {
+			s.permissions[id] = make(map[string]struct{})
+		}
+		s.permissions[id][permission] = struct{}{}
+		return fmt.Sprintf("Player '%s (%s)' granted permission '%s'", s.name(id), id, permission), "Generic"
+
+	case "oxide.revoke":
+		// oxide.revoke user <steam ID> <permission>
+		id, permission := arg(1), arg(2)
+		if _, ok := s.permissions[id][permission]; !ok {
+			return fmt.Sprintf("Player '%s (%s)' does not have permission '%s'", s.name(id), id, permission), "Generic"
+		}
+		delete(s.permissions[id], permission)
+		return fmt.Sprintf("Player '%s (%s)' revoked permission '%s'", s.name(id), id, permission), "Generic"
+
+	case "oxide.group":
+		// oxide.group add <group>
+		group := arg(1)
+		if _, ok := s.groups[group]; ok {
+			return fmt.Sprintf("Group '%s' already exists", group), "Generic"
+		}
+		s.groups[group] = make(map[string]struct{})
+		return fmt.Sprintf("Group '%s' created", group), "Generic"
+
+	case "oxide.usergroup":
+		// oxide.usergroup <add|remove> <steam ID> <group>
+		id, group := arg(1), arg(2)
+		members, ok := s.groups[group]
+		if !ok {
+			return fmt.Sprintf("Group '%s' doesn't exist", group), "Generic"
+		}
+		if arg(0) == "remove" {
+			if _, ok := members[id]; !ok {
+				return fmt.Sprintf("Player '%s (%s)' isn't in group '%s'", s.name(id), id, group), "Generic"
+			}
+			delete(members, id)
+			return fmt.Sprintf("Player '%s (%s)' removed from group: %s", s.name(id), id, group), "Generic"
+		}
+		members[id] = struct{}{}
+		return fmt.Sprintf("Player '%s (%s)' added to group: %s", s.name(id), id, group), "Generic"
+	}
+
+	return fmt.Sprintf("Command '%s' not found", name), "Warning"
+}
+
+// disconnect removes the player specified by steamID from the connected
+// players. The caller must hold s.mutex.
+func (s *Server) disconnect(steamID string) (rcon.Player, bool) {
+	for i, player := range s.players {
+		if player.SteamID != steamID {
+			continue
+		}
+		s.players = append(s.players[:i], s.players[i+1:]...)
+		return player, true
+	}
+	return rcon.Player{}, false
+}
+
+// name retrieves the display name of the player specified by steamID. Like
+// a Rust server, players that are not connected are "unnamed". The caller
+// must hold s.mutex.
+func (s *Server) name(steamID string) string {
+	for _, player := range s.players {
+		if player.SteamID == steamID {
+			return player.DisplayName
+		}
+	}
+	return "unnamed"
+}
+
+// nextScript pops the next scripted Response for the command. The caller
+// must hold s.mutex.
+func (s *Server) nextScript(name string) (Response, bool) {
+	responses := s.scripts[name]
+	if len(responses) == 0 {
+		return Response{}, false
+	}
+	s.scripts[name] = responses[1:]
+	return responses[0], true
+}
+
+func (s *Server) connections() []*conn {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+// conn is a client connection to the Server. Writes to a conn are
+// serialized, as gorilla/websocket supports only one concurrent writer.
+type conn struct {
+	ws        *websocket.Conn
+	mutex     *sync.Mutex
+	closeOnce *sync.Once
+}
+
+func (c *conn) write(in rcon.Inbound) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.ws.WriteJSON(in)
+}
+
+func (c *conn) close() {
+	c.closeOnce.Do(func() { c.ws.Close() })
+}
+
+// chatInbound builds the unsolicited Inbound message a Rust server sends for
+// an in-game chat message.
+func chatInbound(steamID, username, message string) rcon.Inbound {
+	b, _ := json.Marshal(rcon.Chat{
+		Channel:  0,
+		Message:  message,
+		UserID:   steamID,
+		Username: username,
+		Color:    "#5af",
+		Time:     time.Now().Unix(),
+	})
+	return rcon.Inbound{
+		Identifier: 0,
+		Message:    string(b),
+		Type:       "Chat",
+	}
+}
+
+// unquote removes surrounding double quotes from each argument.
+func unquote(args []string) []string {
+	unquoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		unquoted = append(unquoted, strings.Trim(arg, "\""))
+	}
+	return unquoted
+}
@@ -0,0 +1,229 @@
+package rcon
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// response is an Inbound message that has been classified by a classifier.
+type response struct {
+	// message is the raw Inbound message.
+	message string
+	// err is the typed error the message maps onto. A nil err indicates the
+	// command was successful.
+	err error
+	// fields are the values captured by the matching pattern, keyed by
+	// capture group name, e.g. "name", "steamid", "permission".
+	fields map[string]string
+}
+
+// expectation is a captured field value a response is expected to agree
+// with.
+type expectation struct {
+	key, value string
+}
+
+// want creates an expectation that the field specified by key, if captured,
+// is equal to value.
+func want(key, value string) expectation {
+	return expectation{key: key, value: value}
+}
+
+// check ensures the response agrees with each expectation. This guards
+// against a response regarding a different subject, e.g. a different steam
+// ID, being mistaken for the outcome of the command.
+func (r response) check(expectations ...expectation) error {
+	for _, exp := range expectations {
+		captured, ok := r.fields[exp.key]
+		if !ok || strings.EqualFold(captured, exp.value) {
+			continue
+		}
+		return fmt.Errorf(
+			"%w: %s \"%s\" does not match \"%s\": \"%s\"",
+			errUnexpectedInboundMessage,
+			exp.key,
+			captured,
+			exp.value,
+			r.message,
+		)
+	}
+	return nil
+}
+
+// matcher maps messages matching pattern onto err.
+type matcher struct {
+	pattern *regexp.Regexp
+	err     error
+}
+
+// match creates a matcher. The expr is compiled case-insensitively and must
+// match the entire message. Named capture groups in expr are captured as
+// response fields.
+func match(expr string, err error) matcher {
+	return matcher{
+		pattern: regexp.MustCompile(`(?is)^\s*` + expr + `\s*$`),
+		err:     err,
+	}
+}
+
+// classifier classifies the responses of a single Rust server command. The
+// matchers are evaluated in order, the first matching matcher is used.
+type classifier []matcher
+
+// classify classifies the message. If no matcher matches the message, an error
+// wrapping errUnexpectedInboundMessage is returned.
+func (c classifier) classify(message string) (*response, error) {
+	for _, m := range c {
+		submatches := m.pattern.FindStringSubmatch(message)
+		if submatches == nil {
+			continue
+		}
+
+		fields := make(map[string]string)
+		for i, name := range m.pattern.SubexpNames() {
+			if name == "" || i >= len(submatches) || submatches[i] == "" {
+				continue
+			}
+			fields[name] = submatches[i]
+		}
+		return &response{message: message, err: m.err, fields: fields}, nil
+	}
+	return nil, fmt.Errorf("%w: \"%s\"", errUnexpectedInboundMessage, message)
+}
+
+// outcome classifies the Inbound message via the classifier and returns the
+// typed error the message maps onto, nil on success. Captured fields are
+// logged for debugging.
+func (c Client) outcome(
+	responses classifier,
+	in *Inbound,
+	expectations ...expectation,
+) error {
+	res, err := responses.classify(in.Message)
+	if err != nil {
+		return err
+	}
+	if err := res.check(expectations...); err != nil {
+		return err
+	}
+
+	c.logger.Debug(
+		"classified rcon response",
+		zap.Int("identifier", in.Identifier),
+		zap.Any("fields", res.fields),
+		zap.NamedError("outcome", res.err),
+	)
+	return res.err
+}
+
+// Common sub-expressions used by the classifiers below. Oxide refers to
+// players as either "<name>", "<steam ID>", or "<name> (<steam ID>)". Oxide
+// refers to plugins by name, e.g. "AdminRadar", when it has failed to find
+// them, and by title, e.g. "Admin Radar", once found.
+const (
+	steamIDExpr = `(?P<steamid>\d+)`
+	playerExpr  = `(?P<name>.*?)(?: \((?P<steamid>\d+)\))?`
+	pluginExpr  = `(?P<title>.+?)(?: v(?P<version>\S+))?(?: by (?P<author>.+))?`
+)
+
+var (
+	addModeratorResponses = classifier{
+		match(`Added moderator (?P<name>.*?),? steamid `+steamIDExpr+`(?:,.*)?`, nil),
+		match(`User `+steamIDExpr+` is already an? Moderator`, ErrModeratorExists),
+	}
+
+	removeModeratorResponses = classifier{
+		match(`Removed Moderator:? `+steamIDExpr+`(?: .*)?`, nil),
+		match(`User `+steamIDExpr+` isn'?t an? moderator`, ErrModeratorDNE),
+	}
+
+	addOwnerResponses = classifier{
+		match(`Added owner (?P<name>.*?),? steamid `+steamIDExpr+`(?:,.*)?`, nil),
+		match(`User `+steamIDExpr+` is already an? Owner`, ErrOwnerExists),
+	}
+
+	removeOwnerResponses = classifier{
+		match(`Removed Owner:? `+steamIDExpr+`(?: .*)?`, nil),
+		match(`User `+steamIDExpr+` isn'?t an? owner`, ErrOwnerDNE),
+	}
+
+	grantPermissionResponses = classifier{
+		match(`Player '`+playerExpr+`' granted permission '(?P<permission>[^']+)'`, nil),
+		match(`Player '`+playerExpr+`' already has permission '(?P<permission>[^']+)'`, ErrPermissionAlreadyGranted),
+		match(`Player '(?P<name>.*?)' not found`, ErrPlayerNotFound),
+		match(`Permission '(?P<permission>[^']+)' doesn'?t exist`, ErrPermissionDNE),
+	}
+
+	grantGroupPermissionResponses = classifier{
+		match(`Group '(?P<group>[^']+)' granted permission '(?P<permission>[^']+)'`, nil),
+		match(`Group '(?P<group>[^']+)' already has permission '(?P<permission>[^']+)'`, ErrPermissionAlreadyGranted),
+		match(`Group '(?P<group>[^']+)' doesn'?t exist`, ErrGroupDNE),
+		match(`Permission '(?P<permission>[^']+)' doesn'?t exist`, ErrPermissionDNE),
+	}
+
+	revokePermissionResponses = classifier{
+		match(`Player '`+playerExpr+`' revoked permission '(?P<permission>[^']+)'`, nil),
+		match(`Player '`+playerExpr+`' does not have permission '(?P<permission>[^']+)'`, ErrPermissionNotGranted),
+		match(`Player '(?P<name>.*?)' not found`, ErrPlayerNotFound),
+		match(`Permission '(?P<permission>[^']+)' doesn'?t exist`, ErrPermissionDNE),
+	}
+
+	createGroupResponses = classifier{
+		match(`Group '(?P<group>[^']+)' created`, nil),
+		match(`Group '(?P<group>[^']+)' already exists`, ErrGroupExists),
+	}
+
+	addToGroupResponses = classifier{
+		match(`Player '`+playerExpr+`' added to group:? '?(?P<group>[^\s']+)'?`, nil),
+		match(`Group '(?P<group>[^']+)' doesn'?t exist`, ErrGroupDNE),
+		match(`Player '(?P<name>.*?)' not found`, ErrPlayerNotFound),
+	}
+
+	removeFromGroupResponses = classifier{
+		match(`Player '`+playerExpr+`' removed from group:? '?(?P<group>[^\s']+)'?`, nil),
+		match(`Player '`+playerExpr+`' isn'?t in group:? '?(?P<group>[^\s']+)'?`, ErrNotInGroup),
+		match(`Group '(?P<group>[^']+)' doesn'?t exist`, ErrGroupDNE),
+		match(`Player '(?P<name>.*?)' not found`, ErrPlayerNotFound),
+	}
+
+	kickResponses = classifier{
+		match(`Kicked:? (?P<name>.*)`, nil),
+		match(`Player not found`, ErrPlayerNotFound),
+	}
+
+	banResponses = classifier{
+		match(`(?:Kick)?banned User:? `+steamIDExpr+`(?: - "?(?P<name>.*?)"?(?: for "?(?P<reason>.*?)"?)?)?`, nil),
+		match(`User `+steamIDExpr+` is already banned`, ErrBanExists),
+	}
+
+	unbanResponses = classifier{
+		match(`Unbanned User:? `+steamIDExpr+`(?: .*)?`, nil),
+		match(`User `+steamIDExpr+` isn'?t banned`, ErrBanDNE),
+	}
+
+	// Oxide compiles plugins asynchronously, oxide.reload may respond with
+	// the loaded plugin, compilation progress, or nothing at all. Anything
+	// other than the plugin not being loaded is treated as success.
+	reloadPluginResponses = classifier{
+		match(`Plugin '(?P<name>[^']+)' not (?:loaded|found)\.?`, ErrPluginNotLoaded),
+		match(`(?:Re)?loaded plugin `+pluginExpr, nil),
+		match(`.*`, nil),
+	}
+
+	unloadPluginResponses = classifier{
+		match(`Unloaded plugin `+pluginExpr, nil),
+		match(`Plugin '(?P<name>[^']+)' not (?:loaded|found)\.?`, ErrPluginNotLoaded),
+	}
+
+	saveResponses = classifier{
+		match(`(?:\[Save\] )?Saving complete.*`, nil),
+		match(`Saved (?P<entities>[\d,]+) ents.*`, nil),
+	}
+
+	writeCfgResponses = classifier{
+		match(`Config Saved\.?`, nil),
+	}
+)
@@ -0,0 +1,460 @@
+package rcon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		err    error
+		fields map[string]string
+	}
+	tests := map[string]struct {
+		responses classifier
+		message   string
+		exp       expected
+	}{
+		"add moderator unnamed": {
+			responses: addModeratorResponses,
+			message:   "Added moderator unnamed, steamid 76561197962911631",
+			exp: expected{fields: map[string]string{
+				"name":    "unnamed",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add moderator named": {
+			responses: addModeratorResponses,
+			message:   "Added moderator tjper, steamid 76561197962911631",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add moderator name with comma and spaces": {
+			responses: addModeratorResponses,
+			message:   "Added moderator [RPM] tjper, the builder, steamid 76561197962911631",
+			exp: expected{fields: map[string]string{
+				"name":    "[RPM] tjper, the builder",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add moderator with group suffix": {
+			responses: addModeratorResponses,
+			message:   "Added moderator tjper, steamid 76561197962911631, group Admin",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add moderator trailing newline": {
+			responses: addModeratorResponses,
+			message:   "Added moderator tjper, steamid 76561197962911631\n",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add existing moderator": {
+			responses: addModeratorResponses,
+			message:   "User 76561197962911631 is already a Moderator",
+			exp: expected{
+				err:    ErrModeratorExists,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"remove moderator": {
+			responses: removeModeratorResponses,
+			message:   "Removed Moderator: 76561197962911631",
+			exp:       expected{fields: map[string]string{"steamid": "76561197962911631"}},
+		},
+		"remove non-existent moderator": {
+			responses: removeModeratorResponses,
+			message:   "User 76561197962911631 isn't a moderator",
+			exp: expected{
+				err:    ErrModeratorDNE,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"add owner named": {
+			responses: addOwnerResponses,
+			message:   "Added owner tjper, steamid 76561197962911631",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+			}},
+		},
+		"add existing owner": {
+			responses: addOwnerResponses,
+			message:   "User 76561197962911631 is already a Owner",
+			exp: expected{
+				err:    ErrOwnerExists,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"add existing owner grammatical": {
+			responses: addOwnerResponses,
+			message:   "User 76561197962911631 is already an Owner",
+			exp: expected{
+				err:    ErrOwnerExists,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"remove owner": {
+			responses: removeOwnerResponses,
+			message:   "Removed Owner: 76561197962911631",
+			exp:       expected{fields: map[string]string{"steamid": "76561197962911631"}},
+		},
+		"remove non-existent owner": {
+			responses: removeOwnerResponses,
+			message:   "User 76561197962911631 isn't a owner",
+			exp: expected{
+				err:    ErrOwnerDNE,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"grant permission offline player": {
+			responses: grantPermissionResponses,
+			message:   "Player '76561197962911631 (76561197962911631)' granted permission 'bypassqueue.allow'",
+			exp: expected{fields: map[string]string{
+				"name":       "76561197962911631",
+				"steamid":    "76561197962911631",
+				"permission": "bypassqueue.allow",
+			}},
+		},
+		"grant permission named player": {
+			responses: grantPermissionResponses,
+			message:   "Player 'tjper (76561197962911631)' granted permission 'bypassqueue.allow'",
+			exp: expected{fields: map[string]string{
+				"name":       "tjper",
+				"steamid":    "76561197962911631",
+				"permission": "bypassqueue.allow",
+			}},
+		},
+		"grant permission player name with parentheses": {
+			responses: grantPermissionResponses,
+			message:   "Player 'tjper (rpm) (76561197962911631)' granted permission 'vanish.allow'",
+			exp: expected{fields: map[string]string{
+				"name":       "tjper (rpm)",
+				"steamid":    "76561197962911631",
+				"permission": "vanish.allow",
+			}},
+		},
+		"grant permission already granted": {
+			responses: grantPermissionResponses,
+			message:   "Player 'tjper (76561197962911631)' already has permission 'bypassqueue.allow'",
+			exp: expected{
+				err: ErrPermissionAlreadyGranted,
+				fields: map[string]string{
+					"name":       "tjper",
+					"steamid":    "76561197962911631",
+					"permission": "bypassqueue.allow",
+				},
+			},
+		},
+		"grant permission already granted legacy": {
+			responses: grantPermissionResponses,
+			message:   "Player '76561197962911631' already has permission 'bypassqueue.allow'",
+			exp: expected{
+				err: ErrPermissionAlreadyGranted,
+				fields: map[string]string{
+					"name":       "76561197962911631",
+					"permission": "bypassqueue.allow",
+				},
+			},
+		},
+		"grant permission player not found": {
+			responses: grantPermissionResponses,
+			message:   "Player '76561197962911631' not found",
+			exp: expected{
+				err:    ErrPlayerNotFound,
+				fields: map[string]string{"name": "76561197962911631"},
+			},
+		},
+		"grant permission DNE": {
+			responses: grantPermissionResponses,
+			message:   "Permission 'bypassqueue.allow' doesn't exist",
+			exp: expected{
+				err:    ErrPermissionDNE,
+				fields: map[string]string{"permission": "bypassqueue.allow"},
+			},
+		},
+		"grant group permission": {
+			responses: grantGroupPermissionResponses,
+			message:   "Group 'vip' granted permission 'bypassqueue.allow'",
+			exp: expected{fields: map[string]string{
+				"group":      "vip",
+				"permission": "bypassqueue.allow",
+			}},
+		},
+		"grant group permission already granted": {
+			responses: grantGroupPermissionResponses,
+			message:   "Group 'vip' already has permission 'bypassqueue.allow'",
+			exp: expected{
+				err: ErrPermissionAlreadyGranted,
+				fields: map[string]string{
+					"group":      "vip",
+					"permission": "bypassqueue.allow",
+				},
+			},
+		},
+		"grant group permission group DNE": {
+			responses: grantGroupPermissionResponses,
+			message:   "Group 'vip' doesn't exist",
+			exp: expected{
+				err:    ErrGroupDNE,
+				fields: map[string]string{"group": "vip"},
+			},
+		},
+		"grant group permission DNE": {
+			responses: grantGroupPermissionResponses,
+			message:   "Permission 'bypassqueue.allow' doesn't exist",
+			exp: expected{
+				err:    ErrPermissionDNE,
+				fields: map[string]string{"permission": "bypassqueue.allow"},
+			},
+		},
+		"revoke permission": {
+			responses: revokePermissionResponses,
+			message:   "Player 'tjper (76561197962911631)' revoked permission 'bypassqueue.allow'",
+			exp: expected{fields: map[string]string{
+				"name":       "tjper",
+				"steamid":    "76561197962911631",
+				"permission": "bypassqueue.allow",
+			}},
+		},
+		"revoke permission not granted": {
+			responses: revokePermissionResponses,
+			message:   "Player 'tjper (76561197962911631)' does not have permission 'bypassqueue.allow'",
+			exp: expected{
+				err: ErrPermissionNotGranted,
+				fields: map[string]string{
+					"name":       "tjper",
+					"steamid":    "76561197962911631",
+					"permission": "bypassqueue.allow",
+				},
+			},
+		},
+		"create group": {
+			responses: createGroupResponses,
+			message:   "Group 'vip' created",
+			exp:       expected{fields: map[string]string{"group": "vip"}},
+		},
+		"create existing group": {
+			responses: createGroupResponses,
+			message:   "Group 'vip' already exists",
+			exp: expected{
+				err:    ErrGroupExists,
+				fields: map[string]string{"group": "vip"},
+			},
+		},
+		"add to group steam ID": {
+			responses: addToGroupResponses,
+			message:   "Player '76561197962911631' added to group: vip",
+			exp: expected{fields: map[string]string{
+				"name":  "76561197962911631",
+				"group": "vip",
+			}},
+		},
+		"add to group named player": {
+			responses: addToGroupResponses,
+			message:   "Player 'tjper (76561197962911631)' added to group: vip",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+				"group":   "vip",
+			}},
+		},
+		"add to group DNE": {
+			responses: addToGroupResponses,
+			message:   "Group 'vip' doesn't exist",
+			exp: expected{
+				err:    ErrGroupDNE,
+				fields: map[string]string{"group": "vip"},
+			},
+		},
+		"remove from group": {
+			responses: removeFromGroupResponses,
+			message:   "Player 'tjper (76561197962911631)' removed from group: vip",
+			exp: expected{fields: map[string]string{
+				"name":    "tjper",
+				"steamid": "76561197962911631",
+				"group":   "vip",
+			}},
+		},
+		"remove from group not member": {
+			responses: removeFromGroupResponses,
+			message:   "Player 'unnamed (76561197962911631)' isn't in group 'vip'",
+			exp: expected{
+				err: ErrNotInGroup,
+				fields: map[string]string{
+					"name":    "unnamed",
+					"steamid": "76561197962911631",
+					"group":   "vip",
+				},
+			},
+		},
+		"kick": {
+			responses: kickResponses,
+			message:   "Kicked: tjper",
+			exp:       expected{fields: map[string]string{"name": "tjper"}},
+		},
+		"kick player not found": {
+			responses: kickResponses,
+			message:   "Player not found",
+			exp:       expected{err: ErrPlayerNotFound, fields: map[string]string{}},
+		},
+		"ban": {
+			responses: banResponses,
+			message:   `Banned User: 76561197962911631 - "unnamed" for "cheating"`,
+			exp: expected{fields: map[string]string{
+				"steamid": "76561197962911631",
+				"name":    "unnamed",
+				"reason":  "cheating",
+			}},
+		},
+		"ban without reason": {
+			responses: banResponses,
+			message:   "Banned User: 76561197962911631 - tjper",
+			exp: expected{fields: map[string]string{
+				"steamid": "76561197962911631",
+				"name":    "tjper",
+			}},
+		},
+		"kickban": {
+			responses: banResponses,
+			message:   `Kickbanned User: 76561197962911631 - "tjper" for "cheating"`,
+			exp: expected{fields: map[string]string{
+				"steamid": "76561197962911631",
+				"name":    "tjper",
+				"reason":  "cheating",
+			}},
+		},
+		"ban banned player": {
+			responses: banResponses,
+			message:   "User 76561197962911631 is already banned",
+			exp: expected{
+				err:    ErrBanExists,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"unban": {
+			responses: unbanResponses,
+			message:   "Unbanned User: 76561197962911631",
+			exp:       expected{fields: map[string]string{"steamid": "76561197962911631"}},
+		},
+		"unban player not banned": {
+			responses: unbanResponses,
+			message:   "User 76561197962911631 isn't banned",
+			exp: expected{
+				err:    ErrBanDNE,
+				fields: map[string]string{"steamid": "76561197962911631"},
+			},
+		},
+		"reload plugin": {
+			responses: reloadPluginResponses,
+			message:   "Loaded plugin Vanish v1.6.2 by Whispers88",
+			exp: expected{fields: map[string]string{
+				"title":   "Vanish",
+				"version": "1.6.2",
+				"author":  "Whispers88",
+			}},
+		},
+		"reload plugin compiling": {
+			responses: reloadPluginResponses,
+			message:   "",
+			exp:       expected{fields: map[string]string{}},
+		},
+		"reload plugin not loaded": {
+			responses: reloadPluginResponses,
+			message:   "Plugin 'Vanish' not loaded.",
+			exp: expected{
+				err:    ErrPluginNotLoaded,
+				fields: map[string]string{"name": "Vanish"},
+			},
+		},
+		"unload plugin": {
+			responses: unloadPluginResponses,
+			message:   "Unloaded plugin Admin Radar v5.1.3 by nivex",
+			exp: expected{fields: map[string]string{
+				"title":   "Admin Radar",
+				"version": "5.1.3",
+				"author":  "nivex",
+			}},
+		},
+		"unload plugin not found": {
+			responses: unloadPluginResponses,
+			message:   "Plugin 'Vanish' not found",
+			exp: expected{
+				err:    ErrPluginNotLoaded,
+				fields: map[string]string{"name": "Vanish"},
+			},
+		},
+		"save complete": {
+			responses: saveResponses,
+			message:   "Saving complete",
+			exp:       expected{fields: map[string]string{}},
+		},
+		"save complete prefixed": {
+			responses: saveResponses,
+			message:   "[Save] Saving complete",
+			exp:       expected{fields: map[string]string{}},
+		},
+		"saved ents": {
+			responses: saveResponses,
+			message:   "Saved 112,497 ents, cache(0.08), write(0.02), disk(0.01).",
+			exp:       expected{fields: map[string]string{"entities": "112,497"}},
+		},
+		"save in progress": {
+			responses: saveResponses,
+			message:   "Saving...",
+			exp:       expected{err: errUnexpectedInboundMessage},
+		},
+		"writecfg": {
+			responses: writeCfgResponses,
+			message:   "Config Saved",
+			exp:       expected{fields: map[string]string{}},
+		},
+		"unexpected message": {
+			responses: addModeratorResponses,
+			message:   "Command 'moderatorid' not found",
+			exp:       expected{err: errUnexpectedInboundMessage},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := test.responses.classify(test.message)
+			if test.exp.err == errUnexpectedInboundMessage {
+				require.ErrorIs(t, err, errUnexpectedInboundMessage)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.exp.err, res.err)
+			require.Equal(t, test.exp.fields, res.fields)
+		})
+	}
+}
+
+func TestResponseCheck(t *testing.T) {
+	t.Parallel()
+
+	res, err := addModeratorResponses.classify("Added moderator tjper, steamid 76561197962911631")
+	require.Nil(t, err)
+
+	err = res.check(want("steamid", "76561197962911631"))
+	require.Nil(t, err)
+
+	err = res.check(want("steamid", "76561197962911632"))
+	require.ErrorIs(t, err, errUnexpectedInboundMessage)
+
+	// Expectations regarding fields that were not captured are ignored.
+	err = res.check(want("permission", "bypassqueue.allow"))
+	require.Nil(t, err)
+}
@@ -13,23 +13,37 @@ import (
 // because routing does not exist for the identifier passed.
 var ErrRoutingIdentifier = errors.New("identifier routing DNE")
 
+// ErrRouterClosed indicates that an Outbound message could not be sent
+// because the Router has been closed.
+var ErrRouterClosed = errors.New("router closed")
+
 func NewRouter(logger *zap.Logger) *Router {
 	return &Router{
-		logger: logger,
-		mutex:  new(sync.RWMutex),
-		sendc:  make(chan Outbound, 1),
-		routes: make(map[int]chan Inbound),
+		logger:        logger,
+		mutex:         new(sync.RWMutex),
+		sendc:         make(chan Outbound, 1),
+		done:          make(chan struct{}),
+		closeOnce:     new(sync.Once),
+		routes:        make(map[int]chan Inbound),
+		subscriptions: make(map[int]*subscription),
 	}
 }
 
 // Router is responsible for routing Inbound and Outbound messages based on
-// their Identifier fields.
+// their Identifier fields. Inbound messages that cannot be routed are
+// published to the Router's subscriptions.
 type Router struct {
 	logger *zap.Logger
 
 	mutex  *sync.RWMutex
 	sendc  chan Outbound
 	routes map[int]chan Inbound
+
+	done      chan struct{}
+	closeOnce *sync.Once
+
+	subscriptionID int
+	subscriptions  map[int]*subscription
 }
 
 // Write sends the Outbound message and does expect a response.
@@ -37,6 +51,8 @@ func (r *Router) Write(ctx context.Context, out Outbound) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
+	case <-r.done:
+		return ErrRouterClosed
 	case r.sendc <- out:
 	}
 	return nil
@@ -57,7 +73,11 @@ func (r *Router) Request(ctx context.Context, out Outbound) (chan Inbound, error
 	sendRoute()
 	select {
 	case <-ctx.Done():
+		r.CloseRoute(out.Identifier)
 		return nil, ctx.Err()
+	case <-r.done:
+		r.CloseRoute(out.Identifier)
+		return nil, ErrRouterClosed
 	case r.sendc <- out:
 	}
 	return route, nil
@@ -86,6 +106,7 @@ func (r *Router) Injest(cancel chan struct{}, in Inbound) error {
 	}
 
 	if err := fetchRoute(); err != nil {
+		r.publish(in)
 		return err
 	}
 	select {
@@ -96,8 +117,83 @@ func (r *Router) Injest(cancel chan struct{}, in Inbound) error {
 	}
 }
 
+// Close closes the Router. Outbound messages written after the Router has
+// been closed are rejected with ErrRouterClosed.
+func (r *Router) Close() {
+	r.closeOnce.Do(func() { close(r.done) })
+}
+
+// Done returns a channel that is closed when the Router is closed.
+func (r *Router) Done() <-chan struct{} {
+	return r.done
+}
+
 // Outboundc returns a channel responsible for all Outbound messages that are
 // sent via Router.Request.
 func (r *Router) Outboundc() chan Outbound {
 	return r.sendc
 }
+
+// subscriptionBuffer is the number of Inbound messages a subscription may
+// have pending before messages published to it are dropped.
+const subscriptionBuffer = 128
+
+type subscription struct {
+	filter   Filter
+	inboundc chan Inbound
+	dropped  int
+}
+
+// Subscribe registers a subscription that receives all unrouted Inbound
+// messages that pass the filter. The returned function must be called to
+// unsubscribe, it closes the returned channel.
+func (r *Router) Subscribe(filter Filter) (<-chan Inbound, func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.subscriptionID++
+	id := r.subscriptionID
+
+	sub := &subscription{
+		filter:   filter,
+		inboundc: make(chan Inbound, subscriptionBuffer),
+	}
+	r.subscriptions[id] = sub
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			delete(r.subscriptions, id)
+			close(sub.inboundc)
+		})
+	}
+	return sub.inboundc, unsubscribe
+}
+
+// publish sends the Inbound message to each subscription whose filter it
+// passes. Subscriptions that are not keeping up have the message dropped
+// rather than blocking the caller, typically Client.readPump.
+func (r *Router) publish(in Inbound) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, sub := range r.subscriptions {
+		if sub.filter != nil && !sub.filter(in) {
+			continue
+		}
+
+		select {
+		case sub.inboundc <- in:
+		default:
+			sub.dropped++
+			r.logger.Warn(
+				"dropped inbound message for slow subscription",
+				zap.Int("subscription", id),
+				zap.Int("dropped", sub.dropped),
+			)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package rcon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InboundKind classifies an Inbound message by its WebRcon Type.
+type InboundKind string
+
+const (
+	// InboundKindChat is an in-game chat message. Its Message field is a JSON
+	// encoded Chat.
+	InboundKindChat InboundKind = "chat"
+
+	// InboundKindConsole is a console line such as command output, player
+	// join and leave notices, or server log output.
+	InboundKindConsole InboundKind = "console"
+
+	// InboundKindWarning is a warning or error logged by the Rust server.
+	InboundKindWarning InboundKind = "warning"
+)
+
+// Kind classifies the Inbound message.
+func (in Inbound) Kind() InboundKind {
+	switch in.Type {
+	case "Chat":
+		return InboundKindChat
+	case "Warning", "Error":
+		return InboundKindWarning
+	default:
+		return InboundKindConsole
+	}
+}
+
+// errInboundNotChat indicates Inbound.Chat was called on an Inbound that is
+// not of kind InboundKindChat.
+var errInboundNotChat = errors.New("inbound is not a chat message")
+
+// Chat is an in-game chat message sent by a player.
+type Chat struct {
+	Channel  int
+	Message  string
+	UserID   string `json:"UserId"`
+	Username string
+	Color    string
+	Time     int64
+}
+
+// At is the time at which the Chat was sent.
+func (c Chat) At() time.Time {
+	return time.Unix(c.Time, 0)
+}
+
+// Chat parses the Inbound message as a Chat.
+func (in Inbound) Chat() (*Chat, error) {
+	if in.Kind() != InboundKindChat {
+		return nil, errInboundNotChat
+	}
+
+	chat := new(Chat)
+	if err := json.Unmarshal([]byte(in.Message), chat); err != nil {
+		return nil, fmt.Errorf("while unmarshalling chat: %w", err)
+	}
+	return chat, nil
+}
+
+// Filter reports whether an Inbound message should be delivered to a
+// subscription. A nil Filter accepts all messages.
+type Filter func(Inbound) bool
+
+// FilterKinds creates a Filter that accepts Inbound messages of the passed
+// kinds.
+func FilterKinds(kinds ...InboundKind) Filter {
+	return func(in Inbound) bool {
+		for _, kind := range kinds {
+			if in.Kind() == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Subscribe subscribes to unsolicited Inbound messages, such as chat and
+// console output, that pass the filter. The returned channel is closed when
+// the context is cancelled or the Client is closed. A subscriber that falls
+// behind has messages dropped rather than stalling the Client.
+func (c Client) Subscribe(ctx context.Context, filter Filter) (<-chan Inbound, error) {
+	select {
+	case <-c.closed:
+		return nil, errRconClientUnexpectedClose
+	default:
+	}
+
+	inboundc, unsubscribe := c.router.Subscribe(filter)
+	go func() {
+		defer unsubscribe()
+
+		select {
+		case <-ctx.Done():
+		case <-c.closed:
+		}
+	}()
+
+	return inboundc, nil
+}
@@ -0,0 +1,319 @@
+package rcon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	chat := Inbound{
+		Message: `{"Channel":0,"Message":"hello rust world","UserId":"76561197962911631","Username":"tjper","Color":"#5af","Time":1650000000}`,
+		Type:    "Chat",
+	}
+	console := Inbound{
+		Message: "76561197962911631/tjper joined [windows/76561197962911631]",
+		Type:    "Generic",
+	}
+	warning := Inbound{
+		Message: "Kicking 76561197962911631 (Steam Auth Timeout)",
+		Type:    "Warning",
+	}
+
+	type expected struct {
+		inbounds []Inbound
+	}
+	tests := map[string]struct {
+		filter      Filter
+		unsolicited []Inbound
+		exp         expected
+	}{
+		"all": {
+			filter:      nil,
+			unsolicited: []Inbound{chat, console, warning},
+			exp:         expected{inbounds: []Inbound{chat, console, warning}},
+		},
+		"chat": {
+			filter:      FilterKinds(InboundKindChat),
+			unsolicited: []Inbound{console, chat, warning},
+			exp:         expected{inbounds: []Inbound{chat}},
+		},
+		"console and warning": {
+			filter:      FilterKinds(InboundKindConsole, InboundKindWarning),
+			unsolicited: []Inbound{chat, console, warning},
+			exp:         expected{inbounds: []Inbound{console, warning}},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			server := newFakeServer(t)
+			defer server.Close()
+
+			client, err := Dial(ctx, zap.NewNop(), server.url())
+			require.Nil(t, err)
+			defer client.Close()
+
+			inboundc, err := client.Subscribe(ctx, test.filter)
+			require.Nil(t, err)
+
+			for _, in := range test.unsolicited {
+				server.send(t, in)
+			}
+
+			for _, exp := range test.exp.inbounds {
+				select {
+				case <-ctx.Done():
+					t.Fatal("timed out waiting for inbound")
+				case in := <-inboundc:
+					require.Equal(t, exp, in)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeChat(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	client, err := Dial(ctx, zap.NewNop(), server.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	inboundc, err := client.Subscribe(ctx, FilterKinds(InboundKindChat))
+	require.Nil(t, err)
+
+	server.send(t, Inbound{
+		Message: `{"Channel":0,"Message":"hello rust world","UserId":"76561197962911631","Username":"tjper","Color":"#5af","Time":1650000000}`,
+		Type:    "Chat",
+	})
+
+	in := <-inboundc
+	chat, err := in.Chat()
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		Chat{
+			Message:  "hello rust world",
+			UserID:   "76561197962911631",
+			Username: "tjper",
+			Color:    "#5af",
+			Time:     1650000000,
+		},
+		*chat,
+	)
+	require.True(t, chat.At().Equal(time.Unix(1650000000, 0)))
+
+	_, err = Inbound{Type: "Generic"}.Chat()
+	require.ErrorIs(t, err, errInboundNotChat)
+}
+
+func TestSubscribeExcludesResponses(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	client, err := Dial(ctx, zap.NewNop(), server.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	inboundc, err := client.Subscribe(ctx, nil)
+	require.Nil(t, err)
+
+	info, err := client.ServerInfo(ctx)
+	require.Nil(t, err)
+	require.Equal(t, "fake-server", info.Hostname)
+
+	select {
+	case in := <-inboundc:
+		t.Fatalf("unexpected inbound published to subscriber: %v", in)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	client, err := Dial(ctx, zap.NewNop(), server.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	// Subscribe and never read from the subscription.
+	_, err = client.Subscribe(ctx, nil)
+	require.Nil(t, err)
+
+	for i := 0; i < 2*subscriptionBuffer; i++ {
+		server.send(t, Inbound{Message: "console noise", Type: "Generic"})
+	}
+
+	// The client continues to process responses while the subscriber is
+	// stalled.
+	_, err = client.ServerInfo(ctx)
+	require.Nil(t, err)
+}
+
+func TestSubscriptionClosed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newFakeServer(t)
+	defer server.Close()
+
+	client, err := Dial(ctx, zap.NewNop(), server.url())
+	require.Nil(t, err)
+
+	t.Run("context cancelled", func(t *testing.T) {
+		subCtx, subCancel := context.WithCancel(ctx)
+		inboundc, err := client.Subscribe(subCtx, nil)
+		require.Nil(t, err)
+
+		subCancel()
+		_, ok := <-inboundc
+		require.False(t, ok)
+	})
+	t.Run("client closed", func(t *testing.T) {
+		inboundc, err := client.Subscribe(ctx, nil)
+		require.Nil(t, err)
+
+		client.Close()
+		_, ok := <-inboundc
+		require.False(t, ok)
+	})
+	t.Run("subscribe to closed client", func(t *testing.T) {
+		_, err := client.Subscribe(ctx, nil)
+		require.ErrorIs(t, err, errRconClientUnexpectedClose)
+	})
+}
+
+// --- helpers ---
+
+// fakeServer is a minimal WebRcon server. It responds to global.serverinfo
+// and allows unsolicited messages to be sent to the connected client.
+type fakeServer struct {
+	*httptest.Server
+	sendc chan Inbound
+	dropc chan struct{}
+	conns int32
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{
+		sendc: make(chan Inbound),
+		dropc: make(chan struct{}),
+	}
+
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade fake server connection: %s", err)
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&s.conns, 1)
+
+		outc := make(chan Outbound)
+		go func() {
+			defer close(outc)
+			for {
+				var out Outbound
+				if err := conn.ReadJSON(&out); err != nil {
+					return
+				}
+				outc <- out
+			}
+		}()
+
+		for {
+			select {
+			case out, ok := <-outc:
+				if !ok {
+					return
+				}
+				if out.Message != "global.serverinfo" {
+					continue
+				}
+				info, _ := json.Marshal(ServerInfo{Hostname: "fake-server"})
+				if err := conn.WriteJSON(Inbound{
+					Identifier: out.Identifier,
+					Message:    string(info),
+					Type:       "Generic",
+				}); err != nil {
+					return
+				}
+			case in := <-s.sendc:
+				if err := conn.WriteJSON(in); err != nil {
+					return
+				}
+			case <-s.dropc:
+				return
+			}
+		}
+	}))
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+// addr is the fake server's address in the form host:port.
+func (s *fakeServer) addr() string {
+	return strings.TrimPrefix(s.Server.URL, "http://")
+}
+
+// connections is the number of connections the fake server has accepted.
+func (s *fakeServer) connections() int {
+	return int(atomic.LoadInt32(&s.conns))
+}
+
+// drop closes the fake server's connection to the client.
+func (s *fakeServer) drop(t *testing.T) {
+	select {
+	case s.dropc <- struct{}{}:
+	case <-time.After(time.Second):
+		t.Fatal("timed out dropping fake server connection")
+	}
+}
+
+func (s *fakeServer) send(t *testing.T, in Inbound) {
+	select {
+	case s.sendc <- in:
+	case <-time.After(time.Second):
+		t.Fatal("timed out sending unsolicited inbound")
+	}
+}
@@ -0,0 +1,229 @@
+// Package reconciler is responsible for finding and cleaning up cloud
+// resources that were created by cronman but no longer belong to a server,
+// e.g. an instance launched by a server creation that failed part way.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/db"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/server"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OrphanKind is the kind of cloud resource that has been orphaned.
+type OrphanKind string
+
+const (
+	OrphanKindInstance OrphanKind = "instance"
+	OrphanKindAddress  OrphanKind = "address"
+)
+
+// Orphan is a cloud resource that was created by cronman but does not belong
+// to a server.
+type Orphan struct {
+	Kind   OrphanKind
+	Region model.Region
+	// ID is the instance ID or allocation ID of the resource.
+	ID string
+	// AssociationID is the ID of the association between an address and an
+	// instance, if any.
+	AssociationID string
+	// ServerID is the ID of the server the resource was created for.
+	ServerID uuid.UUID
+	// Reason describes why the resource is considered orphaned.
+	Reason string
+}
+
+// Report is the outcome of a reconciliation.
+type Report struct {
+	DryRun  bool
+	Orphans []Orphan
+	// Cleaned is the number of Orphans that were cleaned up.
+	Cleaned int
+}
+
+// Run reconciles cloud resources on an interval until the context is
+// cancelled.
+func (r Reconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := r.Reconcile(ctx)
+		if err != nil {
+			r.logger.Error("while reconciling cloud resources", zap.Error(err))
+		} else {
+			r.logger.Info(
+				"reconciled cloud resources",
+				zap.Bool("dry-run", report.DryRun),
+				zap.Int("orphans", len(report.Orphans)),
+				zap.Int("cleaned", report.Cleaned),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile lists the cloud resources tagged with a server ID in each region
+// and compares them with the servers in the store. Orphaned resources are
+// reported, and cleaned up unless the Reconciler is in dry-run mode. Archived
+// servers retain their resources, their resources are not orphans.
+func (r Reconciler) Reconcile(ctx context.Context) (*Report, error) {
+	servers, err := db.ListServerResources(ctx, r.store)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]model.Server, len(servers))
+	for _, server := range servers {
+		byID[server.ID] = server
+	}
+
+	regions := make([]model.Region, 0, len(r.managers))
+	for region := range r.managers {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+
+	report := &Report{DryRun: r.dryRun, Orphans: make([]Orphan, 0)}
+	for _, region := range regions {
+		manager := r.managers[region]
+
+		instances, err := manager.ListInstances(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("while listing %s instances: %w", region, err)
+		}
+		addresses, err := manager.ListAddresses(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("while listing %s addresses: %w", region, err)
+		}
+
+		orphans := findOrphans(region, byID, instances, addresses, r.time.Now().Add(-r.gracePeriod))
+		for _, orphan := range orphans {
+			logger := r.logger.With(
+				zap.String("kind", string(orphan.Kind)),
+				zap.String("region", string(orphan.Region)),
+				zap.String("resource-id", orphan.ID),
+				zap.Stringer("server-id", orphan.ServerID),
+				zap.String("reason", orphan.Reason),
+			)
+			report.Orphans = append(report.Orphans, orphan)
+
+			if r.dryRun {
+				logger.Warn("found orphaned cloud resource")
+				continue
+			}
+			if err := cleanup(ctx, manager, orphan); err != nil {
+				logger.Error("while cleaning up orphaned cloud resource", zap.Error(err))
+				continue
+			}
+			logger.Info("cleaned up orphaned cloud resource")
+			report.Cleaned++
+		}
+	}
+	return report, nil
+}
+
+// findOrphans determines which of the region's instances and addresses do not
+// belong to the servers. Instances launched after launchedBefore are never
+// considered orphaned. Addresses are ordered before instances so that an
+// address is released before the instance it is associated with is deleted.
+func findOrphans(
+	region model.Region,
+	servers map[uuid.UUID]model.Server,
+	instances []server.Instance,
+	addresses []server.Address,
+	launchedBefore time.Time,
+) []Orphan {
+	orphans := make([]Orphan, 0)
+
+	for _, address := range addresses {
+		reason, ok := orphaned(servers, address.ServerID, func(s model.Server) bool {
+			return s.AllocationID == address.AllocationID
+		})
+		if !ok {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:          OrphanKindAddress,
+			Region:        region,
+			ID:            address.AllocationID,
+			AssociationID: address.AssociationID,
+			ServerID:      address.ServerID,
+			Reason:        reason,
+		})
+	}
+
+	for _, instance := range instances {
+		if instance.LaunchTime.After(launchedBefore) {
+			continue
+		}
+		reason, ok := orphaned(servers, instance.ServerID, func(s model.Server) bool {
+			return s.InstanceID == instance.ID
+		})
+		if !ok {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:     OrphanKindInstance,
+			Region:   region,
+			ID:       instance.ID,
+			ServerID: instance.ServerID,
+			Reason:   reason,
+		})
+	}
+
+	return orphans
+}
+
+// orphaned determines if a resource tagged with serverID is orphaned. owns
+// reports if the server the resource was tagged with still references the
+// resource. If the resource is orphaned, the reason is returned along with
+// true.
+func orphaned(
+	servers map[uuid.UUID]model.Server,
+	serverID uuid.UUID,
+	owns func(model.Server) bool,
+) (string, bool) {
+	server, ok := servers[serverID]
+	if !ok {
+		return "server does not exist", true
+	}
+	// The server's resources may not have been recorded yet.
+	if server.Lifecycle == model.LifecycleCreating ||
+		server.Lifecycle == model.LifecycleUnarchiving {
+		return "", false
+	}
+	if !owns(server) {
+		return "server does not reference resource", true
+	}
+	return "", false
+}
+
+// cleanup deletes the orphaned resource. Associated addresses are
+// disassociated prior to being released.
+func cleanup(ctx context.Context, manager IServerManager, orphan Orphan) error {
+	switch orphan.Kind {
+	case OrphanKindInstance:
+		return manager.DeleteInstance(ctx, orphan.ID)
+	case OrphanKindAddress:
+		if orphan.AssociationID != "" {
+			if err := manager.MakeInstanceUnavailable(ctx, orphan.AssociationID); err != nil {
+				return err
+			}
+		}
+		return manager.ReleaseAddress(ctx, orphan.ID)
+	}
+	return fmt.Errorf("unexpected orphan kind %s", orphan.Kind)
+}
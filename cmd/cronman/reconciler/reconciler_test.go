@@ -0,0 +1,190 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/server"
+	imodel "github.com/tjper/rustcron/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrphans(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.June, 12, 18, 0, 0, 0, time.UTC)
+	launched := now.Add(-2 * time.Hour)
+
+	idle := model.Server{
+		Model:        imodel.Model{ID: uuid.New()},
+		InstanceID:   "i-idle",
+		AllocationID: "eipalloc-idle",
+		Lifecycle:    model.LifecycleIdle,
+	}
+	creating := model.Server{
+		Model:     imodel.Model{ID: uuid.New()},
+		Lifecycle: model.LifecycleCreating,
+	}
+	deleted := uuid.New()
+
+	servers := map[uuid.UUID]model.Server{
+		idle.ID:     idle,
+		creating.ID: creating,
+	}
+
+	tests := map[string]struct {
+		instances []server.Instance
+		addresses []server.Address
+		exp       []Orphan
+	}{
+		"owned": {
+			instances: []server.Instance{
+				{ID: "i-idle", ServerID: idle.ID, LaunchTime: launched},
+			},
+			addresses: []server.Address{
+				{AllocationID: "eipalloc-idle", ServerID: idle.ID},
+			},
+			exp: []Orphan{},
+		},
+		"server creating": {
+			instances: []server.Instance{
+				{ID: "i-creating", ServerID: creating.ID, LaunchTime: launched},
+			},
+			addresses: []server.Address{
+				{AllocationID: "eipalloc-creating", ServerID: creating.ID},
+			},
+			exp: []Orphan{},
+		},
+		"server dne": {
+			instances: []server.Instance{
+				{ID: "i-deleted", ServerID: deleted, LaunchTime: launched},
+			},
+			addresses: []server.Address{
+				{
+					AllocationID:  "eipalloc-deleted",
+					AssociationID: "eipassoc-deleted",
+					ServerID:      deleted,
+				},
+			},
+			exp: []Orphan{
+				{
+					Kind:          OrphanKindAddress,
+					Region:        model.RegionUsEast,
+					ID:            "eipalloc-deleted",
+					AssociationID: "eipassoc-deleted",
+					ServerID:      deleted,
+					Reason:        "server does not exist",
+				},
+				{
+					Kind:     OrphanKindInstance,
+					Region:   model.RegionUsEast,
+					ID:       "i-deleted",
+					ServerID: deleted,
+					Reason:   "server does not exist",
+				},
+			},
+		},
+		"server references different resources": {
+			instances: []server.Instance{
+				{ID: "i-retried", ServerID: idle.ID, LaunchTime: launched},
+			},
+			addresses: []server.Address{
+				{AllocationID: "eipalloc-retried", ServerID: idle.ID},
+			},
+			exp: []Orphan{
+				{
+					Kind:     OrphanKindAddress,
+					Region:   model.RegionUsEast,
+					ID:       "eipalloc-retried",
+					ServerID: idle.ID,
+					Reason:   "server does not reference resource",
+				},
+				{
+					Kind:     OrphanKindInstance,
+					Region:   model.RegionUsEast,
+					ID:       "i-retried",
+					ServerID: idle.ID,
+					Reason:   "server does not reference resource",
+				},
+			},
+		},
+		"instance within grace period": {
+			instances: []server.Instance{
+				{ID: "i-deleted", ServerID: deleted, LaunchTime: now.Add(-time.Minute)},
+			},
+			exp: []Orphan{},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			orphans := findOrphans(
+				model.RegionUsEast,
+				servers,
+				test.instances,
+				test.addresses,
+				now.Add(-defaultGracePeriod),
+			)
+			require.Equal(t, test.exp, orphans)
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		orphan Orphan
+		exp    []string
+	}{
+		"instance": {
+			orphan: Orphan{Kind: OrphanKindInstance, ID: "i-orphan"},
+			exp:    []string{"delete i-orphan"},
+		},
+		"address": {
+			orphan: Orphan{Kind: OrphanKindAddress, ID: "eipalloc-orphan"},
+			exp:    []string{"release eipalloc-orphan"},
+		},
+		"associated address": {
+			orphan: Orphan{
+				Kind:          OrphanKindAddress,
+				ID:            "eipalloc-orphan",
+				AssociationID: "eipassoc-orphan",
+			},
+			exp: []string{"disassociate eipassoc-orphan", "release eipalloc-orphan"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := make([]string, 0)
+			manager := server.NewMockManager()
+			manager.SetDeleteInstanceHandler(func(_ context.Context, id string) error {
+				calls = append(calls, "delete "+id)
+				return nil
+			})
+			manager.SetMakeInstanceUnavailableHandler(func(_ context.Context, id string) error {
+				calls = append(calls, "disassociate "+id)
+				return nil
+			})
+			manager.SetReleaseAddressHandler(func(_ context.Context, id string) error {
+				calls = append(calls, "release "+id)
+				return nil
+			})
+
+			err := cleanup(context.Background(), manager, test.orphan)
+			require.Nil(t, err)
+			require.Equal(t, test.exp, calls)
+		})
+	}
+}
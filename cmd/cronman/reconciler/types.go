@@ -0,0 +1,111 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/server"
+	itime "github.com/tjper/rustcron/internal/time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultInterval is the default interval at which the Reconciler
+	// reconciles cloud resources.
+	defaultInterval = time.Hour
+
+	// defaultGracePeriod is the default duration after launch during which an
+	// instance is never considered orphaned. This guards against instances
+	// whose creation is in progress.
+	defaultGracePeriod = 30 * time.Minute
+)
+
+// IServerManager represents the API by which the Reconciler lists and cleans
+// up cloud resources within a region.
+type IServerManager interface {
+	ListInstances(ctx context.Context) ([]server.Instance, error)
+	ListAddresses(ctx context.Context) ([]server.Address, error)
+	DeleteInstance(ctx context.Context, instanceID string) error
+	MakeInstanceUnavailable(ctx context.Context, associationID string) error
+	ReleaseAddress(ctx context.Context, allocationID string) error
+}
+
+// ITime represents the API by which the Reconciler interacts with time.
+type ITime interface {
+	Now() time.Time
+}
+
+// Reconciler compares the cloud resources cronman has created with the
+// servers in the store. Resources that do not belong to a server are orphans,
+// and are reported or cleaned up.
+type Reconciler struct {
+	logger   *zap.Logger
+	time     ITime
+	store    *gorm.DB
+	managers map[model.Region]IServerManager
+
+	interval    time.Duration
+	gracePeriod time.Duration
+	dryRun      bool
+}
+
+// Option mutates a Reconciler instance. Typically used with New to configure
+// a Reconciler instance.
+type Option func(*Reconciler)
+
+// WithInterval is an Option that configures the interval at which the
+// Reconciler reconciles cloud resources.
+func WithInterval(interval time.Duration) Option {
+	return func(r *Reconciler) {
+		r.interval = interval
+	}
+}
+
+// WithGracePeriod is an Option that configures the duration after launch
+// during which an instance is never considered orphaned.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(r *Reconciler) {
+		r.gracePeriod = gracePeriod
+	}
+}
+
+// WithDryRun is an Option that configures the Reconciler to only report
+// orphans, rather than clean them up.
+func WithDryRun(dryRun bool) Option {
+	return func(r *Reconciler) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithTime is an Option that configures the Reconciler's source of time.
+func WithTime(time ITime) Option {
+	return func(r *Reconciler) {
+		r.time = time
+	}
+}
+
+// New creates a new Reconciler object.
+func New(
+	logger *zap.Logger,
+	store *gorm.DB,
+	managers map[model.Region]IServerManager,
+	options ...Option,
+) *Reconciler {
+	r := &Reconciler{
+		logger:      logger.With(zap.String("reconciler-id", uuid.NewString())),
+		time:        new(itime.Time),
+		store:       store,
+		managers:    managers,
+		interval:    defaultInterval,
+		gracePeriod: defaultGracePeriod,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
@@ -2,7 +2,6 @@ package redis
 
 import (
 	"context"
-	"time"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -19,24 +18,14 @@ type Redis struct {
 	redis *redis.Client
 }
 
-// SetNX wraps redis.Client.SetNX.Result().
-func (r Redis) SetNX(
+// Eval wraps redis.Client.Eval.Result().
+func (r Redis) Eval(
 	ctx context.Context,
-	key string,
-	val interface{},
-	exp time.Duration,
-) (bool, error) {
-	return r.redis.SetNX(ctx, key, val, exp).Result()
-}
-
-// SetXX wraps redis.Client.SetXX.Result().
-func (r Redis) SetXX(
-	ctx context.Context,
-	key string,
-	val interface{},
-	exp time.Duration,
-) (bool, error) {
-	return r.redis.SetXX(ctx, key, val, exp).Result()
+	script string,
+	keys []string,
+	args ...interface{},
+) (interface{}, error) {
+	return r.redis.Eval(ctx, script, keys, args...).Result()
 }
 
 // Subscribe wraps redis.Client.Subscribe.
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tjper/rustcron/cmd/cronman/controller"
 	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
 	ihttp "github.com/tjper/rustcron/internal/http"
 	"github.com/tjper/rustcron/internal/session"
 	"github.com/tjper/rustcron/internal/validator"
@@ -20,28 +21,63 @@ import (
 )
 
 type IController interface {
-	CreateServer(context.Context, model.Server) (*model.DormantServer, error)
 	GetServer(context.Context, uuid.UUID) (interface{}, error)
 	UpdateServer(context.Context, controller.UpdateServerInput) (*model.DormantServer, error)
 	ArchiveServer(context.Context, uuid.UUID) (*model.ArchivedServer, error)
-	StartServer(context.Context, uuid.UUID) (*model.DormantServer, error)
-	MakeServerLive(context.Context, uuid.UUID) (*model.LiveServer, error)
-	StopServer(context.Context, uuid.UUID) (*model.DormantServer, error)
 	WipeServer(context.Context, uuid.UUID, model.Wipe) error
 
+	CreateServerJob(context.Context, model.Server) (*model.Job, error)
+	StartServerJob(context.Context, uuid.UUID) (*model.Job, error)
+	StopServerJob(context.Context, uuid.UUID) (*model.Job, error)
+	WipeServerJob(context.Context, uuid.UUID, model.Wipe) (*model.Job, error)
+	DecommissionServerJob(context.Context, uuid.UUID) (*model.Job, error)
+	UnarchiveServerJob(context.Context, uuid.UUID) (*model.Job, error)
+	GetJob(context.Context, uuid.UUID) (*model.Job, error)
+
+	GetServerConfig(context.Context, uuid.UUID) (*model.ServerConfig, error)
+	CreateServerTemplate(context.Context, string, model.ServerConfig) (*model.ServerTemplate, error)
+	GetServerTemplate(context.Context, uuid.UUID) (*model.ServerTemplate, error)
+	ListServerTemplates(context.Context) ([]model.ServerTemplate, error)
+	DeleteServerTemplate(context.Context, uuid.UUID) error
+
 	ListServers(context.Context, interface{}) error
 
 	AddServerTags(context.Context, uuid.UUID, model.Tags) error
 	RemoveServerTags(context.Context, uuid.UUID, []uuid.UUID) error
 
-	AddServerEvents(context.Context, uuid.UUID, model.Events) error
-	RemoveServerEvents(context.Context, uuid.UUID, []uuid.UUID) error
+	AddServerEvents(context.Context, uuid.UUID, model.Events) (model.ScheduleViolations, error)
+	RemoveServerEvents(context.Context, uuid.UUID, []uuid.UUID) (model.ScheduleViolations, error)
+	AddServerBlackouts(context.Context, uuid.UUID, model.Blackouts) error
+	RemoveServerBlackouts(context.Context, uuid.UUID, []uuid.UUID) error
 
 	AddServerModerators(context.Context, uuid.UUID, model.Moderators) error
 	RemoveServerModerators(context.Context, uuid.UUID, []uuid.UUID) error
 
 	AddServerOwners(context.Context, uuid.UUID, model.Owners) error
 	RemoveServerOwners(context.Context, uuid.UUID, []uuid.UUID) error
+
+	AddServerBans(context.Context, uuid.UUID, model.Bans) error
+	RemoveServerBans(context.Context, uuid.UUID, []uuid.UUID) error
+
+	ListPlugins(context.Context) (model.Plugins, error)
+	EnableServerPlugins(context.Context, uuid.UUID, []uuid.UUID) error
+	DisableServerPlugins(context.Context, uuid.UUID, []uuid.UUID) error
+
+	UpdateServerCountdown(context.Context, uuid.UUID, model.Countdown) error
+
+	ListServerPlayers(context.Context, uuid.UUID) ([]rcon.Player, error)
+	KickServerPlayer(context.Context, uuid.UUID, string, string) error
+
+	ExecServerRcon(context.Context, uuid.UUID, uuid.UUID, string) (*model.RconCommand, error)
+	ListServerRconCommands(context.Context, uuid.UUID) (model.RconCommands, error)
+
+	ListServerHistory(context.Context, controller.ListServerHistoryInput) ([]model.AuditEntry, error)
+
+	GetServerSchedule(context.Context, uuid.UUID, time.Time, time.Time) (*model.Schedule, error)
+
+	ListServerExecutions(context.Context, controller.ListExecutionsInput) ([]model.Execution, error)
+	ListExecutions(context.Context, controller.ListExecutionsInput) ([]model.Execution, error)
+	RetryExecutionJob(context.Context, uuid.UUID) (*model.Job, error)
 }
 
 type ISessionMiddleware interface {
@@ -77,6 +113,8 @@ func NewAPI(
 
 			router.Method(http.MethodPatch, "/server", PatchServer{API: api})
 			router.Method(http.MethodPost, "/server/archive", ArchiveServer{API: api})
+			router.Method(http.MethodPost, "/server/decommission", DecommissionServer{API: api})
+			router.Method(http.MethodPost, "/server/unarchive", UnarchiveServer{API: api})
 			router.Method(http.MethodPost, "/server/wipe", WipeServer{API: api})
 
 			router.Method(http.MethodPost, "/server/tags", AddServerTags{API: api})
@@ -84,6 +122,8 @@ func NewAPI(
 
 			router.Method(http.MethodPost, "/server/events", AddServerEvents{API: api})
 			router.Method(http.MethodDelete, "/server/events", RemoveServerEvents{API: api})
+			router.Method(http.MethodPost, "/server/blackouts", AddServerBlackouts{API: api})
+			router.Method(http.MethodDelete, "/server/blackouts", RemoveServerBlackouts{API: api})
 
 			router.Method(http.MethodPost, "/server/moderators", AddServerModerators{API: api})
 			router.Method(http.MethodDelete, "/server/moderators", RemoveServerModerators{API: api})
@@ -91,22 +131,45 @@ func NewAPI(
 			router.Method(http.MethodPost, "/server/owners", AddServerOwners{API: api})
 			router.Method(http.MethodDelete, "/server/owners", RemoveServerOwners{API: api})
 
-			router.Group(func(router chi.Router) {
-				router.Use(middleware.Timeout(30 * time.Minute))
+			router.Method(http.MethodPost, "/server/bans", AddServerBans{API: api})
+			router.Method(http.MethodDelete, "/server/bans", RemoveServerBans{API: api})
+
+			router.Method(http.MethodGet, "/plugins", Plugins{API: api})
+			router.Method(http.MethodPost, "/server/plugins", EnableServerPlugins{API: api})
+			router.Method(http.MethodDelete, "/server/plugins", DisableServerPlugins{API: api})
+
+			router.Method(http.MethodPut, fmt.Sprintf("/server/{%s}/countdown", serverIDParam), PutServerCountdown{API: api})
+
+			router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/players", serverIDParam), ServerPlayers{API: api})
+			router.Method(http.MethodPost, fmt.Sprintf("/server/{%s}/players/kick", serverIDParam), KickServerPlayer{API: api})
+
+			router.Method(http.MethodPost, fmt.Sprintf("/server/{%s}/rcon", serverIDParam), ExecServerRcon{API: api})
+			router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/rcon", serverIDParam), ServerRconCommands{API: api})
+
+			router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/history", serverIDParam), ServerHistory{API: api})
+			router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/schedule", serverIDParam), GetServerSchedule{API: api})
+			router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/executions", serverIDParam), ServerExecutions{API: api})
+
+			router.Method(http.MethodGet, "/executions", Executions{API: api})
+			router.Method(http.MethodPost, fmt.Sprintf("/executions/{%s}/retry", executionIDParam), RetryExecution{API: api})
 
-				router.Method(http.MethodPost, "/server/start", StartServer{API: api})
-			})
+			router.Method(http.MethodPost, "/server", CreateServer{API: api})
+			router.Method(http.MethodPost, "/server/start", StartServer{API: api})
+			router.Method(http.MethodPost, "/server/stop", StopServer{API: api})
+			router.Method(http.MethodPost, fmt.Sprintf("/server/{%s}/clone", serverIDParam), CloneServer{API: api})
 
-			router.Group(func(router chi.Router) {
-				router.Use(middleware.Timeout(10 * time.Minute))
+			router.Method(http.MethodPost, "/templates", CreateServerTemplate{API: api})
+			router.Method(http.MethodGet, "/templates", ServerTemplates{API: api})
+			router.Method(http.MethodGet, fmt.Sprintf("/templates/{%s}", templateIDParam), GetServerTemplate{API: api})
+			router.Method(http.MethodDelete, fmt.Sprintf("/templates/{%s}", templateIDParam), DeleteServerTemplate{API: api})
+			router.Method(http.MethodPost, fmt.Sprintf("/templates/{%s}/server", templateIDParam), CreateServerFromTemplate{API: api})
 
-				router.Method(http.MethodPost, "/server", CreateServer{API: api})
-				router.Method(http.MethodPost, "/server/stop", StopServer{API: api})
-			})
+			router.Method(http.MethodGet, fmt.Sprintf("/jobs/{%s}", jobIDParam), GetJob{API: api})
 		})
 
 		router.Method(http.MethodGet, "/servers", Servers{API: api})
 		router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}", serverIDParam), GetServer{API: api})
+		router.Method(http.MethodGet, fmt.Sprintf("/server/{%s}/schedule/public", serverIDParam), GetPublicServerSchedule{API: api})
 	})
 
 	return &api
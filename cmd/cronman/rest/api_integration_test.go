@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -47,7 +48,7 @@ func TestCreateServerAuthz(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
@@ -73,7 +74,7 @@ func TestCreateServerAuthz(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
@@ -101,7 +102,7 @@ func TestCreateServerAuthz(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
@@ -137,7 +138,7 @@ func TestStartServer(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
@@ -221,7 +222,7 @@ func TestStopServer(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
@@ -322,7 +323,7 @@ func TestWipeServer(t *testing.T) {
 		allocationID, err := rand.GenerateString(16)
 		require.Nil(t, err)
 
-		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind) (*server.CreateInstanceOutput, error) {
+		suite.serverManager.SetCreateInstanceHandler(func(context.Context, model.InstanceKind, uuid.UUID) (*server.CreateInstanceOutput, error) {
 			return &server.CreateInstanceOutput{
 					Instance: types.Instance{
 						InstanceId: aws.String(instanceID),
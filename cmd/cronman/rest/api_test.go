@@ -4,15 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/tjper/rustcron/cmd/cronman/controller"
+	cronmanerrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
 	"github.com/tjper/rustcron/internal/healthz"
 	ihttp "github.com/tjper/rustcron/internal/http"
+	imodel "github.com/tjper/rustcron/internal/model"
+	"github.com/tjper/rustcron/internal/session"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -88,6 +97,7 @@ func TestCreateServerInputValidation(t *testing.T) {
 					Options: map[string]interface{}{
 						"server.tags": "weekly,vanilla,NA",
 					},
+					Countdown: model.DefaultCountdown,
 					Wipes: model.Wipes{
 						{MapSeed: 1000, MapSalt: 2000, Kind: model.WipeKindFull},
 					},
@@ -177,6 +187,42 @@ func TestCreateServerInputValidation(t *testing.T) {
 				status: http.StatusBadRequest,
 			},
 		},
+		"schedule without stop": {
+			req: CreateServerBody{
+				Name:         "a-valid-server-name",
+				InstanceKind: model.InstanceKindSmall,
+				MaxPlayers:   200,
+				MapSize:      3000,
+				MapSeed:      1000,
+				MapSalt:      2000,
+				TickRate:     30,
+				RconPassword: "a-valid-rcon-password",
+				Description:  "a-valid-description",
+				URL:          "https://rustpm.com",
+				Background:   model.BackgroundKindForest,
+				BannerURL:    "https://rustpm.com/banner",
+				Region:       model.RegionUsEast,
+				Options: map[string]interface{}{
+					"server.tags": "weekly,vanilla,NA",
+				},
+				Events: Events{
+					{Schedule: "40 11 * * *", Kind: model.EventKindStart},
+					{Schedule: "0 12 * * *", Kind: model.EventKindLive},
+				},
+				Moderators: Moderators{
+					{SteamID: "87672208073022742"},
+				},
+				Owners: Owners{
+					{SteamID: "76561197962911631"},
+				},
+				Tags: Tags{
+					{Description: "1-valid-tag", Icon: model.IconKindCalendarDay, Value: "1-valid-tag-value"},
+				},
+			},
+			exp: expected{
+				status: http.StatusBadRequest,
+			},
+		},
 		"owners and moderators collision": {
 			req: CreateServerBody{
 				Name:         "a-valid-server-name",
@@ -218,22 +264,16 @@ func TestCreateServerInputValidation(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			// HTTP endpoint being tested returns an accepted (202) status code and
-			// continues to process data critical to test. The done channel allows
-			// the test to wait for async processing to complete for the test ends.
-			done := make(chan struct{})
-
+			jobID := uuid.New()
 			controller := NewControllerMock(
-				WithCreateServer(func(ctx context.Context, server model.Server) (*model.DormantServer, error) {
-					defer func() {
-						close(done)
-					}()
-
+				WithCreateServerJob(func(ctx context.Context, server model.Server) (*model.Job, error) {
 					test.exp.server.ID = server.ID
 
 					require.Exactly(t, test.exp.server, server, "created server not as expected")
-					return &model.DormantServer{
-						Server: server,
+					return &model.Job{
+						Model:    imodel.Model{ID: jobID},
+						Kind:     model.JobKindCreateServer,
+						ServerID: server.ID,
 					}, nil
 				}),
 			)
@@ -266,8 +306,1811 @@ func TestCreateServerInputValidation(t *testing.T) {
 			require.Equal(t, test.exp.status, resp.StatusCode)
 
 			if resp.StatusCode == http.StatusAccepted {
-				<-done
+				var created CreateServerResponse
+				err := json.NewDecoder(resp.Body).Decode(&created)
+				require.Nil(t, err)
+				require.Equal(t, test.exp.server.ID, created.ID)
+				require.Equal(t, jobID, created.JobID)
+			}
+		})
+	}
+}
+
+func TestExecServerRcon(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	userID := uuid.New()
+
+	type expected struct {
+		status int
+		resp   *RconCommand
+	}
+	tests := map[string]struct {
+		path    string
+		body    interface{}
+		execErr error
+		exp     expected
+	}{
+		"valid command": {
+			path: fmt.Sprintf("/v1/server/%s/rcon", serverID),
+			body: ExecServerRconBody{Command: "global.serverinfo"},
+			exp: expected{
+				status: http.StatusOK,
+				resp: &RconCommand{
+					ServerID:     serverID,
+					UserID:       userID,
+					Command:      "global.serverinfo",
+					Response:     "{}",
+					ResponseType: "Generic",
+				},
+			},
+		},
+		"missing command": {
+			path: fmt.Sprintf("/v1/server/%s/rcon", serverID),
+			body: ExecServerRconBody{},
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"invalid server ID": {
+			path: "/v1/server/not-a-uuid/rcon",
+			body: ExecServerRconBody{Command: "global.serverinfo"},
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"server DNE": {
+			path:    fmt.Sprintf("/v1/server/%s/rcon", serverID),
+			body:    ExecServerRconBody{Command: "global.serverinfo"},
+			execErr: cronmanerrors.ErrServerDNE,
+			exp:     expected{status: http.StatusNotFound},
+		},
+		"server not live": {
+			path:    fmt.Sprintf("/v1/server/%s/rcon", serverID),
+			body:    ExecServerRconBody{Command: "global.serverinfo"},
+			execErr: fmt.Errorf("while retrieving live server: %w", cronmanerrors.ErrServerNotLive),
+			exp:     expected{status: http.StatusConflict},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithExecServerRcon(func(_ context.Context, id uuid.UUID, user uuid.UUID, command string) (*model.RconCommand, error) {
+					if test.execErr != nil {
+						return nil, test.execErr
+					}
+					return &model.RconCommand{
+						ServerID:     id,
+						UserID:       user,
+						Command:      command,
+						Response:     "{}",
+						ResponseType: "Generic",
+					}, nil
+				}),
+			)
+
+			api := newAdminAPI(controller, userID)
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, test.path, buf)
+
+			api.Mux.ServeHTTP(rr, req)
+
+			resp := rr.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, test.exp.status, resp.StatusCode)
+			if test.exp.resp == nil {
+				return
+			}
+
+			var command RconCommand
+			err = json.NewDecoder(resp.Body).Decode(&command)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.resp, command)
+		})
+	}
+}
+
+func TestKickServerPlayer(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+
+	tests := map[string]struct {
+		path    string
+		body    interface{}
+		kickErr error
+		exp     int
+	}{
+		"kick": {
+			path: fmt.Sprintf("/v1/server/%s/players/kick", serverID),
+			body: KickServerPlayerBody{SteamID: "76561197962911631", Reason: "being rude"},
+			exp:  http.StatusNoContent,
+		},
+		"invalid steam ID": {
+			path: fmt.Sprintf("/v1/server/%s/players/kick", serverID),
+			body: KickServerPlayerBody{SteamID: "tjper", Reason: "being rude"},
+			exp:  http.StatusBadRequest,
+		},
+		"missing reason": {
+			path: fmt.Sprintf("/v1/server/%s/players/kick", serverID),
+			body: KickServerPlayerBody{SteamID: "76561197962911631"},
+			exp:  http.StatusBadRequest,
+		},
+		"player not found": {
+			path:    fmt.Sprintf("/v1/server/%s/players/kick", serverID),
+			body:    KickServerPlayerBody{SteamID: "76561197962911631", Reason: "being rude"},
+			kickErr: fmt.Errorf("while kicking player: %w", rcon.ErrPlayerNotFound),
+			exp:     http.StatusNotFound,
+		},
+		"server not live": {
+			path:    fmt.Sprintf("/v1/server/%s/players/kick", serverID),
+			body:    KickServerPlayerBody{SteamID: "76561197962911631", Reason: "being rude"},
+			kickErr: cronmanerrors.ErrServerNotLive,
+			exp:     http.StatusConflict,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithKickServerPlayer(func(_ context.Context, id uuid.UUID, steamID string, reason string) error {
+					require.Equal(t, serverID, id)
+					return test.kickErr
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, test.path, buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+func TestServerPlayers(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	controller := NewControllerMock(
+		WithListServerPlayers(func(_ context.Context, id uuid.UUID) ([]rcon.Player, error) {
+			if id != serverID {
+				return nil, cronmanerrors.ErrServerDNE
+			}
+			return []rcon.Player{
+				{SteamID: "76561197962911631", DisplayName: "tjper", Ping: 42, Address: "10.0.0.1:1234"},
+			}, nil
+		}),
+	)
+	api := newAdminAPI(controller, uuid.New())
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/server/%s/players", serverID), nil)
+	api.Mux.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var players []Player
+	err := json.NewDecoder(rr.Body).Decode(&players)
+	require.Nil(t, err)
+	require.Equal(t, []Player{{SteamID: "76561197962911631", DisplayName: "tjper", Ping: 42}}, players)
+
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/server/%s/players", uuid.New()), nil)
+	api.Mux.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAddServerBans(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+
+	tests := map[string]struct {
+		body AddServerBansBody
+		exp  int
+	}{
+		"valid bans": {
+			body: AddServerBansBody{
+				ServerID: serverID,
+				Bans:     Bans{{SteamID: "76561197962911631", Reason: "cheating"}},
+			},
+			exp: http.StatusCreated,
+		},
+		"missing reason": {
+			body: AddServerBansBody{
+				ServerID: serverID,
+				Bans:     Bans{{SteamID: "76561197962911631"}},
+			},
+			exp: http.StatusBadRequest,
+		},
+		"invalid steam ID": {
+			body: AddServerBansBody{
+				ServerID: serverID,
+				Bans:     Bans{{SteamID: "76561197962911631; rm -rf", Reason: "cheating"}},
+			},
+			exp: http.StatusBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithAddServerBans(func(_ context.Context, id uuid.UUID, bans model.Bans) error {
+					require.Equal(t, serverID, id)
+					require.Equal(t, test.body.Bans.ToModelBans(), bans)
+					return nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/server/bans", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+func TestAddServerEvents(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	runAt := time.Date(2022, time.June, 24, 18, 0, 0, 0, time.UTC)
+	friday := time.Friday
+
+	config := &model.ServerConfig{
+		TimeZone: "UTC",
+		Events: []model.EventConfig{
+			{Schedule: "0 17 * * *", Kind: model.EventKindStart},
+			{Schedule: "0 18 * * *", Kind: model.EventKindLive},
+			{Schedule: "0 2 * * *", Kind: model.EventKindStop},
+		},
+	}
+
+	type expected struct {
+		status     int
+		warnings   int
+		violations []model.ViolationKind
+	}
+	tests := map[string]struct {
+		body AddServerEventsBody
+		exp  expected
+	}{
+		"recurring event": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "0 10 * * *", Weekday: &friday, Kind: model.EventKindMapWipe}},
+			},
+			exp: expected{status: http.StatusCreated},
+		},
+		"one-off event": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{RunAt: &runAt, Kind: model.EventKindStart}},
+			},
+			exp: expected{status: http.StatusCreated},
+		},
+		"wipe while running": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "0 20 * * *", Weekday: &friday, Kind: model.EventKindMapWipe}},
+			},
+			exp: expected{status: http.StatusCreated, warnings: 1},
+		},
+		"live while stopped": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "0 12 * * *", Kind: model.EventKindLive}},
+			},
+			exp: expected{
+				status:     http.StatusBadRequest,
+				violations: []model.ViolationKind{model.ViolationKindOutOfOrder, model.ViolationKindOutOfOrder},
+			},
+		},
+		"overlap": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "0 17 * * *", Kind: model.EventKindMapWipe}},
+			},
+			exp: expected{
+				status:     http.StatusBadRequest,
+				violations: []model.ViolationKind{model.ViolationKindOverlap},
+			},
+		},
+		"schedule and run at": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "0 18 * * *", RunAt: &runAt, Kind: model.EventKindStart}},
+			},
+			exp: expected{status: http.StatusBadRequest},
+		},
+		"weekday and run at": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Weekday: &friday, RunAt: &runAt, Kind: model.EventKindStart}},
+			},
+			exp: expected{status: http.StatusBadRequest},
+		},
+		"neither schedule nor run at": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Kind: model.EventKindStart}},
+			},
+			exp: expected{status: http.StatusBadRequest},
+		},
+		"invalid schedule": {
+			body: AddServerEventsBody{
+				ServerID: serverID,
+				Events:   Events{{Schedule: "every friday", Kind: model.EventKindStart}},
+			},
+			exp: expected{status: http.StatusBadRequest},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithAddServerEvents(func(_ context.Context, id uuid.UUID, events model.Events) (model.ScheduleViolations, error) {
+					require.Equal(t, serverID, id)
+					require.Equal(t, test.body.Events.ToModelEvents(), events)
+
+					// The schedule is validated including the server's
+					// existing events, as the controller does.
+					server := config.Server(id)
+					server.Events = append(server.Events, events...)
+					return validateScheduleMock(server)
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/server/events", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+			require.Len(t, rr.Header().Values("Warning"), test.exp.warnings)
+
+			if test.exp.violations == nil {
+				return
+			}
+			var resp ScheduleErrorResponse
+			err = json.NewDecoder(rr.Body).Decode(&resp)
+			require.Nil(t, err)
+
+			kinds := make([]model.ViolationKind, 0, len(resp.Violations))
+			for _, violation := range resp.Violations {
+				require.Equal(t, model.ViolationSeverityError, violation.Severity)
+				kinds = append(kinds, violation.Kind)
+			}
+			require.Equal(t, test.exp.violations, kinds)
+		})
+	}
+}
+
+func TestRemoveServerEvents(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+
+	start := model.Event{Schedule: "0 17 * * *", Kind: model.EventKindStart, ServerID: serverID}
+	start.ID = uuid.New()
+	live := model.Event{Schedule: "0 18 * * *", Kind: model.EventKindLive, ServerID: serverID}
+	live.ID = uuid.New()
+	stop := model.Event{Schedule: "0 2 * * *", Kind: model.EventKindStop, ServerID: serverID}
+	stop.ID = uuid.New()
+
+	events := model.Events{start, live, stop}
+
+	type expected struct {
+		status     int
+		violations []model.ViolationKind
+	}
+	tests := map[string]struct {
+		body RemoveServerEventsBody
+		exp  expected
+	}{
+		"remove live event": {
+			body: RemoveServerEventsBody{ServerID: serverID, EventIDs: []uuid.UUID{live.ID}},
+			exp:  expected{status: http.StatusNoContent},
+		},
+		"remove only stop event": {
+			body: RemoveServerEventsBody{ServerID: serverID, EventIDs: []uuid.UUID{stop.ID}},
+			exp: expected{
+				status:     http.StatusBadRequest,
+				violations: []model.ViolationKind{model.ViolationKindNoStop, model.ViolationKindOutOfOrder},
+			},
+		},
+		"server dne": {
+			body: RemoveServerEventsBody{ServerID: uuid.New(), EventIDs: []uuid.UUID{live.ID}},
+			exp:  expected{status: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithRemoveServerEvents(func(_ context.Context, id uuid.UUID, ids []uuid.UUID) (model.ScheduleViolations, error) {
+					if id != serverID {
+						return nil, cronmanerrors.ErrServerDNE
+					}
+
+					server := model.Server{TimeZone: "UTC"}
+					for _, event := range events {
+						if event.ID != ids[0] {
+							server.Events = append(server.Events, event)
+						}
+					}
+					return validateScheduleMock(server)
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/server/events", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.violations == nil {
+				return
+			}
+			var resp ScheduleErrorResponse
+			err = json.NewDecoder(rr.Body).Decode(&resp)
+			require.Nil(t, err)
+
+			kinds := make([]model.ViolationKind, 0, len(resp.Violations))
+			for _, violation := range resp.Violations {
+				kinds = append(kinds, violation.Kind)
 			}
+			require.Equal(t, test.exp.violations, kinds)
+		})
+	}
+}
+
+func TestAddServerBlackouts(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	startsAt := time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		body AddServerBlackoutsBody
+		exp  int
+	}{
+		"every kind": {
+			body: AddServerBlackoutsBody{
+				ServerID:  serverID,
+				Blackouts: Blackouts{{StartsAt: startsAt, EndsAt: endsAt, Reason: "holiday"}},
+			},
+			exp: http.StatusCreated,
+		},
+		"single kind": {
+			body: AddServerBlackoutsBody{
+				ServerID:  serverID,
+				Blackouts: Blackouts{{StartsAt: startsAt, EndsAt: endsAt, Kind: model.EventKindMapWipe}},
+			},
+			exp: http.StatusCreated,
+		},
+		"unknown kind": {
+			body: AddServerBlackoutsBody{
+				ServerID:  serverID,
+				Blackouts: Blackouts{{StartsAt: startsAt, EndsAt: endsAt, Kind: "restart"}},
+			},
+			exp: http.StatusBadRequest,
+		},
+		"ends before start": {
+			body: AddServerBlackoutsBody{
+				ServerID:  serverID,
+				Blackouts: Blackouts{{StartsAt: endsAt, EndsAt: startsAt}},
+			},
+			exp: http.StatusBadRequest,
+		},
+		"missing start": {
+			body: AddServerBlackoutsBody{
+				ServerID:  serverID,
+				Blackouts: Blackouts{{EndsAt: endsAt}},
+			},
+			exp: http.StatusBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithAddServerBlackouts(func(_ context.Context, id uuid.UUID, blackouts model.Blackouts) error {
+					require.Equal(t, serverID, id)
+					require.Equal(t, test.body.Blackouts.ToModelBlackouts(), blackouts)
+					return nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/server/blackouts", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+// --- helpers ---
+
+// newAdminAPI creates an API where each request is made by an admin with the
+// specified userID.
+func newAdminAPI(controller IController, userID uuid.UUID) *API {
+	injectSession := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sess := session.New("session-id", session.User{ID: userID, Role: session.RoleAdmin}, time.Hour)
+			next.ServeHTTP(w, r.WithContext(session.WithSession(r.Context(), sess)))
+		})
+	}
+
+	sessionMiddleware := ihttp.NewSessionMiddlewareMock(
+		ihttp.WithInjectSessionIntoCtx(injectSession),
+		ihttp.WithTouch(ihttp.SkipMiddleware),
+		ihttp.WithHasRole(ihttp.SkipHasRoleMiddleware),
+	)
+
+	return NewAPI(
+		zap.NewNop(),
+		controller,
+		sessionMiddleware,
+		healthz.NewHTTP(),
+	)
+}
+
+// validateScheduleMock validates the schedule of the server as the controller
+// does, retrieving the schedule's warnings.
+func validateScheduleMock(server model.Server) (model.ScheduleViolations, error) {
+	violations, err := server.ValidateSchedule(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	return violations.Warnings(), nil
+}
+
+func TestEnableServerPlugins(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	pluginID := uuid.New()
+
+	tests := map[string]struct {
+		body      EnableServerPluginsBody
+		enableErr error
+		exp       int
+	}{
+		"enable": {
+			body: EnableServerPluginsBody{ServerID: serverID, PluginIDs: []uuid.UUID{pluginID}},
+			exp:  http.StatusNoContent,
+		},
+		"no plugins": {
+			body: EnableServerPluginsBody{ServerID: serverID},
+			exp:  http.StatusBadRequest,
+		},
+		"plugin DNE": {
+			body:      EnableServerPluginsBody{ServerID: serverID, PluginIDs: []uuid.UUID{pluginID}},
+			enableErr: fmt.Errorf("list plugins; %w", cronmanerrors.ErrPluginDNE),
+			exp:       http.StatusNotFound,
+		},
+		"server DNE": {
+			body:      EnableServerPluginsBody{ServerID: serverID, PluginIDs: []uuid.UUID{pluginID}},
+			enableErr: fmt.Errorf("get server; %w", cronmanerrors.ErrServerDNE),
+			exp:       http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithEnableServerPlugins(func(_ context.Context, id uuid.UUID, ids []uuid.UUID) error {
+					require.Equal(t, serverID, id)
+					require.Equal(t, []uuid.UUID{pluginID}, ids)
+					return test.enableErr
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/server/plugins", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+func TestPutServerCountdown(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	path := fmt.Sprintf("/v1/server/%s/countdown", serverID)
+
+	tests := map[string]struct {
+		body      interface{}
+		updateErr error
+		exp       int
+	}{
+		"update": {
+			body: Countdown{
+				Warnings:        []uint32{900, 60},
+				FinalSeconds:    5,
+				WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}.",
+				FinalTemplate:   "{{.Seconds}}...",
+			},
+			exp: http.StatusNoContent,
+		},
+		"warnings without final countdown": {
+			body: Countdown{
+				Warnings:        []uint32{300},
+				WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}.",
+			},
+			exp: http.StatusNoContent,
+		},
+		"missing warning template": {
+			body: Countdown{Warnings: []uint32{300}},
+			exp:  http.StatusBadRequest,
+		},
+		"final seconds too large": {
+			body: Countdown{FinalSeconds: 61, FinalTemplate: "{{.Seconds}}..."},
+			exp:  http.StatusBadRequest,
+		},
+		"invalid template": {
+			body: Countdown{Warnings: []uint32{300}, WarningTemplate: "{{.Unknown}}"},
+			exp:  http.StatusBadRequest,
+		},
+		"server dne": {
+			body: Countdown{
+				Warnings:        []uint32{300},
+				WarningTemplate: "{{.Server}} will be {{.Action}} in {{.Remaining}}.",
+			},
+			updateErr: cronmanerrors.ErrServerDNE,
+			exp:       http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithUpdateServerCountdown(func(_ context.Context, id uuid.UUID, _ model.Countdown) error {
+					require.Equal(t, serverID, id)
+					return test.updateErr
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(test.body)
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, path, buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+func TestStartServerJob(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	jobID := uuid.New()
+
+	type expected struct {
+		status int
+		job    *Job
+	}
+	tests := map[string]struct {
+		server interface{}
+		exp    expected
+	}{
+		"dormant": {
+			server: &model.DormantServer{
+				Server: model.Server{Lifecycle: model.LifecycleIdle},
+			},
+			exp: expected{
+				status: http.StatusAccepted,
+				job: &Job{
+					ID:       jobID,
+					Kind:     model.JobKindStartServer,
+					ServerID: serverID,
+					Status:   model.JobStatusPending,
+					Steps:    2,
+				},
+			},
+		},
+		"failed": {
+			server: &model.DormantServer{
+				Server: model.Server{Lifecycle: model.LifecycleFailed},
+			},
+			exp: expected{
+				status: http.StatusAccepted,
+				job: &Job{
+					ID:       jobID,
+					Kind:     model.JobKindStartServer,
+					ServerID: serverID,
+					Status:   model.JobStatusPending,
+					Steps:    2,
+				},
+			},
+		},
+		"creating": {
+			server: &model.DormantServer{
+				Server: model.Server{Lifecycle: model.LifecycleCreating},
+			},
+			exp: expected{status: http.StatusConflict},
+		},
+		"live": {
+			server: &model.LiveServer{
+				Server: model.Server{Lifecycle: model.LifecycleIdle},
+			},
+			exp: expected{status: http.StatusConflict},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithGetServer(func(_ context.Context, id uuid.UUID) (interface{}, error) {
+					require.Equal(t, serverID, id)
+					return test.server, nil
+				}),
+				WithStartServerJob(func(_ context.Context, id uuid.UUID) (*model.Job, error) {
+					job := model.NewStartServerJob(id)
+					job.ID = jobID
+					job.Status = model.JobStatusPending
+					return job, nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(map[string]interface{}{"serverId": serverID})
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/server/start", buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.job == nil {
+				return
+			}
+			var job Job
+			err = json.NewDecoder(rr.Body).Decode(&job)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.job, job)
+		})
+	}
+}
+
+func TestArchivedServerJobs(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	jobID := uuid.New()
+
+	type expected struct {
+		status int
+		job    *Job
+	}
+	tests := map[string]struct {
+		path string
+		err  error
+		exp  expected
+	}{
+		"decommission": {
+			path: "/v1/server/decommission",
+			exp: expected{
+				status: http.StatusAccepted,
+				job: &Job{
+					ID:       jobID,
+					Kind:     model.JobKindDecommissionServer,
+					ServerID: serverID,
+					Status:   model.JobStatusPending,
+					Steps:    1,
+				},
+			},
+		},
+		"decommission not archived": {
+			path: "/v1/server/decommission",
+			err:  cronmanerrors.ErrServerNotArchived,
+			exp:  expected{status: http.StatusConflict},
+		},
+		"decommission transitioning": {
+			path: "/v1/server/decommission",
+			err:  cronmanerrors.ErrServerTransition,
+			exp:  expected{status: http.StatusConflict},
+		},
+		"decommission dne": {
+			path: "/v1/server/decommission",
+			err:  cronmanerrors.ErrServerDNE,
+			exp:  expected{status: http.StatusNotFound},
+		},
+		"unarchive": {
+			path: "/v1/server/unarchive",
+			exp: expected{
+				status: http.StatusAccepted,
+				job: &Job{
+					ID:       jobID,
+					Kind:     model.JobKindUnarchiveServer,
+					ServerID: serverID,
+					Status:   model.JobStatusPending,
+					Steps:    1,
+				},
+			},
+		},
+		"unarchive not archived": {
+			path: "/v1/server/unarchive",
+			err:  cronmanerrors.ErrServerNotArchived,
+			exp:  expected{status: http.StatusConflict},
+		},
+		"unarchive dne": {
+			path: "/v1/server/unarchive",
+			err:  cronmanerrors.ErrServerDNE,
+			exp:  expected{status: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			enqueue := func(job *model.Job) (*model.Job, error) {
+				if test.err != nil {
+					return nil, test.err
+				}
+				job.ID = jobID
+				job.Status = model.JobStatusPending
+				return job, nil
+			}
+			controller := NewControllerMock(
+				WithDecommissionServerJob(func(_ context.Context, id uuid.UUID) (*model.Job, error) {
+					require.Equal(t, serverID, id)
+					return enqueue(model.NewDecommissionServerJob(id))
+				}),
+				WithUnarchiveServerJob(func(_ context.Context, id uuid.UUID) (*model.Job, error) {
+					require.Equal(t, serverID, id)
+					return enqueue(model.NewUnarchiveServerJob(id))
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			buf := new(bytes.Buffer)
+			err := json.NewEncoder(buf).Encode(map[string]interface{}{"serverId": serverID})
+			require.Nil(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, test.path, buf)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.job == nil {
+				return
+			}
+			var job Job
+			err = json.NewDecoder(rr.Body).Decode(&job)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.job, job)
+		})
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	serverID := uuid.New()
+	createdAt := time.Date(2022, time.June, 12, 18, 0, 0, 0, time.UTC)
+
+	type expected struct {
+		status int
+		job    *Job
+	}
+	tests := map[string]struct {
+		path   string
+		job    *model.Job
+		getErr error
+		exp    expected
+	}{
+		"running": {
+			path: fmt.Sprintf("/v1/jobs/%s", jobID),
+			job: &model.Job{
+				Model:          imodel.Model{ID: jobID, At: imodel.At{CreatedAt: createdAt}},
+				Kind:           model.JobKindStartServer,
+				ServerID:       serverID,
+				Status:         model.JobStatusRunning,
+				Step:           "making server live",
+				StepsCompleted: 1,
+				Steps:          2,
+				Attempts:       1,
+				StartedAt:      &createdAt,
+			},
+			exp: expected{
+				status: http.StatusOK,
+				job: &Job{
+					ID:             jobID,
+					Kind:           model.JobKindStartServer,
+					ServerID:       serverID,
+					Status:         model.JobStatusRunning,
+					Step:           "making server live",
+					StepsCompleted: 1,
+					Steps:          2,
+					Attempts:       1,
+					CreatedAt:      createdAt,
+					StartedAt:      &createdAt,
+				},
+			},
+		},
+		"failed": {
+			path: fmt.Sprintf("/v1/jobs/%s", jobID),
+			job: &model.Job{
+				Model:      imodel.Model{ID: jobID, At: imodel.At{CreatedAt: createdAt}},
+				Kind:       model.JobKindStopServer,
+				ServerID:   serverID,
+				Status:     model.JobStatusFailed,
+				Steps:      1,
+				Error:      "while stopping server: rcon unavailable",
+				Attempts:   1,
+				FinishedAt: &createdAt,
+			},
+			exp: expected{
+				status: http.StatusOK,
+				job: &Job{
+					ID:         jobID,
+					Kind:       model.JobKindStopServer,
+					ServerID:   serverID,
+					Status:     model.JobStatusFailed,
+					Steps:      1,
+					Error:      "while stopping server: rcon unavailable",
+					Attempts:   1,
+					CreatedAt:  createdAt,
+					FinishedAt: &createdAt,
+				},
+			},
+		},
+		"job dne": {
+			path:   fmt.Sprintf("/v1/jobs/%s", jobID),
+			getErr: cronmanerrors.ErrJobDNE,
+			exp:    expected{status: http.StatusNotFound},
+		},
+		"invalid job ID": {
+			path: "/v1/jobs/not-a-uuid",
+			exp:  expected{status: http.StatusBadRequest},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithGetJob(func(_ context.Context, id uuid.UUID) (*model.Job, error) {
+					require.Equal(t, jobID, id)
+					return test.job, test.getErr
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.job == nil {
+				return
+			}
+			var job Job
+			err := json.NewDecoder(rr.Body).Decode(&job)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.job, job)
+		})
+	}
+}
+
+func TestCloneServer(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	jobID := uuid.New()
+	path := fmt.Sprintf("/v1/server/%s/clone", serverID)
+
+	source := model.ServerConfig{
+		Name:         "source",
+		InstanceKind: model.InstanceKindStandard,
+		MaxPlayers:   200,
+		MapSeed:      1,
+		MapSalt:      2,
+		Region:       model.RegionUsEast,
+		Owners:       []string{"owner"},
+	}
+
+	type expected struct {
+		status int
+		config *model.ServerConfig
+	}
+	tests := map[string]struct {
+		body   string
+		getErr error
+		exp    expected
+	}{
+		"no overrides": {
+			exp: expected{status: http.StatusAccepted, config: &source},
+		},
+		"overrides": {
+			body: `{"name": "clone", "region": "usWest", "timeZone": "America/Los_Angeles", "mapSeed": 3}`,
+			exp: expected{
+				status: http.StatusAccepted,
+				config: func() *model.ServerConfig {
+					config := source.Clone()
+					config.Name = "clone"
+					config.Region = model.RegionUsWest
+					config.TimeZone = "America/Los_Angeles"
+					config.MapSeed = 3
+					return &config
+				}(),
+			},
+		},
+		"invalid override": {
+			body: `{"url": "not-a-url"}`,
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"invalid time zone override": {
+			body: `{"timeZone": "Local"}`,
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"server dne": {
+			getErr: cronmanerrors.ErrServerDNE,
+			exp:    expected{status: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithGetServerConfig(func(_ context.Context, id uuid.UUID) (*model.ServerConfig, error) {
+					require.Equal(t, serverID, id)
+					if test.getErr != nil {
+						return nil, test.getErr
+					}
+					config := source.Clone()
+					return &config, nil
+				}),
+				WithCreateServerJob(func(_ context.Context, server model.Server) (*model.Job, error) {
+					require.NotEqual(t, serverID, server.ID)
+					require.Equal(t, test.exp.config.Server(server.ID), server)
+					return &model.Job{Model: imodel.Model{ID: jobID}}, nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.body))
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.config == nil {
+				return
+			}
+			var resp CreateServerResponse
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			require.Nil(t, err)
+			require.Equal(t, jobID, resp.JobID)
+		})
+	}
+}
+
+func TestCreateServerTemplate(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	templateID := uuid.New()
+
+	body, err := ioutil.ReadFile("testdata/default-body.json")
+	require.Nil(t, err)
+
+	type expected struct {
+		status int
+		name   string
+		config *model.ServerConfig
+	}
+	tests := map[string]struct {
+		body         string
+		sourceEvents []model.EventConfig
+		createErr    error
+		exp          expected
+	}{
+		"from server": {
+			body: fmt.Sprintf(`{"name": "weekly", "serverId": "%s"}`, serverID),
+			exp: expected{
+				status: http.StatusCreated,
+				name:   "weekly",
+				config: &model.ServerConfig{Name: "source"},
+			},
+		},
+		"from body": {
+			body: fmt.Sprintf(`{"name": "weekly", "server": %s}`, body),
+			exp: expected{
+				status: http.StatusCreated,
+				name:   "weekly",
+				config: func() *model.ServerConfig {
+					var b CreateServerBody
+					err := json.Unmarshal(body, &b)
+					require.Nil(t, err)
+					config := b.ToModelServer(uuid.Nil).Config()
+					return &config
+				}(),
+			},
+		},
+		"from server never stopped": {
+			body:         fmt.Sprintf(`{"name": "weekly", "serverId": "%s"}`, serverID),
+			sourceEvents: []model.EventConfig{{Schedule: "0 20 * * *", Kind: model.EventKindStart}},
+			exp:          expected{status: http.StatusBadRequest},
+		},
+		"both sources": {
+			body: fmt.Sprintf(`{"name": "weekly", "serverId": "%s", "server": %s}`, serverID, body),
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"no source": {
+			body: `{"name": "weekly"}`,
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"no name": {
+			body: fmt.Sprintf(`{"serverId": "%s"}`, serverID),
+			exp:  expected{status: http.StatusBadRequest},
+		},
+		"exists": {
+			body:      fmt.Sprintf(`{"name": "weekly", "serverId": "%s"}`, serverID),
+			createErr: cronmanerrors.ErrServerTemplateExists,
+			exp:       expected{status: http.StatusConflict},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithGetServerConfig(func(_ context.Context, id uuid.UUID) (*model.ServerConfig, error) {
+					require.Equal(t, serverID, id)
+					return &model.ServerConfig{Name: "source", Events: test.sourceEvents}, nil
+				}),
+				WithCreateServerTemplate(func(_ context.Context, name string, config model.ServerConfig) (*model.ServerTemplate, error) {
+					if test.createErr != nil {
+						return nil, test.createErr
+					}
+					require.Equal(t, test.exp.name, name)
+					require.Equal(t, *test.exp.config, config)
+					return &model.ServerTemplate{
+						Model:  imodel.Model{ID: templateID},
+						Name:   name,
+						Config: config,
+					}, nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/templates", strings.NewReader(test.body))
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.config == nil {
+				return
+			}
+			var template ServerTemplate
+			err := json.NewDecoder(rr.Body).Decode(&template)
+			require.Nil(t, err)
+			require.Equal(t, templateID, template.ID)
+			require.Equal(t, test.exp.name, template.Name)
+		})
+	}
+}
+
+func TestCreateServerFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	templateID := uuid.New()
+	jobID := uuid.New()
+	path := fmt.Sprintf("/v1/templates/%s/server", templateID)
+
+	template := model.ServerTemplate{
+		Model: imodel.Model{ID: templateID},
+		Name:  "weekly",
+		Config: model.ServerConfig{
+			Name:        "weekly",
+			Description: "weekly wipe",
+			Events: []model.EventConfig{
+				{Schedule: "0 20 * * *", Kind: model.EventKindStart},
+				{Schedule: "0 4 * * *", Kind: model.EventKindStop},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		body   string
+		noStop bool
+		getErr error
+		exp    int
+	}{
+		"create": {
+			body: `{"name": "weekly us west", "region": "usWest"}`,
+			exp:  http.StatusAccepted,
+		},
+		"template never stops server": {
+			body:   `{"name": "weekly us west", "region": "usWest"}`,
+			noStop: true,
+			exp:    http.StatusBadRequest,
+		},
+		"template dne": {
+			getErr: cronmanerrors.ErrServerTemplateDNE,
+			exp:    http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller := NewControllerMock(
+				WithGetServerTemplate(func(_ context.Context, id uuid.UUID) (*model.ServerTemplate, error) {
+					require.Equal(t, templateID, id)
+					if test.getErr != nil {
+						return nil, test.getErr
+					}
+					cloned := template.Clone()
+					if test.noStop {
+						cloned.Config.Events = cloned.Config.Events[:1]
+					}
+					return &cloned, nil
+				}),
+				WithCreateServerJob(func(_ context.Context, server model.Server) (*model.Job, error) {
+					require.Equal(t, "weekly us west", server.Name)
+					require.Equal(t, model.RegionUsWest, server.Region)
+					require.Equal(t, "weekly wipe", server.Description)
+					require.Equal(
+						t,
+						model.Events{
+							{Schedule: "0 20 * * *", Kind: model.EventKindStart},
+							{Schedule: "0 4 * * *", Kind: model.EventKindStop},
+						},
+						server.Events,
+					)
+					return &model.Job{Model: imodel.Model{ID: jobID}}, nil
+				}),
+			)
+			api := newAdminAPI(controller, uuid.New())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.body))
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp, rr.Code)
+		})
+	}
+}
+
+func TestServerHistory(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	userID := uuid.New()
+	createdAt := time.Date(2022, time.June, 18, 18, 0, 0, 0, time.UTC)
+
+	entries := make([]model.AuditEntry, 0, 3)
+	for i := 0; i < 3; i++ {
+		entries = append(entries, model.AuditEntry{
+			ID:       uuid.New(),
+			ServerID: serverID,
+			Actor:    model.NewUserActor(userID, "admin@rustpm.com"),
+			Action:   model.AuditActionUpdateServer,
+			Changes: model.AuditChanges{
+				"rconPassword": {Before: "[redacted]", After: "[redacted]"},
+			},
+			Outcome:   model.AuditOutcomeSucceeded,
+			CreatedAt: createdAt.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+
+	type expected struct {
+		status  int
+		input   *controller.ListServerHistoryInput
+		history *ServerHistoryPage
+	}
+	tests := map[string]struct {
+		query   string
+		listErr error
+		exp     expected
+	}{
+		"defaults": {
+			exp: expected{
+				status: http.StatusOK,
+				input: &controller.ListServerHistoryInput{
+					ServerID: serverID,
+					Actions:  []model.AuditAction{},
+					Limit:    defaultHistoryLimit + 1,
+				},
+				history: &ServerHistoryPage{Entries: AuditEntriesFromModel(entries)},
+			},
+		},
+		"paginated": {
+			query: "?limit=2&offset=4&action=updateServer&action=stopServer",
+			exp: expected{
+				status: http.StatusOK,
+				input: &controller.ListServerHistoryInput{
+					ServerID: serverID,
+					Actions:  []model.AuditAction{model.AuditActionUpdateServer, model.AuditActionStopServer},
+					Limit:    3,
+					Offset:   4,
+				},
+				history: &ServerHistoryPage{
+					Entries:    AuditEntriesFromModel(entries[:2]),
+					NextOffset: func() *int { next := 6; return &next }(),
+				},
+			},
+		},
+		"unknown action": {
+			query: "?action=reticulateSplines",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"limit too large": {
+			query: fmt.Sprintf("?limit=%d", maxHistoryLimit+1),
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"invalid offset": {
+			query: "?offset=-1",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"server dne": {
+			listErr: cronmanerrors.ErrServerDNE,
+			exp:     expected{status: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := NewControllerMock(
+				WithListServerHistory(func(_ context.Context, input controller.ListServerHistoryInput) ([]model.AuditEntry, error) {
+					if test.exp.input != nil {
+						require.Equal(t, *test.exp.input, input)
+					}
+					if test.listErr != nil {
+						return nil, test.listErr
+					}
+					return entries, nil
+				}),
+			)
+			api := newAdminAPI(ctrl, userID)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(
+				http.MethodGet,
+				fmt.Sprintf("/v1/server/%s/history%s", serverID, test.query),
+				nil,
+			)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.history == nil {
+				return
+			}
+			var history ServerHistoryPage
+			err := json.NewDecoder(rr.Body).Decode(&history)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.history, history)
+		})
+	}
+}
+
+func TestExecutions(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	userID := uuid.New()
+	scheduledAt := time.Date(2022, time.June, 23, 18, 0, 0, 0, time.UTC)
+
+	executions := make([]model.Execution, 0, 3)
+	for i := 0; i < 3; i++ {
+		at := scheduledAt.Add(-time.Duration(i) * 24 * time.Hour)
+		finishedAt := at.Add(time.Minute)
+		executions = append(executions, model.Execution{
+			ID:          uuid.New(),
+			EventID:     uuid.New(),
+			ServerID:    serverID,
+			Kind:        model.EventKindMapWipe,
+			Actor:       model.Actor{Kind: model.ActorKindDirector},
+			ScheduledAt: at,
+			StartedAt:   at,
+			FinishedAt:  &finishedAt,
+			Outcome:     model.ExecutionOutcomeFailed,
+			Error:       "while wiping server: instance unavailable",
+		})
+	}
+
+	type expected struct {
+		status int
+		input  *controller.ListExecutionsInput
+		page   *ExecutionsPage
+	}
+	tests := map[string]struct {
+		path    string
+		query   string
+		listErr error
+		exp     expected
+	}{
+		"server defaults": {
+			path: fmt.Sprintf("/v1/server/%s/executions", serverID),
+			exp: expected{
+				status: http.StatusOK,
+				input: &controller.ListExecutionsInput{
+					ServerID: serverID,
+					Kinds:    []model.EventKind{},
+					Limit:    defaultExecutionsLimit + 1,
+				},
+				page: &ExecutionsPage{Executions: ExecutionsFromModel(executions)},
+			},
+		},
+		"server paginated": {
+			path:  fmt.Sprintf("/v1/server/%s/executions", serverID),
+			query: "?limit=2&offset=4&outcome=failed&kind=mapWipe&kind=fullWipe",
+			exp: expected{
+				status: http.StatusOK,
+				input: &controller.ListExecutionsInput{
+					ServerID: serverID,
+					Kinds:    []model.EventKind{model.EventKindMapWipe, model.EventKindFullWipe},
+					Outcome:  model.ExecutionOutcomeFailed,
+					Limit:    3,
+					Offset:   4,
+				},
+				page: &ExecutionsPage{
+					Executions: ExecutionsFromModel(executions[:2]),
+					NextOffset: func() *int { next := 6; return &next }(),
+				},
+			},
+		},
+		"server dne": {
+			path:    fmt.Sprintf("/v1/server/%s/executions", serverID),
+			listErr: cronmanerrors.ErrServerDNE,
+			exp:     expected{status: http.StatusNotFound},
+		},
+		"feed": {
+			path:  "/v1/executions",
+			query: "?outcome=failed",
+			exp: expected{
+				status: http.StatusOK,
+				input: &controller.ListExecutionsInput{
+					Kinds:   []model.EventKind{},
+					Outcome: model.ExecutionOutcomeFailed,
+					Limit:   defaultExecutionsLimit + 1,
+				},
+				page: &ExecutionsPage{Executions: ExecutionsFromModel(executions)},
+			},
+		},
+		"unknown outcome": {
+			path:  "/v1/executions",
+			query: "?outcome=skipped",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"unknown kind": {
+			path:  "/v1/executions",
+			query: "?kind=reticulateSplines",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"limit too large": {
+			path:  "/v1/executions",
+			query: fmt.Sprintf("?limit=%d", maxExecutionsLimit+1),
+			exp:   expected{status: http.StatusBadRequest},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			list := func(_ context.Context, input controller.ListExecutionsInput) ([]model.Execution, error) {
+				if test.exp.input != nil {
+					require.Equal(t, *test.exp.input, input)
+				}
+				if test.listErr != nil {
+					return nil, test.listErr
+				}
+				return executions, nil
+			}
+			ctrl := NewControllerMock(
+				WithListServerExecutions(list),
+				WithListExecutions(list),
+			)
+			api := newAdminAPI(ctrl, userID)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, test.path+test.query, nil)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.page == nil {
+				return
+			}
+			var page ExecutionsPage
+			err := json.NewDecoder(rr.Body).Decode(&page)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.page, page)
+		})
+	}
+}
+
+func TestRetryExecution(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	executionID := uuid.New()
+	job := model.NewStartServerJob(uuid.New())
+	job.Kind = model.JobKindRetryExecution
+
+	type expected struct {
+		status int
+		job    *Job
+	}
+	tests := map[string]struct {
+		path     string
+		retryErr error
+		exp      expected
+	}{
+		"retry": {
+			path: fmt.Sprintf("/v1/executions/%s/retry", executionID),
+			exp: expected{
+				status: http.StatusAccepted,
+				job:    func() *Job { j := JobFromModel(*job); return &j }(),
+			},
+		},
+		"execution dne": {
+			path:     fmt.Sprintf("/v1/executions/%s/retry", executionID),
+			retryErr: cronmanerrors.ErrExecutionDNE,
+			exp:      expected{status: http.StatusNotFound},
+		},
+		"execution not failed": {
+			path:     fmt.Sprintf("/v1/executions/%s/retry", executionID),
+			retryErr: cronmanerrors.ErrExecutionNotFailed,
+			exp:      expected{status: http.StatusConflict},
+		},
+		"invalid id": {
+			path: "/v1/executions/last-night/retry",
+			exp:  expected{status: http.StatusBadRequest},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := NewControllerMock(
+				WithRetryExecutionJob(func(_ context.Context, id uuid.UUID) (*model.Job, error) {
+					require.Equal(t, executionID, id)
+					if test.retryErr != nil {
+						return nil, test.retryErr
+					}
+					return job, nil
+				}),
+			)
+			api := newAdminAPI(ctrl, userID)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, test.path, nil)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+
+			if test.exp.job == nil {
+				return
+			}
+			var res Job
+			err := json.NewDecoder(rr.Body).Decode(&res)
+			require.Nil(t, err)
+			require.Equal(t, *test.exp.job, res)
+		})
+	}
+}
+
+func TestGetServerSchedule(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	from := time.Date(2022, time.June, 16, 12, 0, 0, 0, time.UTC)
+	live := model.Event{Model: imodel.Model{ID: uuid.New()}, Schedule: "0 18 * * *", Kind: model.EventKindLive}
+	stop := model.Event{Model: imodel.Model{ID: uuid.New()}, Schedule: "0 2 * * *", Kind: model.EventKindStop}
+	wipe := model.Event{Model: imodel.Model{ID: uuid.New()}, Schedule: "0 20 * * 4", Kind: model.EventKindMapWipe}
+
+	schedule := &model.Schedule{
+		From: from,
+		To:   from.Add(24 * time.Hour),
+		Occurrences: []model.Occurrence{
+			{Event: live, At: from.Add(6 * time.Hour)},
+			{Event: wipe, At: from.Add(8 * time.Hour)},
+			{Event: stop, At: from.Add(14 * time.Hour)},
+		},
+		OnlineWindows: []model.Window{
+			{StartsAt: from.Add(6 * time.Hour), EndsAt: from.Add(14 * time.Hour)},
+		},
+		NextWipe: &model.Occurrence{Event: wipe, At: from.Add(8 * time.Hour)},
+	}
+
+	type expected struct {
+		status int
+		from   time.Time
+		to     time.Time
+	}
+	tests := map[string]struct {
+		path   string
+		query  string
+		getErr error
+		exp    expected
+	}{
+		"schedule": {
+			path:  "schedule",
+			query: "?from=2022-06-16T12:00:00Z&to=2022-06-17T12:00:00Z",
+			exp:   expected{status: http.StatusOK, from: from, to: from.Add(24 * time.Hour)},
+		},
+		"default to": {
+			path:  "schedule",
+			query: "?from=2022-06-16T12:00:00Z",
+			exp:   expected{status: http.StatusOK, from: from, to: from.Add(defaultScheduleRange)},
+		},
+		"public schedule": {
+			path:  "schedule/public",
+			query: "?from=2022-06-16T12:00:00Z&to=2022-06-17T12:00:00Z",
+			exp:   expected{status: http.StatusOK, from: from, to: from.Add(24 * time.Hour)},
+		},
+		"invalid from": {
+			path:  "schedule",
+			query: "?from=yesterday",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"to before from": {
+			path:  "schedule",
+			query: "?from=2022-06-16T12:00:00Z&to=2022-06-15T12:00:00Z",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"range too large": {
+			path:  "schedule/public",
+			query: "?from=2022-06-16T12:00:00Z&to=2022-08-16T12:00:00Z",
+			exp:   expected{status: http.StatusBadRequest},
+		},
+		"server dne": {
+			path:   "schedule",
+			getErr: cronmanerrors.ErrServerDNE,
+			exp:    expected{status: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := NewControllerMock(
+				WithGetServerSchedule(func(_ context.Context, id uuid.UUID, from, to time.Time) (*model.Schedule, error) {
+					require.Equal(t, serverID, id)
+					if test.getErr != nil {
+						return nil, test.getErr
+					}
+					require.True(t, test.exp.from.Equal(from))
+					require.True(t, test.exp.to.Equal(to))
+					return schedule, nil
+				}),
+			)
+			api := newAdminAPI(ctrl, uuid.New())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(
+				http.MethodGet,
+				fmt.Sprintf("/v1/server/%s/%s%s", serverID, test.path, test.query),
+				nil,
+			)
+
+			api.Mux.ServeHTTP(rr, req)
+			require.Equal(t, test.exp.status, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+
+			var res map[string]json.RawMessage
+			err := json.NewDecoder(rr.Body).Decode(&res)
+			require.Nil(t, err)
+			require.Contains(t, res, "onlineWindows")
+			require.Contains(t, res, "nextWipe")
+
+			_, hasTimeline := res["timeline"]
+			require.Equal(t, test.path == "schedule", hasTimeline)
+			if !hasTimeline {
+				return
+			}
+
+			var timeline []EventAt
+			err = json.Unmarshal(res["timeline"], &timeline)
+			require.Nil(t, err)
+			require.Equal(t, EventAtsFromModel(schedule.Occurrences), timeline)
 		})
 	}
 }
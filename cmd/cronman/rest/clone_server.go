@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CloneServer struct{ API }
+
+func (ep CloneServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	overrides, ok := ep.decodeServerOverrides(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := ep.ctrl.GetServerConfig(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	overrides.Apply(config)
+	ep.createServerFromConfig(w, r, *config)
+}
+
+// decodeServerOverrides decodes and validates the optional ServerOverrides
+// request body. If the body is invalid, an error response is written and
+// false is returned.
+func (api API) decodeServerOverrides(w http.ResponseWriter, r *http.Request) (*ServerOverrides, bool) {
+	var overrides ServerOverrides
+	err := json.NewDecoder(r.Body).Decode(&overrides)
+	if err != nil && !errors.Is(err, io.EOF) {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return nil, false
+	}
+
+	if err := api.valid.Struct(overrides); err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return nil, false
+	}
+	return &overrides, true
+}
+
+// createServerFromConfig enqueues a job that creates a new server from the
+// config, and writes the CreateServerResponse. The schedule of the config is
+// validated prior to enqueueing the job.
+func (api API) createServerFromConfig(
+	w http.ResponseWriter,
+	r *http.Request,
+	config model.ServerConfig,
+) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		ihttp.ErrInternal(api.logger, w, err)
+		return
+	}
+
+	server := config.Server(id)
+	if !api.validateSchedule(w, server) {
+		return
+	}
+
+	job, err := api.ctrl.CreateServerJob(r.Context(), server)
+	if err != nil {
+		ihttp.ErrInternal(api.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	resp := CreateServerResponse{ID: id, JobID: job.ID}
+	if err := json.NewEncoder(w).Encode(&resp); err != nil {
+		api.logger.Error("while encoding create server response", zap.Error(err))
+		return
+	}
+}
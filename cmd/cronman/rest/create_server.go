@@ -1,10 +1,8 @@
 package rest
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
-	"time"
 
 	"github.com/google/uuid"
 	ihttp "github.com/tjper/rustcron/internal/http"
@@ -30,29 +28,35 @@ func (ep CreateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if b.Countdown != nil {
+		if err := b.Countdown.ToModel().Validate(); err != nil {
+			ihttp.ErrBadRequest(ep.logger, w, err)
+			return
+		}
+	}
+
 	id, err := uuid.NewRandom()
 	if err != nil {
 		ihttp.ErrInternal(ep.logger, w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	server := b.ToModelServer(id)
+	if !ep.validateSchedule(w, server) {
+		return
+	}
 
-	var resp CreateServerResponse
-	resp.FromUUID(id)
+	job, err := ep.ctrl.CreateServerJob(r.Context(), server)
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
 
+	resp := CreateServerResponse{ID: id, JobID: job.ID}
 	if err := json.NewEncoder(w).Encode(&resp); err != nil {
 		ep.logger.Error("while encoding create server response", zap.Error(err))
 		return
 	}
-
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
-		defer cancel()
-
-		if _, err := ep.ctrl.CreateServer(ctx, b.ToModelServer(id)); err != nil {
-			ep.logger.Error("while creating server", zap.Error(err))
-			return
-		}
-	}()
 }
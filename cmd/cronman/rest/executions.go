@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tjper/rustcron/cmd/cronman/controller"
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	executionIDParam = "executionID"
+
+	// defaultExecutionsLimit is the number of executions listed when a limit
+	// is not specified.
+	defaultExecutionsLimit = 50
+	// maxExecutionsLimit is the maximum number of executions that may be
+	// listed at once.
+	maxExecutionsLimit = 100
+)
+
+type ServerExecutions struct{ API }
+
+func (ep ServerExecutions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	input, ok := ep.executionsInput(w, r)
+	if !ok {
+		return
+	}
+	input.ServerID = id
+
+	executions, err := ep.ctrl.ListServerExecutions(r.Context(), input)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(executionsPage(executions, input)); err != nil {
+		ep.logger.Error("while encoding server executions json", zap.Error(err))
+		return
+	}
+}
+
+type Executions struct{ API }
+
+func (ep Executions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	input, ok := ep.executionsInput(w, r)
+	if !ok {
+		return
+	}
+
+	executions, err := ep.ctrl.ListExecutions(r.Context(), input)
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(executionsPage(executions, input)); err != nil {
+		ep.logger.Error("while encoding executions json", zap.Error(err))
+		return
+	}
+}
+
+type RetryExecution struct{ API }
+
+func (ep RetryExecution) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, executionIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	job, err := ep.ctrl.RetryExecutionJob(r.Context(), id)
+	if errors.Is(err, ierrors.ErrExecutionDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if errors.Is(err, ierrors.ErrExecutionNotFailed) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding retry execution job json", zap.Error(err))
+		return
+	}
+}
+
+// executionsInput parses the limit, offset, outcome and kind query parameters
+// of the request. An additional execution is requested to determine if there
+// are further executions. If the query parameters are invalid, an error
+// response is written and false is returned.
+func (api API) executionsInput(w http.ResponseWriter, r *http.Request) (controller.ListExecutionsInput, bool) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"), defaultExecutionsLimit)
+	if err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return controller.ListExecutionsInput{}, false
+	}
+	if limit < 1 || limit > maxExecutionsLimit {
+		ihttp.ErrBadRequest(api.logger, w, fmt.Errorf("limit must be between 1 and %d", maxExecutionsLimit))
+		return controller.ListExecutionsInput{}, false
+	}
+
+	offset, err := intParam(query.Get("offset"), 0)
+	if err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return controller.ListExecutionsInput{}, false
+	}
+	if offset < 0 {
+		ihttp.ErrBadRequest(api.logger, w, errors.New("offset must not be negative"))
+		return controller.ListExecutionsInput{}, false
+	}
+
+	outcome := model.ExecutionOutcome(query.Get("outcome"))
+	if outcome != "" && !outcome.IsValid() {
+		ihttp.ErrBadRequest(api.logger, w, fmt.Errorf("unknown outcome %q", outcome))
+		return controller.ListExecutionsInput{}, false
+	}
+
+	kinds := make([]model.EventKind, 0, len(query["kind"]))
+	for _, value := range query["kind"] {
+		kind := model.EventKind(value)
+		if !kind.IsValid() {
+			ihttp.ErrBadRequest(api.logger, w, fmt.Errorf("unknown kind %q", value))
+			return controller.ListExecutionsInput{}, false
+		}
+		kinds = append(kinds, kind)
+	}
+
+	return controller.ListExecutionsInput{
+		Kinds:   kinds,
+		Outcome: outcome,
+		Limit:   limit + 1,
+		Offset:  offset,
+	}, true
+}
+
+// executionsPage builds the page of executions listed with input.
+func executionsPage(executions []model.Execution, input controller.ListExecutionsInput) ExecutionsPage {
+	limit := input.Limit - 1
+
+	page := ExecutionsPage{Executions: ExecutionsFromModel(executions)}
+	if len(page.Executions) > limit {
+		page.Executions = page.Executions[:limit]
+		next := input.Offset + limit
+		page.NextOffset = &next
+	}
+	return page
+}
@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const jobIDParam = "jobID"
+
+type GetJob struct{ API }
+
+func (ep GetJob) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	job, err := ep.ctrl.GetJob(r.Context(), id)
+	if errors.Is(err, ierrors.ErrJobDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding job json", zap.Error(err))
+		return
+	}
+}
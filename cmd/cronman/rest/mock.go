@@ -3,10 +3,12 @@ package rest
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/tjper/rustcron/cmd/cronman/controller"
 	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
 )
 
 // ErrMisconfiguredMock indicates an isntance of ControllerMock is being
@@ -28,15 +30,6 @@ func NewControllerMock(options ...ControllerMockOption) *ControllerMock {
 // ControllerMock instance.
 type ControllerMockOption func(*ControllerMock)
 
-// WithCreateServer provides a ControllerMockOption that configures a
-// ControllerMock to utilize the passed function to mock CreateServer
-// functionality.
-func WithCreateServer(fn createServerFunc) ControllerMockOption {
-	return func(mock *ControllerMock) {
-		mock.createServer = fn
-	}
-}
-
 // WithGetServer provides a ControllerMockOption that configures a
 // ControllerMock to utilize the passed function to mock GetServer
 // functionality.
@@ -64,33 +57,6 @@ func WithArchiveServer(fn archiveServerFunc) ControllerMockOption {
 	}
 }
 
-// WithStartServer provides a ControllerMockOption that configures a
-// ControllerMock to utilize the passed function to mock StartServer
-// functionality.
-func WithStartServer(fn startServerFunc) ControllerMockOption {
-	return func(mock *ControllerMock) {
-		mock.startServer = fn
-	}
-}
-
-// WithMakeServerLive provides a ControllerMockOption that configures a
-// ControllerMock to utilize the passed function to mock MakeServerLive
-// functionality.
-func WithMakeServerLive(fn makeServerLiveFunc) ControllerMockOption {
-	return func(mock *ControllerMock) {
-		mock.makeServerLive = fn
-	}
-}
-
-// WithStopServer provides a ControllerMockOption that configures a
-// ControllerMock to utilize the passed function to mock StopServer
-// functionality.
-func WithStopServer(fn stopServerFunc) ControllerMockOption {
-	return func(mock *ControllerMock) {
-		mock.stopServer = fn
-	}
-}
-
 // WithWipeServer provides a ControllerMockOption that configures a
 // ControllerMock to utilize the passed function to mock WipeServer
 // functionality.
@@ -181,36 +147,317 @@ func WithRemoveServerOwners(fn removeServerOwnersFunc) ControllerMockOption {
 	}
 }
 
+// WithExecServerRcon provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ExecServerRcon
+// functionality.
+func WithExecServerRcon(fn execServerRconFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.execServerRcon = fn
+	}
+}
+
+// WithListServerRconCommands provides a ControllerMockOption that configures
+// a ControllerMock to utilize the passed function to mock
+// ListServerRconCommands functionality.
+func WithListServerRconCommands(fn listServerRconCommandsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listServerRconCommands = fn
+	}
+}
+
+// WithAddServerBans provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock AddServerBans
+// functionality.
+func WithAddServerBans(fn addServerBansFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.addServerBans = fn
+	}
+}
+
+// WithRemoveServerBans provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock RemoveServerBans
+// functionality.
+func WithRemoveServerBans(fn removeServerBansFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.removeServerBans = fn
+	}
+}
+
+// WithListServerPlayers provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListServerPlayers
+// functionality.
+func WithListServerPlayers(fn listServerPlayersFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listServerPlayers = fn
+	}
+}
+
+// WithKickServerPlayer provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock KickServerPlayer
+// functionality.
+func WithKickServerPlayer(fn kickServerPlayerFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.kickServerPlayer = fn
+	}
+}
+
+// WithListPlugins provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListPlugins
+// functionality.
+func WithListPlugins(fn listPluginsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listPlugins = fn
+	}
+}
+
+// WithEnableServerPlugins provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock EnableServerPlugins
+// functionality.
+func WithEnableServerPlugins(fn enableServerPluginsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.enableServerPlugins = fn
+	}
+}
+
+// WithDisableServerPlugins provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock DisableServerPlugins
+// functionality.
+func WithDisableServerPlugins(fn disableServerPluginsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.disableServerPlugins = fn
+	}
+}
+
+// WithUpdateServerCountdown provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock UpdateServerCountdown
+// functionality.
+func WithUpdateServerCountdown(fn updateServerCountdownFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.updateServerCountdown = fn
+	}
+}
+
+// WithCreateServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock CreateServerJob
+// functionality.
+func WithCreateServerJob(fn createServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.createServerJob = fn
+	}
+}
+
+// WithStartServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock StartServerJob
+// functionality.
+func WithStartServerJob(fn startServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.startServerJob = fn
+	}
+}
+
+// WithStopServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock StopServerJob
+// functionality.
+func WithStopServerJob(fn stopServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.stopServerJob = fn
+	}
+}
+
+// WithWipeServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock WipeServerJob
+// functionality.
+func WithWipeServerJob(fn wipeServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.wipeServerJob = fn
+	}
+}
+
+// WithGetJob provides a ControllerMockOption that configures a ControllerMock
+// to utilize the passed function to mock GetJob functionality.
+func WithGetJob(fn getJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.getJob = fn
+	}
+}
+
+// WithGetServerConfig provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock GetServerConfig
+// functionality.
+func WithGetServerConfig(fn getServerConfigFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.getServerConfig = fn
+	}
+}
+
+// WithCreateServerTemplate provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock CreateServerTemplate
+// functionality.
+func WithCreateServerTemplate(fn createServerTemplateFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.createServerTemplate = fn
+	}
+}
+
+// WithGetServerTemplate provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock GetServerTemplate
+// functionality.
+func WithGetServerTemplate(fn getServerTemplateFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.getServerTemplate = fn
+	}
+}
+
+// WithListServerTemplates provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListServerTemplates
+// functionality.
+func WithListServerTemplates(fn listServerTemplatesFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listServerTemplates = fn
+	}
+}
+
+// WithDeleteServerTemplate provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock DeleteServerTemplate
+// functionality.
+func WithDeleteServerTemplate(fn deleteServerTemplateFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.deleteServerTemplate = fn
+	}
+}
+
+// WithDecommissionServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock DecommissionServerJob
+// functionality.
+func WithDecommissionServerJob(fn decommissionServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.decommissionServerJob = fn
+	}
+}
+
+// WithUnarchiveServerJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock UnarchiveServerJob
+// functionality.
+func WithUnarchiveServerJob(fn unarchiveServerJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.unarchiveServerJob = fn
+	}
+}
+
+// WithListServerHistory provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListServerHistory
+// functionality.
+func WithListServerHistory(fn listServerHistoryFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listServerHistory = fn
+	}
+}
+
+// WithAddServerBlackouts provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock AddServerBlackouts
+// functionality.
+func WithAddServerBlackouts(fn addServerBlackoutsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.addServerBlackouts = fn
+	}
+}
+
+// WithRemoveServerBlackouts provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock RemoveServerBlackouts
+// functionality.
+func WithRemoveServerBlackouts(fn removeServerBlackoutsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.removeServerBlackouts = fn
+	}
+}
+
+// WithGetServerSchedule provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock GetServerSchedule
+// functionality.
+func WithGetServerSchedule(fn getServerScheduleFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.getServerSchedule = fn
+	}
+}
+
+// WithListServerExecutions provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListServerExecutions
+// functionality.
+func WithListServerExecutions(fn listServerExecutionsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listServerExecutions = fn
+	}
+}
+
+// WithListExecutions provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock ListExecutions
+// functionality.
+func WithListExecutions(fn listExecutionsFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.listExecutions = fn
+	}
+}
+
+// WithRetryExecutionJob provides a ControllerMockOption that configures a
+// ControllerMock to utilize the passed function to mock RetryExecutionJob
+// functionality.
+func WithRetryExecutionJob(fn retryExecutionJobFunc) ControllerMockOption {
+	return func(mock *ControllerMock) {
+		mock.retryExecutionJob = fn
+	}
+}
+
 type (
-	createServerFunc           func(context.Context, model.Server) (*model.DormantServer, error)
 	getServerFunc              func(context.Context, uuid.UUID) (interface{}, error)
 	updateServerFunc           func(context.Context, controller.UpdateServerInput) (*model.DormantServer, error)
 	archiveServerFunc          func(context.Context, uuid.UUID) (*model.ArchivedServer, error)
-	startServerFunc            func(context.Context, uuid.UUID) (*model.DormantServer, error)
-	makeServerLiveFunc         func(context.Context, uuid.UUID) (*model.LiveServer, error)
-	stopServerFunc             func(context.Context, uuid.UUID) (*model.DormantServer, error)
 	wipeServerFunc             func(context.Context, uuid.UUID, model.Wipe) error
 	listServersFunc            func(context.Context, interface{}) error
 	addServerTagsFunc          func(context.Context, uuid.UUID, model.Tags) error
 	removeServerTagsFunc       func(context.Context, uuid.UUID, []uuid.UUID) error
-	addServerEventsFunc        func(context.Context, uuid.UUID, model.Events) error
-	removeServerEventsFunc     func(context.Context, uuid.UUID, []uuid.UUID) error
+	addServerEventsFunc        func(context.Context, uuid.UUID, model.Events) (model.ScheduleViolations, error)
+	removeServerEventsFunc     func(context.Context, uuid.UUID, []uuid.UUID) (model.ScheduleViolations, error)
 	addServerModeratorsFunc    func(context.Context, uuid.UUID, model.Moderators) error
 	removeServerModeratorsFunc func(context.Context, uuid.UUID, []uuid.UUID) error
 	addServerOwnersFunc        func(context.Context, uuid.UUID, model.Owners) error
 	removeServerOwnersFunc     func(context.Context, uuid.UUID, []uuid.UUID) error
+	execServerRconFunc         func(context.Context, uuid.UUID, uuid.UUID, string) (*model.RconCommand, error)
+	listServerRconCommandsFunc func(context.Context, uuid.UUID) (model.RconCommands, error)
+	addServerBansFunc          func(context.Context, uuid.UUID, model.Bans) error
+	removeServerBansFunc       func(context.Context, uuid.UUID, []uuid.UUID) error
+	listServerPlayersFunc      func(context.Context, uuid.UUID) ([]rcon.Player, error)
+	kickServerPlayerFunc       func(context.Context, uuid.UUID, string, string) error
+	listPluginsFunc            func(context.Context) (model.Plugins, error)
+	enableServerPluginsFunc    func(context.Context, uuid.UUID, []uuid.UUID) error
+	disableServerPluginsFunc   func(context.Context, uuid.UUID, []uuid.UUID) error
+	updateServerCountdownFunc  func(context.Context, uuid.UUID, model.Countdown) error
+	createServerJobFunc        func(context.Context, model.Server) (*model.Job, error)
+	startServerJobFunc         func(context.Context, uuid.UUID) (*model.Job, error)
+	stopServerJobFunc          func(context.Context, uuid.UUID) (*model.Job, error)
+	wipeServerJobFunc          func(context.Context, uuid.UUID, model.Wipe) (*model.Job, error)
+	getJobFunc                 func(context.Context, uuid.UUID) (*model.Job, error)
+	getServerConfigFunc        func(context.Context, uuid.UUID) (*model.ServerConfig, error)
+	createServerTemplateFunc   func(context.Context, string, model.ServerConfig) (*model.ServerTemplate, error)
+	getServerTemplateFunc      func(context.Context, uuid.UUID) (*model.ServerTemplate, error)
+	listServerTemplatesFunc    func(context.Context) ([]model.ServerTemplate, error)
+	deleteServerTemplateFunc   func(context.Context, uuid.UUID) error
+	decommissionServerJobFunc  func(context.Context, uuid.UUID) (*model.Job, error)
+	unarchiveServerJobFunc     func(context.Context, uuid.UUID) (*model.Job, error)
+	listServerHistoryFunc      func(context.Context, controller.ListServerHistoryInput) ([]model.AuditEntry, error)
+	addServerBlackoutsFunc     func(context.Context, uuid.UUID, model.Blackouts) error
+	removeServerBlackoutsFunc  func(context.Context, uuid.UUID, []uuid.UUID) error
+	getServerScheduleFunc      func(context.Context, uuid.UUID, time.Time, time.Time) (*model.Schedule, error)
+	listServerExecutionsFunc   func(context.Context, controller.ListExecutionsInput) ([]model.Execution, error)
+	listExecutionsFunc         func(context.Context, controller.ListExecutionsInput) ([]model.Execution, error)
+	retryExecutionJobFunc      func(context.Context, uuid.UUID) (*model.Job, error)
 )
 
 // ControllerMock is typically used to implement the IController interface for
 // testing purposes.
 type ControllerMock struct {
-	createServer           createServerFunc
 	getServer              getServerFunc
 	updateServer           updateServerFunc
 	archiveServer          archiveServerFunc
-	startServer            startServerFunc
-	makeServerLive         makeServerLiveFunc
-	stopServer             stopServerFunc
 	wipeServer             wipeServerFunc
 	listServers            listServersFunc
 	addServerTags          addServerTagsFunc
@@ -221,14 +468,35 @@ type ControllerMock struct {
 	removeServerModerators removeServerModeratorsFunc
 	addServerOwners        addServerOwnersFunc
 	removeServerOwners     removeServerOwnersFunc
-}
-
-// CreateServer executes the handler set with WithCreateServer.
-func (m ControllerMock) CreateServer(ctx context.Context, server model.Server) (*model.DormantServer, error) {
-	if m.createServer == nil {
-		return nil, ErrMisconfiguredMock
-	}
-	return m.createServer(ctx, server)
+	execServerRcon         execServerRconFunc
+	listServerRconCommands listServerRconCommandsFunc
+	addServerBans          addServerBansFunc
+	removeServerBans       removeServerBansFunc
+	listServerPlayers      listServerPlayersFunc
+	kickServerPlayer       kickServerPlayerFunc
+	listPlugins            listPluginsFunc
+	enableServerPlugins    enableServerPluginsFunc
+	disableServerPlugins   disableServerPluginsFunc
+	updateServerCountdown  updateServerCountdownFunc
+	createServerJob        createServerJobFunc
+	startServerJob         startServerJobFunc
+	stopServerJob          stopServerJobFunc
+	wipeServerJob          wipeServerJobFunc
+	getJob                 getJobFunc
+	getServerConfig        getServerConfigFunc
+	createServerTemplate   createServerTemplateFunc
+	getServerTemplate      getServerTemplateFunc
+	listServerTemplates    listServerTemplatesFunc
+	deleteServerTemplate   deleteServerTemplateFunc
+	decommissionServerJob  decommissionServerJobFunc
+	unarchiveServerJob     unarchiveServerJobFunc
+	listServerHistory      listServerHistoryFunc
+	addServerBlackouts     addServerBlackoutsFunc
+	removeServerBlackouts  removeServerBlackoutsFunc
+	getServerSchedule      getServerScheduleFunc
+	listServerExecutions   listServerExecutionsFunc
+	listExecutions         listExecutionsFunc
+	retryExecutionJob      retryExecutionJobFunc
 }
 
 // GetServer executes the handler set with WithGetServer.
@@ -255,30 +523,6 @@ func (m ControllerMock) ArchiveServer(ctx context.Context, id uuid.UUID) (*model
 	return m.archiveServer(ctx, id)
 }
 
-// StartServer executes the handler set with WithStartServer.
-func (m ControllerMock) StartServer(ctx context.Context, id uuid.UUID) (*model.DormantServer, error) {
-	if m.startServer == nil {
-		return nil, ErrMisconfiguredMock
-	}
-	return m.startServer(ctx, id)
-}
-
-// MakeServerLive executes the handler set with WithMakeServerLive.
-func (m ControllerMock) MakeServerLive(ctx context.Context, id uuid.UUID) (*model.LiveServer, error) {
-	if m.makeServerLive == nil {
-		return nil, ErrMisconfiguredMock
-	}
-	return m.makeServerLive(ctx, id)
-}
-
-// StopServer executes the handler set with WithStopServer.
-func (m ControllerMock) StopServer(ctx context.Context, id uuid.UUID) (*model.DormantServer, error) {
-	if m.stopServer == nil {
-		return nil, ErrMisconfiguredMock
-	}
-	return m.stopServer(ctx, id)
-}
-
 // WipeServer executes the handler set with WithWipeServer.
 func (m ControllerMock) WipeServer(ctx context.Context, id uuid.UUID, wipe model.Wipe) error {
 	if m.wipeServer == nil {
@@ -312,17 +556,17 @@ func (m ControllerMock) RemoveServerTags(ctx context.Context, id uuid.UUID, ids
 }
 
 // AddServerEvents executes the handler set with WithAddServerEvents.
-func (m ControllerMock) AddServerEvents(ctx context.Context, id uuid.UUID, events model.Events) error {
+func (m ControllerMock) AddServerEvents(ctx context.Context, id uuid.UUID, events model.Events) (model.ScheduleViolations, error) {
 	if m.addServerEvents == nil {
-		return ErrMisconfiguredMock
+		return nil, ErrMisconfiguredMock
 	}
 	return m.addServerEvents(ctx, id, events)
 }
 
 // RemoveServerEvents executes the handler set with WithRemoveServerEvents.
-func (m ControllerMock) RemoveServerEvents(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
+func (m ControllerMock) RemoveServerEvents(ctx context.Context, id uuid.UUID, ids []uuid.UUID) (model.ScheduleViolations, error) {
 	if m.removeServerEvents == nil {
-		return ErrMisconfiguredMock
+		return nil, ErrMisconfiguredMock
 	}
 	return m.removeServerEvents(ctx, id, ids)
 }
@@ -358,3 +602,239 @@ func (m ControllerMock) RemoveServerOwners(ctx context.Context, id uuid.UUID, id
 	}
 	return m.removeServerOwners(ctx, id, ids)
 }
+
+// ExecServerRcon executes the handler set with WithExecServerRcon.
+func (m ControllerMock) ExecServerRcon(ctx context.Context, serverID uuid.UUID, userID uuid.UUID, command string) (*model.RconCommand, error) {
+	if m.execServerRcon == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.execServerRcon(ctx, serverID, userID, command)
+}
+
+// ListServerRconCommands executes the handler set with
+// WithListServerRconCommands.
+func (m ControllerMock) ListServerRconCommands(ctx context.Context, serverID uuid.UUID) (model.RconCommands, error) {
+	if m.listServerRconCommands == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listServerRconCommands(ctx, serverID)
+}
+
+// AddServerBans executes the handler set with WithAddServerBans.
+func (m ControllerMock) AddServerBans(ctx context.Context, id uuid.UUID, bans model.Bans) error {
+	if m.addServerBans == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.addServerBans(ctx, id, bans)
+}
+
+// RemoveServerBans executes the handler set with WithRemoveServerBans.
+func (m ControllerMock) RemoveServerBans(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
+	if m.removeServerBans == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.removeServerBans(ctx, id, ids)
+}
+
+// ListServerPlayers executes the handler set with WithListServerPlayers.
+func (m ControllerMock) ListServerPlayers(ctx context.Context, serverID uuid.UUID) ([]rcon.Player, error) {
+	if m.listServerPlayers == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listServerPlayers(ctx, serverID)
+}
+
+// KickServerPlayer executes the handler set with WithKickServerPlayer.
+func (m ControllerMock) KickServerPlayer(ctx context.Context, serverID uuid.UUID, steamID string, reason string) error {
+	if m.kickServerPlayer == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.kickServerPlayer(ctx, serverID, steamID, reason)
+}
+
+// ListPlugins executes the handler set with WithListPlugins.
+func (m ControllerMock) ListPlugins(ctx context.Context) (model.Plugins, error) {
+	if m.listPlugins == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listPlugins(ctx)
+}
+
+// EnableServerPlugins executes the handler set with WithEnableServerPlugins.
+func (m ControllerMock) EnableServerPlugins(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
+	if m.enableServerPlugins == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.enableServerPlugins(ctx, id, ids)
+}
+
+// DisableServerPlugins executes the handler set with WithDisableServerPlugins.
+func (m ControllerMock) DisableServerPlugins(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
+	if m.disableServerPlugins == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.disableServerPlugins(ctx, id, ids)
+}
+
+// UpdateServerCountdown executes the handler set with
+// WithUpdateServerCountdown.
+func (m ControllerMock) UpdateServerCountdown(ctx context.Context, id uuid.UUID, countdown model.Countdown) error {
+	if m.updateServerCountdown == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.updateServerCountdown(ctx, id, countdown)
+}
+
+// CreateServerJob executes the handler set with WithCreateServerJob.
+func (m ControllerMock) CreateServerJob(ctx context.Context, server model.Server) (*model.Job, error) {
+	if m.createServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.createServerJob(ctx, server)
+}
+
+// StartServerJob executes the handler set with WithStartServerJob.
+func (m ControllerMock) StartServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.startServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.startServerJob(ctx, id)
+}
+
+// StopServerJob executes the handler set with WithStopServerJob.
+func (m ControllerMock) StopServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.stopServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.stopServerJob(ctx, id)
+}
+
+// WipeServerJob executes the handler set with WithWipeServerJob.
+func (m ControllerMock) WipeServerJob(ctx context.Context, id uuid.UUID, wipe model.Wipe) (*model.Job, error) {
+	if m.wipeServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.wipeServerJob(ctx, id, wipe)
+}
+
+// GetJob executes the handler set with WithGetJob.
+func (m ControllerMock) GetJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.getJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.getJob(ctx, id)
+}
+
+// GetServerConfig executes the handler set with WithGetServerConfig.
+func (m ControllerMock) GetServerConfig(ctx context.Context, id uuid.UUID) (*model.ServerConfig, error) {
+	if m.getServerConfig == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.getServerConfig(ctx, id)
+}
+
+// CreateServerTemplate executes the handler set with WithCreateServerTemplate.
+func (m ControllerMock) CreateServerTemplate(ctx context.Context, name string, config model.ServerConfig) (*model.ServerTemplate, error) {
+	if m.createServerTemplate == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.createServerTemplate(ctx, name, config)
+}
+
+// GetServerTemplate executes the handler set with WithGetServerTemplate.
+func (m ControllerMock) GetServerTemplate(ctx context.Context, id uuid.UUID) (*model.ServerTemplate, error) {
+	if m.getServerTemplate == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.getServerTemplate(ctx, id)
+}
+
+// ListServerTemplates executes the handler set with WithListServerTemplates.
+func (m ControllerMock) ListServerTemplates(ctx context.Context) ([]model.ServerTemplate, error) {
+	if m.listServerTemplates == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listServerTemplates(ctx)
+}
+
+// DeleteServerTemplate executes the handler set with WithDeleteServerTemplate.
+func (m ControllerMock) DeleteServerTemplate(ctx context.Context, id uuid.UUID) error {
+	if m.deleteServerTemplate == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.deleteServerTemplate(ctx, id)
+}
+
+// DecommissionServerJob executes the handler set with
+// WithDecommissionServerJob.
+func (m ControllerMock) DecommissionServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.decommissionServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.decommissionServerJob(ctx, id)
+}
+
+// UnarchiveServerJob executes the handler set with WithUnarchiveServerJob.
+func (m ControllerMock) UnarchiveServerJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.unarchiveServerJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.unarchiveServerJob(ctx, id)
+}
+
+// ListServerHistory executes the handler set with WithListServerHistory.
+func (m ControllerMock) ListServerHistory(ctx context.Context, input controller.ListServerHistoryInput) ([]model.AuditEntry, error) {
+	if m.listServerHistory == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listServerHistory(ctx, input)
+}
+
+// AddServerBlackouts executes the handler set with WithAddServerBlackouts.
+func (m ControllerMock) AddServerBlackouts(ctx context.Context, serverID uuid.UUID, blackouts model.Blackouts) error {
+	if m.addServerBlackouts == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.addServerBlackouts(ctx, serverID, blackouts)
+}
+
+// RemoveServerBlackouts executes the handler set with
+// WithRemoveServerBlackouts.
+func (m ControllerMock) RemoveServerBlackouts(ctx context.Context, serverID uuid.UUID, blackoutIDs []uuid.UUID) error {
+	if m.removeServerBlackouts == nil {
+		return ErrMisconfiguredMock
+	}
+	return m.removeServerBlackouts(ctx, serverID, blackoutIDs)
+}
+
+// GetServerSchedule executes the handler set with WithGetServerSchedule.
+func (m ControllerMock) GetServerSchedule(ctx context.Context, id uuid.UUID, from time.Time, to time.Time) (*model.Schedule, error) {
+	if m.getServerSchedule == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.getServerSchedule(ctx, id, from, to)
+}
+
+// ListServerExecutions executes the handler set with WithListServerExecutions.
+func (m ControllerMock) ListServerExecutions(ctx context.Context, input controller.ListExecutionsInput) ([]model.Execution, error) {
+	if m.listServerExecutions == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listServerExecutions(ctx, input)
+}
+
+// ListExecutions executes the handler set with WithListExecutions.
+func (m ControllerMock) ListExecutions(ctx context.Context, input controller.ListExecutionsInput) ([]model.Execution, error) {
+	if m.listExecutions == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.listExecutions(ctx, input)
+}
+
+// RetryExecutionJob executes the handler set with WithRetryExecutionJob.
+func (m ControllerMock) RetryExecutionJob(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	if m.retryExecutionJob == nil {
+		return nil, ErrMisconfiguredMock
+	}
+	return m.retryExecutionJob(ctx, id)
+}
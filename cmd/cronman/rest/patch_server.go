@@ -22,6 +22,12 @@ func (ep PatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ihttp.ErrBadRequest(ep.logger, w, err)
 		return
 	}
+	if timeZone, ok := b.Changes["timeZone"]; ok {
+		if err := ep.valid.Var(timeZone, "required,timezone"); err != nil {
+			ihttp.ErrBadRequest(ep.logger, w, err)
+			return
+		}
+	}
 
 	server, err := ep.ctrl.UpdateServer(r.Context(), b.ToUpdateServerInput())
 	if errors.Is(err, cronmanerrors.ErrServerDNE) {
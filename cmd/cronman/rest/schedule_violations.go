@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"go.uber.org/zap"
+)
+
+// validateSchedule validates the schedule of the server. If the schedule has
+// errors, a ScheduleErrorResponse is written and false is returned. Warnings
+// are written as Warning headers and do not reject the schedule.
+func (api API) validateSchedule(w http.ResponseWriter, server model.Server) bool {
+	violations, err := server.ValidateSchedule(time.Now())
+	if err != nil {
+		ihttp.ErrInternal(api.logger, w, err)
+		return false
+	}
+
+	if api.scheduleError(w, violations.Err()) {
+		return false
+	}
+	scheduleWarnings(w, violations)
+	return true
+}
+
+// scheduleError writes a ScheduleErrorResponse if err wraps a
+// *model.ScheduleError, and reports whether it was written.
+func (api API) scheduleError(w http.ResponseWriter, err error) bool {
+	var scheduleErr *model.ScheduleError
+	if !errors.As(err, &scheduleErr) {
+		return false
+	}
+	api.logger.Warn("bad request", zap.Error(err))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	resp := ScheduleErrorResponse{
+		Message:    "Schedule validation failure. Please update your request and retry.",
+		Violations: ScheduleViolationsFromModel(scheduleErr.Violations),
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		api.logger.Error("while encoding schedule error response", zap.Error(err))
+	}
+	return true
+}
+
+// scheduleWarnings writes the warnings of the violations as Warning headers.
+func scheduleWarnings(w http.ResponseWriter, violations model.ScheduleViolations) {
+	for _, warning := range violations.Warnings() {
+		w.Header().Add("Warning", fmt.Sprintf("199 - %q", warning.Message))
+	}
+}
+
+// ScheduleErrorResponse is the response body of a request rejected due to an
+// invalid schedule.
+type ScheduleErrorResponse struct {
+	Message    string              `json:"message"`
+	Violations []ScheduleViolation `json:"violations"`
+}
+
+func ScheduleViolationsFromModel(violations model.ScheduleViolations) []ScheduleViolation {
+	res := make([]ScheduleViolation, 0, len(violations))
+	for _, violation := range violations {
+		res = append(res, ScheduleViolation{
+			Kind:     violation.Kind,
+			Severity: violation.Severity,
+			At:       violation.At,
+			Events:   EventsFromModel(violation.Events),
+			Message:  violation.Message,
+		})
+	}
+	return res
+}
+
+// ScheduleViolation is a problem found with a server's schedule.
+type ScheduleViolation struct {
+	Kind     model.ViolationKind     `json:"kind"`
+	Severity model.ViolationSeverity `json:"severity"`
+	At       *time.Time              `json:"at,omitempty"`
+	Events   Events                  `json:"events"`
+	Message  string                  `json:"message"`
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	cronmanerrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+)
+
+type AddServerBans struct{ API }
+
+func (ep AddServerBans) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b AddServerBansBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	modelBans := b.Bans.ToModelBans()
+
+	err := ep.ctrl.AddServerBans(r.Context(), b.ServerID, modelBans)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	bans := BansFromModel(modelBans)
+
+	if err := json.NewEncoder(w).Encode(bans); err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+}
+
+type RemoveServerBans struct{ API }
+
+func (ep RemoveServerBans) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b RemoveServerBansBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err := ep.ctrl.RemoveServerBans(r.Context(), b.ServerID, b.BanIDs)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
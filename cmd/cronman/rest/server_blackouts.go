@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	cronmanerrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+)
+
+type AddServerBlackouts struct{ API }
+
+func (ep AddServerBlackouts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b AddServerBlackoutsBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	modelBlackouts := b.Blackouts.ToModelBlackouts()
+
+	err := ep.ctrl.AddServerBlackouts(r.Context(), b.ServerID, modelBlackouts)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	blackouts := BlackoutsFromModel(modelBlackouts)
+
+	if err := json.NewEncoder(w).Encode(blackouts); err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+}
+
+type RemoveServerBlackouts struct{ API }
+
+func (ep RemoveServerBlackouts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b RemoveServerBlackoutsBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err := ep.ctrl.RemoveServerBlackouts(r.Context(), b.ServerID, b.BlackoutIDs)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type PutServerCountdown struct{ API }
+
+func (ep PutServerCountdown) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	var b Countdown
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	countdown := b.ToModel()
+	if err := countdown.Validate(); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err = ep.ctrl.UpdateServerCountdown(r.Context(), id, countdown)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
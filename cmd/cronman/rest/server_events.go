@@ -25,16 +25,22 @@ func (ep AddServerEvents) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	modelEvents := b.Events.ToModelEvents()
 
-	err := ep.ctrl.AddServerEvents(r.Context(), b.ServerID, modelEvents)
+	// The schedule is validated by the controller, including the server's
+	// existing events.
+	warnings, err := ep.ctrl.AddServerEvents(r.Context(), b.ServerID, modelEvents)
 	if errors.Is(err, cronmanerrors.ErrServerDNE) {
 		ihttp.ErrNotFound(w)
 		return
 	}
+	if ep.scheduleError(w, err) {
+		return
+	}
 	if err != nil {
 		ihttp.ErrInternal(ep.logger, w, err)
 		return
 	}
 
+	scheduleWarnings(w, warnings)
 	w.WriteHeader(http.StatusCreated)
 
 	events := EventsFromModel(modelEvents)
@@ -59,14 +65,22 @@ func (ep RemoveServerEvents) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := ep.ctrl.RemoveServerEvents(r.Context(), b.ServerID, b.EventIDs)
+	// The schedule is validated by the controller, removing an event the
+	// schedule relies upon is rejected.
+	warnings, err := ep.ctrl.RemoveServerEvents(r.Context(), b.ServerID, b.EventIDs)
 	if errors.Is(err, cronmanerrors.ErrServerDNE) {
 		ihttp.ErrNotFound(w)
+		return
+	}
+	if ep.scheduleError(w, err) {
+		return
 	}
 	if err != nil {
 		ihttp.ErrInternal(ep.logger, w, err)
 		return
 	}
 
+	scheduleWarnings(w, warnings)
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tjper/rustcron/cmd/cronman/controller"
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultHistoryLimit is the number of history entries listed when a
+	// limit is not specified.
+	defaultHistoryLimit = 50
+	// maxHistoryLimit is the maximum number of history entries that may be
+	// listed at once.
+	maxHistoryLimit = 100
+)
+
+type ServerHistory struct{ API }
+
+func (ep ServerHistory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"), defaultHistoryLimit)
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+	if limit < 1 || limit > maxHistoryLimit {
+		ihttp.ErrBadRequest(ep.logger, w, fmt.Errorf("limit must be between 1 and %d", maxHistoryLimit))
+		return
+	}
+
+	offset, err := intParam(query.Get("offset"), 0)
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+	if offset < 0 {
+		ihttp.ErrBadRequest(ep.logger, w, errors.New("offset must not be negative"))
+		return
+	}
+
+	actions := make([]model.AuditAction, 0, len(query["action"]))
+	for _, value := range query["action"] {
+		action := model.AuditAction(value)
+		if !action.IsValid() {
+			ihttp.ErrBadRequest(ep.logger, w, fmt.Errorf("unknown action %q", value))
+			return
+		}
+		actions = append(actions, action)
+	}
+
+	// An additional entry is listed to determine if there are further
+	// entries.
+	entries, err := ep.ctrl.ListServerHistory(r.Context(), controller.ListServerHistoryInput{
+		ServerID: id,
+		Actions:  actions,
+		Limit:    limit + 1,
+		Offset:   offset,
+	})
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	history := ServerHistoryPage{Entries: AuditEntriesFromModel(entries)}
+	if len(history.Entries) > limit {
+		history.Entries = history.Entries[:limit]
+		next := offset + limit
+		history.NextOffset = &next
+	}
+
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		ep.logger.Error("while encoding server history json", zap.Error(err))
+		return
+	}
+}
+
+// intParam parses the query parameter value as an int. If value is empty,
+// def is returned.
+func intParam(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("while parsing query parameter %q: %w", value, err)
+	}
+	return i, nil
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ServerPlayers struct{ API }
+
+func (ep ServerPlayers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	players, err := ep.ctrl.ListServerPlayers(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if errors.Is(err, ierrors.ErrServerNotLive) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(PlayersFromRcon(players)); err != nil {
+		ep.logger.Error("while encoding players json", zap.Error(err))
+		return
+	}
+}
+
+type KickServerPlayer struct{ API }
+
+func (ep KickServerPlayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	var b KickServerPlayerBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err = ep.ctrl.KickServerPlayer(r.Context(), id, b.SteamID, b.Reason)
+	if errors.Is(err, ierrors.ErrServerDNE) || errors.Is(err, rcon.ErrPlayerNotFound) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if errors.Is(err, ierrors.ErrServerNotLive) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	cronmanerrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"go.uber.org/zap"
+)
+
+type Plugins struct{ API }
+
+func (ep Plugins) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	plugins, err := ep.ctrl.ListPlugins(r.Context())
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(PluginsFromModel(plugins)); err != nil {
+		ep.logger.Error("while encoding plugins json", zap.Error(err))
+		return
+	}
+}
+
+type EnableServerPlugins struct{ API }
+
+func (ep EnableServerPlugins) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b EnableServerPluginsBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err := ep.ctrl.EnableServerPlugins(r.Context(), b.ServerID, b.PluginIDs)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) || errors.Is(err, cronmanerrors.ErrPluginDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type DisableServerPlugins struct{ API }
+
+func (ep DisableServerPlugins) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b DisableServerPluginsBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err := ep.ctrl.DisableServerPlugins(r.Context(), b.ServerID, b.PluginIDs)
+	if errors.Is(err, cronmanerrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+	"github.com/tjper/rustcron/internal/session"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ExecServerRcon struct{ API }
+
+func (ep ExecServerRcon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sess, ok := session.FromContext(r.Context())
+	if !ok {
+		ihttp.ErrUnauthorized(w)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	var b ExecServerRconBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	command, err := ep.ctrl.ExecServerRcon(r.Context(), id, sess.User.ID, b.Command)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if errors.Is(err, ierrors.ErrServerNotLive) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(RconCommandFromModel(*command)); err != nil {
+		ep.logger.Error("while encoding rcon command json", zap.Error(err))
+		return
+	}
+}
+
+type ServerRconCommands struct{ API }
+
+func (ep ServerRconCommands) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	commands, err := ep.ctrl.ListServerRconCommands(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(RconCommandsFromModel(commands)); err != nil {
+		ep.logger.Error("while encoding rcon commands json", zap.Error(err))
+		return
+	}
+}
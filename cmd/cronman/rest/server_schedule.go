@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultScheduleRange is the period of time a schedule covers when the
+	// end of the schedule is not specified.
+	defaultScheduleRange = 7 * 24 * time.Hour
+	// maxScheduleRange is the maximum period of time a schedule may cover.
+	maxScheduleRange = 31 * 24 * time.Hour
+)
+
+type GetServerSchedule struct{ API }
+
+func (ep GetServerSchedule) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := ep.schedule(w, r)
+	if !ok {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(ScheduleFromModel(*schedule)); err != nil {
+		ep.logger.Error("while encoding server schedule json", zap.Error(err))
+		return
+	}
+}
+
+type GetPublicServerSchedule struct{ API }
+
+func (ep GetPublicServerSchedule) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := ep.schedule(w, r)
+	if !ok {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(PublicServerScheduleFromModel(*schedule)); err != nil {
+		ep.logger.Error("while encoding public server schedule json", zap.Error(err))
+		return
+	}
+}
+
+// schedule retrieves the schedule of the server specified by the request. The
+// from and to query parameters are RFC 3339 times that bound the schedule,
+// from defaults to now and to defaults to a week after from. If the schedule
+// could not be retrieved, an error response is written and false is returned.
+func (api API) schedule(w http.ResponseWriter, r *http.Request) (*model.Schedule, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, serverIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return nil, false
+	}
+
+	query := r.URL.Query()
+
+	from, err := timeParam(query.Get("from"), time.Now())
+	if err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return nil, false
+	}
+	to, err := timeParam(query.Get("to"), from.Add(defaultScheduleRange))
+	if err != nil {
+		ihttp.ErrBadRequest(api.logger, w, err)
+		return nil, false
+	}
+	if !to.After(from) {
+		ihttp.ErrBadRequest(api.logger, w, errors.New("to must be after from"))
+		return nil, false
+	}
+	if to.Sub(from) > maxScheduleRange {
+		ihttp.ErrBadRequest(api.logger, w, fmt.Errorf("schedule may not exceed %s", maxScheduleRange))
+		return nil, false
+	}
+
+	schedule, err := api.ctrl.GetServerSchedule(r.Context(), id, from, to)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return nil, false
+	}
+	if err != nil {
+		ihttp.ErrInternal(api.logger, w, err)
+		return nil, false
+	}
+	return schedule, true
+}
+
+// timeParam parses the query parameter value as an RFC 3339 time. If value is
+// empty, def is returned.
+func timeParam(value string, def time.Time) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("while parsing query parameter %q: %w", value, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const templateIDParam = "templateID"
+
+var errTemplateSource = errors.New("template must be created from either a server or a server configuration")
+
+type CreateServerTemplate struct{ API }
+
+func (ep CreateServerTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b CreateServerTemplateBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	var config model.ServerConfig
+	switch {
+	case b.ServerID != nil && b.Server != nil:
+		ihttp.ErrBadRequest(ep.logger, w, errTemplateSource)
+		return
+
+	case b.ServerID != nil:
+		serverConfig, err := ep.ctrl.GetServerConfig(r.Context(), *b.ServerID)
+		if errors.Is(err, ierrors.ErrServerDNE) {
+			ihttp.ErrNotFound(w)
+			return
+		}
+		if err != nil {
+			ihttp.ErrInternal(ep.logger, w, err)
+			return
+		}
+		config = *serverConfig
+
+	default:
+		if err := b.Server.validateOwnerAndModeratorIntersection(); err != nil {
+			ihttp.ErrBadRequest(ep.logger, w, err)
+			return
+		}
+		if b.Server.Countdown != nil {
+			if err := b.Server.Countdown.ToModel().Validate(); err != nil {
+				ihttp.ErrBadRequest(ep.logger, w, err)
+				return
+			}
+		}
+		config = b.Server.ToModelServer(uuid.Nil).Config()
+	}
+
+	if !ep.validateSchedule(w, config.Server(uuid.Nil)) {
+		return
+	}
+
+	template, err := ep.ctrl.CreateServerTemplate(r.Context(), b.Name, config)
+	if errors.Is(err, ierrors.ErrServerTemplateExists) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(w).Encode(ServerTemplateFromModel(*template)); err != nil {
+		ep.logger.Error("while encoding server template json", zap.Error(err))
+		return
+	}
+}
+
+type ServerTemplates struct{ API }
+
+func (ep ServerTemplates) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	templates, err := ep.ctrl.ListServerTemplates(r.Context())
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(ServerTemplatesFromModel(templates)); err != nil {
+		ep.logger.Error("while encoding server templates json", zap.Error(err))
+		return
+	}
+}
+
+type GetServerTemplate struct{ API }
+
+func (ep GetServerTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, templateIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	template, err := ep.ctrl.GetServerTemplate(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerTemplateDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(ServerTemplateFromModel(*template)); err != nil {
+		ep.logger.Error("while encoding server template json", zap.Error(err))
+		return
+	}
+}
+
+type DeleteServerTemplate struct{ API }
+
+func (ep DeleteServerTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, templateIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	err = ep.ctrl.DeleteServerTemplate(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerTemplateDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type CreateServerFromTemplate struct{ API }
+
+func (ep CreateServerFromTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, templateIDParam))
+	if err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	overrides, ok := ep.decodeServerOverrides(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := ep.ctrl.GetServerTemplate(r.Context(), id)
+	if errors.Is(err, ierrors.ErrServerTemplateDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	overrides.Apply(&template.Config)
+	ep.createServerFromConfig(w, r, template.Config)
+}
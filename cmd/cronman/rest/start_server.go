@@ -1,11 +1,9 @@
 package rest
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
-	"time"
 
 	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/model"
@@ -39,25 +37,22 @@ func (ep StartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, ok := server.(*model.DormantServer); !ok {
+	dormant, ok := server.(*model.DormantServer)
+	if !ok || dormant.Server.Lifecycle.IsTransitional() {
 		ihttp.ErrConflict(w)
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
-
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-		defer cancel()
+	job, err := ep.ctrl.StartServerJob(r.Context(), b.ServerID)
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
 
-		if _, err = ep.ctrl.StartServer(ctx, b.ServerID); err != nil {
-			ep.logger.Error("while starting server", zap.Error(err))
-			return
-		}
+	w.WriteHeader(http.StatusAccepted)
 
-		if _, err := ep.ctrl.MakeServerLive(ctx, b.ServerID); err != nil {
-			ep.logger.Error("while making server live", zap.Error(err))
-			return
-		}
-	}()
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding start server job json", zap.Error(err))
+		return
+	}
 }
@@ -1,11 +1,9 @@
 package rest
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
-	"time"
 
 	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/model"
@@ -39,20 +37,22 @@ func (ep StopServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, ok := server.(*model.LiveServer); !ok {
+	live, ok := server.(*model.LiveServer)
+	if !ok || live.Server.Lifecycle.IsTransitional() {
 		ihttp.ErrConflict(w)
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	job, err := ep.ctrl.StopServerJob(r.Context(), b.ServerID)
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
-		defer cancel()
+	w.WriteHeader(http.StatusAccepted)
 
-		if _, err := ep.ctrl.StopServer(ctx, b.ServerID); err != nil {
-			ep.logger.Error("while stopping server", zap.Error(err))
-			return
-		}
-	}()
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding stop server job json", zap.Error(err))
+		return
+	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/tjper/rustcron/cmd/cronman/controller"
 	"github.com/tjper/rustcron/cmd/cronman/model"
+	"github.com/tjper/rustcron/cmd/cronman/rcon"
 	imodel "github.com/tjper/rustcron/internal/model"
 
 	"github.com/google/uuid"
@@ -25,12 +26,14 @@ type CreateServerBody struct {
 	Background   model.BackgroundKind   `json:"background" validate:"required"`
 	BannerURL    string                 `json:"bannerURL" validate:"required,url"`
 	Region       model.Region           `json:"region" validate:"required"`
+	TimeZone     string                 `json:"timeZone" validate:"omitempty,timezone"`
 	Options      map[string]interface{} `json:"options"`
 
 	Events     Events     `json:"events" validate:"required,min=1,dive,required"`
 	Moderators Moderators `json:"moderators" validate:"required,dive,required"`
 	Owners     Owners     `json:"owners" validate:"required,min=1,dive,required"`
 	Tags       Tags       `json:"tags" validate:"required,dive,required"`
+	Countdown  *Countdown `json:"countdown" validate:"omitempty"`
 }
 
 func (body CreateServerBody) ToModelServer(id uuid.UUID) model.Server {
@@ -38,7 +41,7 @@ func (body CreateServerBody) ToModelServer(id uuid.UUID) model.Server {
 	for _, event := range body.Events {
 		events = append(
 			events,
-			model.Event{Schedule: event.Schedule, Weekday: event.Weekday, Kind: event.Kind},
+			model.Event{Schedule: event.Schedule, Weekday: event.Weekday, RunAt: event.RunAt, Kind: event.Kind},
 		)
 	}
 
@@ -66,6 +69,11 @@ func (body CreateServerBody) ToModelServer(id uuid.UUID) model.Server {
 		)
 	}
 
+	countdown := model.DefaultCountdown.Clone()
+	if body.Countdown != nil {
+		countdown = body.Countdown.ToModel()
+	}
+
 	return model.Server{
 		Model:        imodel.Model{ID: id},
 		Name:         body.Name,
@@ -79,6 +87,7 @@ func (body CreateServerBody) ToModelServer(id uuid.UUID) model.Server {
 		Background:   body.Background,
 		BannerURL:    body.BannerURL,
 		Region:       body.Region,
+		TimeZone:     body.TimeZone,
 		Options:      body.Options,
 		Wipes: model.Wipes{
 			{Kind: model.WipeKindFull, MapSeed: body.MapSeed, MapSalt: body.MapSalt},
@@ -87,6 +96,7 @@ func (body CreateServerBody) ToModelServer(id uuid.UUID) model.Server {
 		Moderators: moderators,
 		Owners:     owners,
 		Tags:       tags,
+		Countdown:  countdown,
 	}
 }
 
@@ -106,18 +116,13 @@ func (body CreateServerBody) validateOwnerAndModeratorIntersection() error {
 }
 
 type CreateServerResponse struct {
-	ID uuid.UUID `json:"id"`
-}
-
-func (r *CreateServerResponse) FromUUID(id uuid.UUID) {
-	*r = CreateServerResponse{
-		ID: id,
-	}
+	ID    uuid.UUID `json:"id"`
+	JobID uuid.UUID `json:"jobId"`
 }
 
 type PutServerBody struct {
 	ID      uuid.UUID              `json:"id" validate:"required"`
-	Changes map[string]interface{} `json:"changes" validate:"required,dive,keys,eq=name|eq=instanceKind|eq=maxPlayers|eq=mapSize|eq=mapSeed|eq=mapSalt|eq=tickRate|eq=rconPassword|eq=description|eq=url|eq=background|eq=bannerURL|eq=wipeDay|eq=blueprintWipeFrequency|eq=mapWipeFrequency|eq=region|eq=events|eq=moderators|eq=tags"`
+	Changes map[string]interface{} `json:"changes" validate:"required,dive,keys,eq=name|eq=instanceKind|eq=maxPlayers|eq=mapSize|eq=mapSeed|eq=mapSalt|eq=tickRate|eq=rconPassword|eq=description|eq=url|eq=background|eq=bannerURL|eq=wipeDay|eq=blueprintWipeFrequency|eq=mapWipeFrequency|eq=region|eq=timeZone|eq=events|eq=moderators|eq=tags"`
 }
 
 func (body PutServerBody) ToUpdateServerInput() controller.UpdateServerInput {
@@ -136,7 +141,7 @@ type RemoveServerTagsBody struct {
 
 type AddServerEventsBody struct {
 	ServerID uuid.UUID `json:"serverId" validate:"required"`
-	Events   Events    `json:"events" validate:"required"`
+	Events   Events    `json:"events" validate:"required,dive"`
 }
 
 type RemoveServerEventsBody struct {
@@ -144,6 +149,16 @@ type RemoveServerEventsBody struct {
 	EventIDs []uuid.UUID `json:"eventIds" validate:"required"`
 }
 
+type AddServerBlackoutsBody struct {
+	ServerID  uuid.UUID `json:"serverId" validate:"required"`
+	Blackouts Blackouts `json:"blackouts" validate:"required,dive"`
+}
+
+type RemoveServerBlackoutsBody struct {
+	ServerID    uuid.UUID   `json:"serverId" validate:"required"`
+	BlackoutIDs []uuid.UUID `json:"blackoutIds" validate:"required"`
+}
+
 type AddServerModeratorsBody struct {
 	ServerID   uuid.UUID  `json:"serverId" validate:"required"`
 	Moderators Moderators `json:"moderators" validate:"required"`
@@ -176,8 +191,13 @@ func ServerFromModel(server model.Server) *Server {
 		TickRate:     server.TickRate,
 		Description:  server.Description,
 		Background:   server.Background,
+		TimeZone:     server.TimeZone,
 		Tags:         TagsFromModel(server.Tags),
 		Events:       EventsFromModel(server.Events),
+		Blackouts:    BlackoutsFromModel(server.Blackouts),
+
+		Lifecycle:          server.Lifecycle,
+		LifecycleChangedAt: server.LifecycleChangedAt,
 	}
 }
 
@@ -192,8 +212,13 @@ type Server struct {
 	TickRate     uint8                `json:"tickRate"`
 	Description  string               `json:"description"`
 	Background   model.BackgroundKind `json:"background"`
+	TimeZone     string               `json:"timeZone"`
 	Tags         Tags                 `json:"tags"`
 	Events       Events               `json:"events"`
+	Blackouts    Blackouts            `json:"blackouts"`
+
+	Lifecycle          model.Lifecycle `json:"lifecycle"`
+	LifecycleChangedAt *time.Time      `json:"lifecycleChangedAt,omitempty"`
 }
 
 const (
@@ -210,7 +235,7 @@ type DormantServer struct {
 }
 
 func DormantServerFromModel(dormant model.DormantServer) (*DormantServer, error) {
-	_, at, err := dormant.Server.Events.NextEvent(time.Now().UTC(), model.EventKindLive)
+	_, at, err := dormant.Server.NextEvent(time.Now(), model.EventKindLive)
 	if err != nil {
 		return nil, err
 	}
@@ -231,9 +256,10 @@ type LiveServer struct {
 	Header
 	Server
 
-	ActivePlayers uint8     `json:"activePlayers"`
-	QueuedPlayers uint8     `json:"queuedPlayers"`
-	CreatedAt     time.Time `json:"createdAt"`
+	ActivePlayers uint8      `json:"activePlayers"`
+	QueuedPlayers uint8      `json:"queuedPlayers"`
+	LastSavedAt   *time.Time `json:"lastSavedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
 }
 
 func LiveServerFromModel(live model.LiveServer) *LiveServer {
@@ -246,6 +272,7 @@ func LiveServerFromModel(live model.LiveServer) *LiveServer {
 		Server:        *server,
 		ActivePlayers: live.ActivePlayers,
 		QueuedPlayers: live.QueuedPlayers,
+		LastSavedAt:   live.Server.LastSavedAt,
 		CreatedAt:     live.CreatedAt,
 	}
 }
@@ -253,6 +280,7 @@ func LiveServerFromModel(live model.LiveServer) *LiveServer {
 type ArchivedServer struct {
 	Header
 	Server
+	DecommissionedAt *time.Time `json:"decommissionedAt,omitempty"`
 }
 
 func ArchivedServerFromModel(archived model.ArchivedServer) *ArchivedServer {
@@ -262,7 +290,8 @@ func ArchivedServerFromModel(archived model.ArchivedServer) *ArchivedServer {
 			ID:   archived.Server.ID,
 			Kind: "archived",
 		},
-		Server: *server,
+		Server:           *server,
+		DecommissionedAt: archived.DecommissionedAt,
 	}
 }
 
@@ -320,6 +349,7 @@ func EventsFromModel(modelEvents model.Events) Events {
 				ID:       event.ID,
 				Schedule: event.Schedule,
 				Weekday:  event.Weekday,
+				RunAt:    event.RunAt,
 				Kind:     event.Kind,
 			},
 		)
@@ -337,6 +367,7 @@ func (events Events) ToModelEvents() model.Events {
 			model.Event{
 				Schedule: event.Schedule,
 				Weekday:  event.Weekday,
+				RunAt:    event.RunAt,
 				Kind:     event.Kind,
 			},
 		)
@@ -344,19 +375,83 @@ func (events Events) ToModelEvents() model.Events {
 	return modelEvents
 }
 
+// Event is a server event. Recurring events have a Schedule and optionally a
+// Weekday, one-off events have a RunAt.
 type Event struct {
 	ID       uuid.UUID       `json:"id"`
-	Schedule string          `json:"schedule" validate:"required,cron"`
-	Weekday  *time.Weekday   `json:"weekday,omitempty" validate:"omitempty,min=0,max=6"`
+	Schedule string          `json:"schedule,omitempty" validate:"omitempty,cron,excluded_with=RunAt"`
+	Weekday  *time.Weekday   `json:"weekday,omitempty" validate:"omitempty,min=0,max=6,excluded_with=RunAt"`
+	RunAt    *time.Time      `json:"runAt,omitempty" validate:"required_without=Schedule"`
 	Kind     model.EventKind `json:"kind" validate:"required"`
 }
 
+func BlackoutsFromModel(modelBlackouts model.Blackouts) Blackouts {
+	blackouts := make(Blackouts, 0, len(modelBlackouts))
+	for _, blackout := range modelBlackouts {
+		blackouts = append(
+			blackouts,
+			Blackout{
+				ID:       blackout.ID,
+				StartsAt: blackout.StartsAt,
+				EndsAt:   blackout.EndsAt,
+				Kind:     blackout.Kind,
+				Reason:   blackout.Reason,
+			},
+		)
+	}
+	return blackouts
+}
+
+type Blackouts []Blackout
+
+func (blackouts Blackouts) ToModelBlackouts() model.Blackouts {
+	modelBlackouts := make(model.Blackouts, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		modelBlackouts = append(
+			modelBlackouts,
+			model.Blackout{
+				StartsAt: blackout.StartsAt,
+				EndsAt:   blackout.EndsAt,
+				Kind:     blackout.Kind,
+				Reason:   blackout.Reason,
+			},
+		)
+	}
+	return modelBlackouts
+}
+
+// Blackout is a window of time during which a server's recurring events do
+// not occur. A Blackout without a Kind applies to events of every kind.
+type Blackout struct {
+	ID       uuid.UUID       `json:"id"`
+	StartsAt time.Time       `json:"startsAt" validate:"required"`
+	EndsAt   time.Time       `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	Kind     model.EventKind `json:"kind,omitempty" validate:"omitempty,oneof=start stop live fullWipe mapWipe"`
+	Reason   string          `json:"reason,omitempty"`
+}
+
 type EventAt struct {
 	ID   uuid.UUID       `json:"id"`
 	At   time.Time       `json:"at"`
 	Kind model.EventKind `json:"kind"`
 }
 
+func EventAtFromModel(occurrence model.Occurrence) EventAt {
+	return EventAt{
+		ID:   occurrence.Event.ID,
+		At:   occurrence.At,
+		Kind: occurrence.Event.Kind,
+	}
+}
+
+func EventAtsFromModel(occurrences []model.Occurrence) []EventAt {
+	res := make([]EventAt, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		res = append(res, EventAtFromModel(occurrence))
+	}
+	return res
+}
+
 func ModeratorsFromModel(modelModerators model.Moderators) Moderators {
 	moderators := make(Moderators, 0, len(modelModerators))
 	for _, moderator := range modelModerators {
@@ -424,3 +519,447 @@ type Owner struct {
 	ID      uuid.UUID `json:"id"`
 	SteamID string    `json:"steamId" validate:"required"`
 }
+
+type AddServerBansBody struct {
+	ServerID uuid.UUID `json:"serverId" validate:"required"`
+	Bans     Bans      `json:"bans" validate:"required,dive,required"`
+}
+
+type RemoveServerBansBody struct {
+	ServerID uuid.UUID   `json:"serverId" validate:"required"`
+	BanIDs   []uuid.UUID `json:"banIds" validate:"required"`
+}
+
+func BansFromModel(modelBans model.Bans) Bans {
+	bans := make(Bans, 0, len(modelBans))
+	for _, ban := range modelBans {
+		bans = append(
+			bans,
+			Ban{
+				ID:      ban.ID,
+				SteamID: ban.SteamID,
+				Reason:  ban.Reason,
+			},
+		)
+	}
+	return bans
+}
+
+type Bans []Ban
+
+func (bans Bans) ToModelBans() model.Bans {
+	modelBans := make(model.Bans, 0, len(bans))
+	for _, ban := range bans {
+		modelBans = append(
+			modelBans,
+			model.Ban{
+				SteamID: ban.SteamID,
+				Reason:  ban.Reason,
+			},
+		)
+	}
+	return modelBans
+}
+
+type Ban struct {
+	ID      uuid.UUID `json:"id"`
+	SteamID string    `json:"steamId" validate:"required,numeric"`
+	Reason  string    `json:"reason" validate:"required"`
+}
+
+type EnableServerPluginsBody struct {
+	ServerID  uuid.UUID   `json:"serverId" validate:"required"`
+	PluginIDs []uuid.UUID `json:"pluginIds" validate:"required,min=1"`
+}
+
+type DisableServerPluginsBody struct {
+	ServerID  uuid.UUID   `json:"serverId" validate:"required"`
+	PluginIDs []uuid.UUID `json:"pluginIds" validate:"required,min=1"`
+}
+
+func PluginsFromModel(modelPlugins model.Plugins) []Plugin {
+	plugins := make([]Plugin, 0, len(modelPlugins))
+	for _, plugin := range modelPlugins {
+		grants := make([]PluginGrant, 0, len(plugin.Grants))
+		for _, grant := range plugin.Grants {
+			grants = append(
+				grants,
+				PluginGrant{Group: grant.Group, Permission: grant.Permission},
+			)
+		}
+
+		plugins = append(
+			plugins,
+			Plugin{
+				ID:      plugin.ID,
+				Name:    plugin.Name,
+				URL:     plugin.URL,
+				Version: plugin.Version,
+				Default: plugin.Default,
+				Grants:  grants,
+			},
+		)
+	}
+	return plugins
+}
+
+type Plugin struct {
+	ID      uuid.UUID     `json:"id"`
+	Name    string        `json:"name"`
+	URL     string        `json:"url"`
+	Version string        `json:"version"`
+	Default bool          `json:"default"`
+	Grants  []PluginGrant `json:"grants"`
+}
+
+type PluginGrant struct {
+	Group      string `json:"group"`
+	Permission string `json:"permission"`
+}
+
+// Countdown is a server's pre-shutdown warning schedule. See model.Countdown
+// for more details.
+type Countdown struct {
+	Warnings        []uint32 `json:"warnings" validate:"max=16,dive,min=1,max=86400"`
+	FinalSeconds    uint32   `json:"finalSeconds" validate:"max=60"`
+	WarningTemplate string   `json:"warningTemplate" validate:"required_with=Warnings,max=256"`
+	FinalTemplate   string   `json:"finalTemplate" validate:"required_with=FinalSeconds,max=256"`
+}
+
+func CountdownFromModel(countdown model.Countdown) Countdown {
+	warnings := make([]uint32, 0, len(countdown.Warnings))
+	warnings = append(warnings, countdown.Warnings...)
+	return Countdown{
+		Warnings:        warnings,
+		FinalSeconds:    countdown.FinalSeconds,
+		WarningTemplate: countdown.WarningTemplate,
+		FinalTemplate:   countdown.FinalTemplate,
+	}
+}
+
+func (c Countdown) ToModel() model.Countdown {
+	warnings := make([]uint32, 0, len(c.Warnings))
+	warnings = append(warnings, c.Warnings...)
+	return model.Countdown{
+		Warnings:        warnings,
+		FinalSeconds:    c.FinalSeconds,
+		WarningTemplate: c.WarningTemplate,
+		FinalTemplate:   c.FinalTemplate,
+	}
+}
+
+type KickServerPlayerBody struct {
+	SteamID string `json:"steamId" validate:"required,numeric"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+func PlayersFromRcon(rconPlayers []rcon.Player) []Player {
+	players := make([]Player, 0, len(rconPlayers))
+	for _, player := range rconPlayers {
+		players = append(
+			players,
+			Player{
+				SteamID:          player.SteamID,
+				DisplayName:      player.DisplayName,
+				Ping:             player.Ping,
+				ConnectedSeconds: player.ConnectedSeconds,
+				Health:           player.Health,
+			},
+		)
+	}
+	return players
+}
+
+type Player struct {
+	SteamID          string  `json:"steamId"`
+	DisplayName      string  `json:"displayName"`
+	Ping             int     `json:"ping"`
+	ConnectedSeconds int     `json:"connectedSeconds"`
+	Health           float32 `json:"health"`
+}
+
+type ExecServerRconBody struct {
+	Command string `json:"command" validate:"required"`
+}
+
+func RconCommandFromModel(command model.RconCommand) RconCommand {
+	return RconCommand{
+		ID:           command.ID,
+		ServerID:     command.ServerID,
+		UserID:       command.UserID,
+		Command:      command.Command,
+		Response:     command.Response,
+		ResponseType: command.ResponseType,
+		Error:        command.Error,
+		CreatedAt:    command.CreatedAt,
+	}
+}
+
+func RconCommandsFromModel(modelCommands model.RconCommands) []RconCommand {
+	commands := make([]RconCommand, 0, len(modelCommands))
+	for _, command := range modelCommands {
+		commands = append(commands, RconCommandFromModel(command))
+	}
+	return commands
+}
+
+type RconCommand struct {
+	ID           uuid.UUID `json:"id"`
+	ServerID     uuid.UUID `json:"serverId"`
+	UserID       uuid.UUID `json:"userId"`
+	Command      string    `json:"command"`
+	Response     string    `json:"response"`
+	ResponseType string    `json:"responseType"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type Job struct {
+	ID             uuid.UUID       `json:"id"`
+	Kind           model.JobKind   `json:"kind"`
+	ServerID       uuid.UUID       `json:"serverId"`
+	Status         model.JobStatus `json:"status"`
+	Step           string          `json:"step,omitempty"`
+	StepsCompleted uint8           `json:"stepsCompleted"`
+	Steps          uint8           `json:"steps"`
+	Error          string          `json:"error,omitempty"`
+	Attempts       uint8           `json:"attempts"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	StartedAt      *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time      `json:"finishedAt,omitempty"`
+}
+
+func JobFromModel(job model.Job) Job {
+	return Job{
+		ID:             job.ID,
+		Kind:           job.Kind,
+		ServerID:       job.ServerID,
+		Status:         job.Status,
+		Step:           job.Step,
+		StepsCompleted: job.StepsCompleted,
+		Steps:          job.Steps,
+		Error:          job.Error,
+		Attempts:       job.Attempts,
+		CreatedAt:      job.CreatedAt,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+	}
+}
+
+// ServerOverrides are changes made to a server configuration when creating a
+// server from an existing server or a template. Nil fields are not changed.
+type ServerOverrides struct {
+	Name         *string             `json:"name" validate:"omitempty,min=1"`
+	InstanceKind *model.InstanceKind `json:"instanceKind" validate:"omitempty,min=1"`
+	MaxPlayers   *uint16             `json:"maxPlayers" validate:"omitempty,min=1"`
+	MapSeed      *uint32             `json:"mapSeed" validate:"omitempty,min=1"`
+	MapSalt      *uint32             `json:"mapSalt" validate:"omitempty,min=1"`
+	RconPassword *string             `json:"rconPassword" validate:"omitempty,min=1"`
+	Description  *string             `json:"description" validate:"omitempty,min=1"`
+	URL          *string             `json:"url" validate:"omitempty,url"`
+	BannerURL    *string             `json:"bannerURL" validate:"omitempty,url"`
+	Region       *model.Region       `json:"region" validate:"omitempty,min=1"`
+	TimeZone     *string             `json:"timeZone" validate:"omitempty,timezone"`
+}
+
+// Apply makes the overrides to the config.
+func (overrides ServerOverrides) Apply(config *model.ServerConfig) {
+	if overrides.Name != nil {
+		config.Name = *overrides.Name
+	}
+	if overrides.InstanceKind != nil {
+		config.InstanceKind = *overrides.InstanceKind
+	}
+	if overrides.MaxPlayers != nil {
+		config.MaxPlayers = *overrides.MaxPlayers
+	}
+	if overrides.MapSeed != nil {
+		config.MapSeed = *overrides.MapSeed
+	}
+	if overrides.MapSalt != nil {
+		config.MapSalt = *overrides.MapSalt
+	}
+	if overrides.RconPassword != nil {
+		config.RconPassword = *overrides.RconPassword
+	}
+	if overrides.Description != nil {
+		config.Description = *overrides.Description
+	}
+	if overrides.URL != nil {
+		config.URL = *overrides.URL
+	}
+	if overrides.BannerURL != nil {
+		config.BannerURL = *overrides.BannerURL
+	}
+	if overrides.Region != nil {
+		config.Region = *overrides.Region
+	}
+	if overrides.TimeZone != nil {
+		config.TimeZone = *overrides.TimeZone
+	}
+}
+
+type CreateServerTemplateBody struct {
+	Name     string            `json:"name" validate:"required,max=64"`
+	ServerID *uuid.UUID        `json:"serverId" validate:"required_without=Server"`
+	Server   *CreateServerBody `json:"server" validate:"required_without=ServerID"`
+}
+
+type ServerTemplate struct {
+	ID        uuid.UUID          `json:"id"`
+	Name      string             `json:"name"`
+	Config    model.ServerConfig `json:"config"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func ServerTemplateFromModel(template model.ServerTemplate) ServerTemplate {
+	return ServerTemplate{
+		ID:        template.ID,
+		Name:      template.Name,
+		Config:    template.Config,
+		CreatedAt: template.CreatedAt,
+	}
+}
+
+func ServerTemplatesFromModel(templates []model.ServerTemplate) []ServerTemplate {
+	converted := make([]ServerTemplate, 0, len(templates))
+	for _, template := range templates {
+		converted = append(converted, ServerTemplateFromModel(template))
+	}
+	return converted
+}
+
+type AuditEntry struct {
+	ID        uuid.UUID          `json:"id"`
+	ServerID  uuid.UUID          `json:"serverId"`
+	Actor     model.Actor        `json:"actor"`
+	Action    model.AuditAction  `json:"action"`
+	Changes   model.AuditChanges `json:"changes"`
+	Outcome   model.AuditOutcome `json:"outcome"`
+	Error     string             `json:"error,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func AuditEntryFromModel(entry model.AuditEntry) AuditEntry {
+	return AuditEntry{
+		ID:        entry.ID,
+		ServerID:  entry.ServerID,
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		Changes:   entry.Changes,
+		Outcome:   entry.Outcome,
+		Error:     entry.Error,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func AuditEntriesFromModel(entries []model.AuditEntry) []AuditEntry {
+	res := make([]AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, AuditEntryFromModel(entry))
+	}
+	return res
+}
+
+// ServerHistoryPage is a page of a server's audit history, most recent
+// first.
+type ServerHistoryPage struct {
+	Entries []AuditEntry `json:"entries"`
+	// NextOffset is the offset of the next page, if any.
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+type Execution struct {
+	ID          uuid.UUID              `json:"id"`
+	EventID     uuid.UUID              `json:"eventId"`
+	ServerID    uuid.UUID              `json:"serverId"`
+	Kind        model.EventKind        `json:"kind"`
+	Actor       model.Actor            `json:"actor"`
+	ScheduledAt time.Time              `json:"scheduledAt"`
+	StartedAt   time.Time              `json:"startedAt"`
+	FinishedAt  *time.Time             `json:"finishedAt,omitempty"`
+	Outcome     model.ExecutionOutcome `json:"outcome"`
+	Error       string                 `json:"error,omitempty"`
+	RetryOf     *uuid.UUID             `json:"retryOf,omitempty"`
+}
+
+func ExecutionFromModel(execution model.Execution) Execution {
+	return Execution{
+		ID:          execution.ID,
+		EventID:     execution.EventID,
+		ServerID:    execution.ServerID,
+		Kind:        execution.Kind,
+		Actor:       execution.Actor,
+		ScheduledAt: execution.ScheduledAt,
+		StartedAt:   execution.StartedAt,
+		FinishedAt:  execution.FinishedAt,
+		Outcome:     execution.Outcome,
+		Error:       execution.Error,
+		RetryOf:     execution.RetryOf,
+	}
+}
+
+func ExecutionsFromModel(executions []model.Execution) []Execution {
+	res := make([]Execution, 0, len(executions))
+	for _, execution := range executions {
+		res = append(res, ExecutionFromModel(execution))
+	}
+	return res
+}
+
+// ExecutionsPage is a page of event executions, most recent first.
+type ExecutionsPage struct {
+	Executions []Execution `json:"executions"`
+	// NextOffset is the offset of the next page, if any.
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+func ScheduleFromModel(schedule model.Schedule) ServerSchedule {
+	return ServerSchedule{
+		PublicServerSchedule: PublicServerScheduleFromModel(schedule),
+		Timeline:             EventAtsFromModel(schedule.Occurrences),
+	}
+}
+
+// ServerSchedule is the expansion of a server's events over a period of time.
+type ServerSchedule struct {
+	PublicServerSchedule
+	// Timeline is each start, live, stop and wipe within the schedule ordered
+	// by time.
+	Timeline []EventAt `json:"timeline"`
+}
+
+func PublicServerScheduleFromModel(schedule model.Schedule) PublicServerSchedule {
+	windows := make([]Window, 0, len(schedule.OnlineWindows))
+	for _, window := range schedule.OnlineWindows {
+		windows = append(windows, Window{StartsAt: window.StartsAt, EndsAt: window.EndsAt})
+	}
+
+	var nextWipe *EventAt
+	if schedule.NextWipe != nil {
+		wipe := EventAtFromModel(*schedule.NextWipe)
+		nextWipe = &wipe
+	}
+
+	return PublicServerSchedule{
+		From:          schedule.From,
+		To:            schedule.To,
+		OnlineWindows: windows,
+		NextWipe:      nextWipe,
+	}
+}
+
+// PublicServerSchedule is the portion of a server's schedule that is shown
+// publicly, when the server is expected to be online and when it next wipes.
+type PublicServerSchedule struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	OnlineWindows []Window  `json:"onlineWindows"`
+	NextWipe      *EventAt  `json:"nextWipe,omitempty"`
+}
+
+// Window is a period of time starting at StartsAt and ending at EndsAt.
+type Window struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
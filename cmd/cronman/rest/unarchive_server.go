@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	ihttp "github.com/tjper/rustcron/internal/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UnarchiveServer struct{ API }
+
+func (ep UnarchiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type body struct {
+		ServerID uuid.UUID `validate:"required"`
+	}
+
+	var b body
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	if err := ep.valid.Struct(b); err != nil {
+		ihttp.ErrBadRequest(ep.logger, w, err)
+		return
+	}
+
+	job, err := ep.ctrl.UnarchiveServerJob(r.Context(), b.ServerID)
+	if errors.Is(err, ierrors.ErrServerDNE) {
+		ihttp.ErrNotFound(w)
+		return
+	}
+	if errors.Is(err, ierrors.ErrServerNotArchived) ||
+		errors.Is(err, ierrors.ErrServerTransition) {
+		ihttp.ErrConflict(w)
+		return
+	}
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding unarchive server job json", zap.Error(err))
+		return
+	}
+}
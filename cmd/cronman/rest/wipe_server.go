@@ -1,11 +1,9 @@
 package rest
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
-	"time"
 
 	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
 	"github.com/tjper/rustcron/cmd/cronman/mapgen"
@@ -65,7 +63,12 @@ func (ep WipeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_, isDormant := serverI.(*model.DormantServer)
 
 	if isDormant {
-		if err := ep.ctrl.WipeServer(r.Context(), b.ServerID, wipe); err != nil {
+		err := ep.ctrl.WipeServer(r.Context(), b.ServerID, wipe)
+		if errors.Is(err, ierrors.ErrServerTransition) {
+			ihttp.ErrConflict(w)
+			return
+		}
+		if err != nil {
 			ihttp.ErrInternal(ep.logger, w, err)
 			return
 		}
@@ -74,31 +77,16 @@ func (ep WipeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	job, err := ep.ctrl.WipeServerJob(r.Context(), b.ServerID, wipe)
+	if err != nil {
+		ihttp.ErrInternal(ep.logger, w, err)
+		return
+	}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
-		defer cancel()
+	w.WriteHeader(http.StatusAccepted)
 
-		if _, err := ep.ctrl.StopServer(ctx, b.ServerID); err != nil {
-			ihttp.ErrInternal(ep.logger, w, err)
-			return
-		}
-		defer func() {
-			if _, err := ep.ctrl.StartServer(ctx, b.ServerID); err != nil {
-				ep.logger.Error("while restarting a wiped server", zap.Error(err))
-				return
-			}
-
-			if _, err := ep.ctrl.MakeServerLive(ctx, b.ServerID); err != nil {
-				ep.logger.Error("while make a wiped server live", zap.Error(err))
-				return
-			}
-		}()
-
-		if err := ep.ctrl.WipeServer(ctx, b.ServerID, wipe); err != nil {
-			ihttp.ErrInternal(ep.logger, w, err)
-			return
-		}
-	}()
+	if err := json.NewEncoder(w).Encode(JobFromModel(*job)); err != nil {
+		ep.logger.Error("while encoding wipe server job json", zap.Error(err))
+		return
+	}
 }
@@ -0,0 +1,396 @@
+// Package runner is responsible for running durable jobs, long-running server
+// operations that are enqueued by the API.
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/db"
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Run claims and runs jobs until the context is cancelled. Jobs that are
+// running when the context is cancelled are left running, they are resumed by
+// a Runner once they have been orphaned.
+func (r Runner) Run(ctx context.Context) error {
+	sem := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := db.FailAbandonedJobs(ctx, r.store, orphaned(), maxAttempts); err != nil {
+			r.logger.Error("while failing abandoned jobs", zap.Error(err))
+		}
+
+	claim:
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case sem <- struct{}{}:
+			}
+
+			job, err := db.ClaimJob(ctx, r.store, orphaned(), maxAttempts)
+			if err != nil {
+				<-sem
+				if !errors.Is(err, db.ErrNoJobs) {
+					r.logger.Error("while claiming job", zap.Error(err))
+				}
+				break claim
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				r.run(ctx, *job)
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// orphaned is the time before which a running job's heartbeat must have
+// occurred for the job to be considered abandoned.
+func orphaned() time.Time {
+	return time.Now().Add(-orphanedAfter)
+}
+
+// timeouts are the maximum durations each kind of job may run.
+var timeouts = map[model.JobKind]time.Duration{
+	model.JobKindCreateServer: 20 * time.Minute,
+	model.JobKindStartServer:  30 * time.Minute,
+	model.JobKindStopServer:   20 * time.Minute,
+	model.JobKindWipeServer:   time.Hour,
+
+	model.JobKindDecommissionServer: 20 * time.Minute,
+	model.JobKindUnarchiveServer:    20 * time.Minute,
+
+	// A retried wipe execution takes as long as a wipe job.
+	model.JobKindRetryExecution: time.Hour,
+}
+
+// finishTimeout is the maximum duration recording a job's outcome may take.
+const finishTimeout = 10 * time.Second
+
+// run runs the claimed job and records its outcome. While the job is running
+// its heartbeat is updated. If the attempt becomes stale, e.g. the job was
+// claimed by another Runner after heartbeats failed, the job's context is
+// cancelled and its outcome is not recorded.
+func (r Runner) run(ctx context.Context, job model.Job) {
+	logger := r.logger.With(
+		zap.Stringer("job-id", job.ID),
+		zap.String("job-kind", string(job.Kind)),
+		zap.Stringer("server-id", job.ServerID),
+		zap.Uint8("attempt", job.Attempts),
+	)
+	logger.Info("running job")
+
+	jobCtx, cancel := context.WithTimeout(ctx, timeouts[job.Kind])
+	defer cancel()
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+	go r.heartbeat(heartbeatCtx, logger, job, cancel)
+
+	progress := func(step string, completed uint8) {
+		err := db.UpdateJobProgress(ctx, r.store, job.ID, job.Attempts, step, completed)
+		if errors.Is(err, db.ErrJobAttemptStale) {
+			logger.Warn("job attempt stale; cancelling job")
+			cancel()
+			return
+		}
+		if err != nil {
+			logger.Error("while updating job progress", zap.Error(err))
+		}
+	}
+	err := r.execute(jobCtx, job, progress)
+
+	// The Runner is shutting down, the job is left running so that it may be
+	// resumed.
+	if ctx.Err() != nil {
+		logger.Info("job interrupted", zap.Error(err))
+		return
+	}
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), finishTimeout)
+	defer finishCancel()
+
+	ferr := db.FinishJob(finishCtx, r.store, job.ID, job.Attempts, err)
+	if errors.Is(ferr, db.ErrJobAttemptStale) {
+		logger.Warn("job attempt stale; outcome not recorded", zap.Error(err))
+		return
+	}
+	if ferr != nil {
+		logger.Error("while finishing job", zap.Error(ferr))
+		return
+	}
+	if err != nil {
+		logger.Error("job failed", zap.Error(err))
+		return
+	}
+	logger.Info("job succeeded")
+}
+
+// heartbeat updates the heartbeat of the job's attempt until the context is
+// cancelled. If the attempt is stale, cancel is called so that the attempt
+// stops.
+func (r Runner) heartbeat(
+	ctx context.Context,
+	logger *zap.Logger,
+	job model.Job,
+	cancel context.CancelFunc,
+) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := db.HeartbeatJob(ctx, r.store, job.ID, job.Attempts)
+			if errors.Is(err, db.ErrJobAttemptStale) {
+				logger.Warn("job attempt stale; cancelling job")
+				cancel()
+				return
+			}
+			if err != nil {
+				logger.Error("while updating job heartbeat", zap.Error(err))
+			}
+		}
+	}
+}
+
+// step is a single step of a job.
+type step struct {
+	name string
+	run  func(context.Context) error
+}
+
+var errJobKind = errors.New("job kind invalid")
+
+// execute executes each of the job's steps, reporting progress prior to each
+// step. Steps are written to be resumed, if a previous attempt at the job was
+// interrupted, steps that have already taken effect are skipped.
+func (r Runner) execute(
+	ctx context.Context,
+	job model.Job,
+	progress func(step string, completed uint8),
+) error {
+	steps, err := r.steps(job)
+	if err != nil {
+		return err
+	}
+
+	// The job's actions are performed on behalf of the actor that enqueued
+	// it.
+	if job.Actor.Kind != "" {
+		ctx = model.WithActor(ctx, job.Actor)
+	}
+
+	// A previous attempt was interrupted and may have left the server
+	// transitioning.
+	if job.Attempts > 1 {
+		err := r.controller.RecoverServer(ctx, job.ServerID)
+		if err != nil && !errors.Is(err, ierrors.ErrServerDNE) {
+			return fmt.Errorf("while recovering server: %w", err)
+		}
+	}
+
+	for i, step := range steps {
+		progress(step.name, uint8(i))
+		if err := step.run(ctx); err != nil {
+			return fmt.Errorf("while %s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+// steps builds the steps the job consists of.
+func (r Runner) steps(job model.Job) ([]step, error) {
+	switch job.Kind {
+	case model.JobKindCreateServer:
+		var server model.Server
+		if err := json.Unmarshal(job.Payload, &server); err != nil {
+			return nil, fmt.Errorf("while unmarshalling create server job payload: %w", err)
+		}
+		return []step{r.createServer(server)}, nil
+
+	case model.JobKindStartServer:
+		return []step{
+			r.startServer(job.ServerID),
+			r.makeServerLive(job.ServerID),
+		}, nil
+
+	case model.JobKindStopServer:
+		return []step{r.stopServer(job.ServerID)}, nil
+
+	case model.JobKindWipeServer:
+		var wipe model.Wipe
+		if err := json.Unmarshal(job.Payload, &wipe); err != nil {
+			return nil, fmt.Errorf("while unmarshalling wipe server job payload: %w", err)
+		}
+		// The wipe is only applied if a previous attempt did not complete
+		// the wipe step. An attempt that applied the wipe but was
+		// interrupted before completing the step is skipped by the wipe's
+		// ID.
+		wipeStep := r.wipeServer(job.ServerID, wipe)
+		if job.StepsCompleted > 1 {
+			wipeStep.run = func(context.Context) error { return nil }
+		}
+		return []step{
+			r.stopServer(job.ServerID),
+			wipeStep,
+			r.startServer(job.ServerID),
+			r.makeServerLive(job.ServerID),
+		}, nil
+
+	case model.JobKindDecommissionServer:
+		return []step{r.decommissionServer(job.ServerID)}, nil
+
+	case model.JobKindUnarchiveServer:
+		return []step{r.unarchiveServer(job.ServerID)}, nil
+
+	case model.JobKindRetryExecution:
+		var payload model.RetryExecutionPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("while unmarshalling retry execution job payload: %w", err)
+		}
+		return []step{r.retryExecution(payload.ExecutionID)}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errJobKind, job.Kind)
+}
+
+func (r Runner) createServer(server model.Server) step {
+	return step{
+		name: "creating server",
+		run: func(ctx context.Context) error {
+			existing, _, err := r.server(ctx, server.ID)
+			if errors.Is(err, ierrors.ErrServerDNE) {
+				_, err := r.controller.CreateServer(ctx, server)
+				return err
+			}
+			if err != nil {
+				return err
+			}
+
+			// The server was created by a previous attempt.
+			if existing.Lifecycle == model.LifecycleFailed {
+				return errors.New(existing.LifecycleReason)
+			}
+			return nil
+		},
+	}
+}
+
+func (r Runner) startServer(id uuid.UUID) step {
+	return step{
+		name: "starting server",
+		run: func(ctx context.Context) error {
+			if _, live, err := r.server(ctx, id); err != nil || live {
+				return err
+			}
+			_, err := r.controller.StartServer(ctx, id)
+			return err
+		},
+	}
+}
+
+func (r Runner) makeServerLive(id uuid.UUID) step {
+	return step{
+		name: "making server live",
+		run: func(ctx context.Context) error {
+			if _, live, err := r.server(ctx, id); err != nil || live {
+				return err
+			}
+			_, err := r.controller.MakeServerLive(ctx, id)
+			return err
+		},
+	}
+}
+
+func (r Runner) stopServer(id uuid.UUID) step {
+	return step{
+		name: "stopping server",
+		run: func(ctx context.Context) error {
+			if _, live, err := r.server(ctx, id); err != nil || !live {
+				return err
+			}
+			_, err := r.controller.StopServer(ctx, id)
+			return err
+		},
+	}
+}
+
+func (r Runner) wipeServer(id uuid.UUID, wipe model.Wipe) step {
+	return step{
+		name: "wiping server",
+		run: func(ctx context.Context) error {
+			return r.controller.WipeServer(ctx, id, wipe)
+		},
+	}
+}
+
+func (r Runner) decommissionServer(id uuid.UUID) step {
+	return step{
+		name: "decommissioning server",
+		run: func(ctx context.Context) error {
+			_, err := r.controller.DecommissionServer(ctx, id)
+			return err
+		},
+	}
+}
+
+func (r Runner) unarchiveServer(id uuid.UUID) step {
+	return step{
+		name: "unarchiving server",
+		run: func(ctx context.Context) error {
+			_, err := r.controller.UnarchiveServer(ctx, id)
+			return err
+		},
+	}
+}
+
+func (r Runner) retryExecution(id uuid.UUID) step {
+	return step{
+		name: "retrying execution",
+		run: func(ctx context.Context) error {
+			_, err := r.controller.RetryExecution(ctx, id)
+			return err
+		},
+	}
+}
+
+// server retrieves the server specified by id, and reports if it is live.
+func (r Runner) server(ctx context.Context, id uuid.UUID) (*model.Server, bool, error) {
+	serverI, err := r.controller.GetServer(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch server := serverI.(type) {
+	case *model.LiveServer:
+		return &server.Server, true, nil
+	case *model.DormantServer:
+		return &server.Server, false, nil
+	}
+	return nil, false, fmt.Errorf("unexpected server type %T", serverI)
+}
@@ -0,0 +1,372 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ierrors "github.com/tjper/rustcron/cmd/cronman/errors"
+	"github.com/tjper/rustcron/cmd/cronman/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	serverID := uuid.New()
+	errStart := errors.New("instance failed to start")
+
+	newJob := func(job *model.Job, err error) model.Job {
+		require.Nil(t, err)
+		job.ServerID = serverID
+		job.Attempts = 1
+		return *job
+	}
+
+	type expected struct {
+		calls    []string
+		progress []string
+		err      error
+	}
+	tests := map[string]struct {
+		job        model.Job
+		controller *controllerFake
+		exp        expected
+	}{
+		"create server": {
+			job: newJob(model.NewCreateServerJob(model.Server{Name: "create"})),
+			controller: &controllerFake{
+				getErr: ierrors.ErrServerDNE,
+			},
+			exp: expected{
+				calls:    []string{"GetServer", "CreateServer"},
+				progress: []string{"creating server"},
+			},
+		},
+		"create server already created": {
+			job: func() model.Job {
+				job := newJob(model.NewCreateServerJob(model.Server{Name: "create"}))
+				job.Attempts = 2
+				return job
+			}(),
+			controller: &controllerFake{
+				lifecycle: model.LifecycleIdle,
+			},
+			exp: expected{
+				calls:    []string{"RecoverServer", "GetServer"},
+				progress: []string{"creating server"},
+			},
+		},
+		"create server already failed": {
+			job: func() model.Job {
+				job := newJob(model.NewCreateServerJob(model.Server{Name: "create"}))
+				job.Attempts = 2
+				return job
+			}(),
+			controller: &controllerFake{
+				lifecycle: model.LifecycleFailed,
+				reason:    "while creating instance: quota exceeded",
+			},
+			exp: expected{
+				calls:    []string{"RecoverServer", "GetServer"},
+				progress: []string{"creating server"},
+				err:      errors.New("while creating server: while creating instance: quota exceeded"),
+			},
+		},
+		"start server": {
+			job:        newJob(model.NewStartServerJob(serverID), nil),
+			controller: &controllerFake{},
+			exp: expected{
+				calls: []string{
+					"GetServer", "StartServer",
+					"GetServer", "MakeServerLive",
+				},
+				progress: []string{"starting server", "making server live"},
+			},
+		},
+		"start live server": {
+			job:        newJob(model.NewStartServerJob(serverID), nil),
+			controller: &controllerFake{live: true},
+			exp: expected{
+				calls:    []string{"GetServer", "GetServer"},
+				progress: []string{"starting server", "making server live"},
+			},
+		},
+		"start server fails": {
+			job:        newJob(model.NewStartServerJob(serverID), nil),
+			controller: &controllerFake{startErr: errStart},
+			exp: expected{
+				calls:    []string{"GetServer", "StartServer"},
+				progress: []string{"starting server"},
+				err:      errStart,
+			},
+		},
+		"resume start server": {
+			job: func() model.Job {
+				job := newJob(model.NewStartServerJob(serverID), nil)
+				job.Attempts = 2
+				job.StepsCompleted = 1
+				return job
+			}(),
+			controller: &controllerFake{},
+			exp: expected{
+				calls: []string{
+					"RecoverServer",
+					"GetServer", "StartServer",
+					"GetServer", "MakeServerLive",
+				},
+				progress: []string{"starting server", "making server live"},
+			},
+		},
+		"stop server": {
+			job:        newJob(model.NewStopServerJob(serverID), nil),
+			controller: &controllerFake{live: true},
+			exp: expected{
+				calls:    []string{"GetServer", "StopServer"},
+				progress: []string{"stopping server"},
+			},
+		},
+		"stop dormant server": {
+			job:        newJob(model.NewStopServerJob(serverID), nil),
+			controller: &controllerFake{},
+			exp: expected{
+				calls:    []string{"GetServer"},
+				progress: []string{"stopping server"},
+			},
+		},
+		"wipe server": {
+			job:        newJob(model.NewWipeServerJob(serverID, model.Wipe{Kind: model.WipeKindMap})),
+			controller: &controllerFake{live: true},
+			exp: expected{
+				calls: []string{
+					"GetServer", "StopServer",
+					"WipeServer",
+					"GetServer", "StartServer",
+					"GetServer", "MakeServerLive",
+				},
+				progress: []string{
+					"stopping server",
+					"wiping server",
+					"starting server",
+					"making server live",
+				},
+			},
+		},
+		"resume wipe server after wipe": {
+			job: func() model.Job {
+				job := newJob(model.NewWipeServerJob(serverID, model.Wipe{Kind: model.WipeKindMap}))
+				job.Attempts = 2
+				job.StepsCompleted = 2
+				return job
+			}(),
+			controller: &controllerFake{},
+			exp: expected{
+				calls: []string{
+					"RecoverServer",
+					"GetServer",
+					"GetServer", "StartServer",
+					"GetServer", "MakeServerLive",
+				},
+				progress: []string{
+					"stopping server",
+					"wiping server",
+					"starting server",
+					"making server live",
+				},
+			},
+		},
+		"decommission server": {
+			job:        newJob(model.NewDecommissionServerJob(serverID), nil),
+			controller: &controllerFake{},
+			exp: expected{
+				calls:    []string{"DecommissionServer"},
+				progress: []string{"decommissioning server"},
+			},
+		},
+		"resume unarchive server": {
+			job: func() model.Job {
+				job := newJob(model.NewUnarchiveServerJob(serverID), nil)
+				job.Attempts = 2
+				return job
+			}(),
+			controller: &controllerFake{},
+			exp: expected{
+				calls:    []string{"RecoverServer", "UnarchiveServer"},
+				progress: []string{"unarchiving server"},
+			},
+		},
+		"retry execution": {
+			job: newJob(model.NewRetryExecutionJob(model.Execution{
+				ID:       uuid.New(),
+				ServerID: serverID,
+				Kind:     model.EventKindStart,
+				Outcome:  model.ExecutionOutcomeFailed,
+			})),
+			controller: &controllerFake{},
+			exp: expected{
+				calls:    []string{"RetryExecution"},
+				progress: []string{"retrying execution"},
+			},
+		},
+		"invalid kind": {
+			job:        model.Job{Kind: "reticulateSplines", ServerID: serverID},
+			controller: &controllerFake{},
+			exp: expected{
+				err: errJobKind,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := New(zap.NewNop(), nil, test.controller)
+
+			var progress []string
+			var completed []uint8
+			err := runner.execute(
+				context.Background(),
+				test.job,
+				func(step string, c uint8) {
+					progress = append(progress, step)
+					completed = append(completed, c)
+				},
+			)
+
+			switch {
+			case test.exp.err == nil:
+				require.Nil(t, err)
+			case errors.Is(err, test.exp.err):
+			default:
+				require.EqualError(t, err, test.exp.err.Error())
+			}
+			require.Equal(t, test.exp.calls, test.controller.calls)
+			require.Equal(t, test.exp.progress, progress)
+			for i, c := range completed {
+				require.Equal(t, uint8(i), c)
+			}
+		})
+	}
+}
+
+func TestResumeWipeServerDuringWipe(t *testing.T) {
+	t.Parallel()
+
+	job, err := model.NewWipeServerJob(uuid.New(), model.Wipe{Kind: model.WipeKindMap})
+	require.Nil(t, err)
+	job.Attempts = 1
+
+	controller := &controllerFake{live: true}
+	runner := New(zap.NewNop(), nil, controller)
+
+	err = runner.execute(context.Background(), *job, func(string, uint8) {})
+	require.Nil(t, err)
+
+	// The job is resumed as if the previous attempt applied the wipe but was
+	// interrupted before completing the wipe step.
+	job.Attempts = 2
+	job.StepsCompleted = 1
+	err = runner.execute(context.Background(), *job, func(string, uint8) {})
+	require.Nil(t, err)
+
+	require.Len(t, controller.wipes, 2)
+	require.NotEqual(t, uuid.Nil, controller.wipes[0].ID)
+	require.Equal(t, controller.wipes[0].ID, controller.wipes[1].ID)
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range model.JobKinds {
+		timeout, ok := timeouts[kind]
+		require.True(t, ok, "job kind %s has no timeout", kind)
+		require.Greater(t, timeout, time.Duration(0), "job kind %s has no timeout", kind)
+	}
+}
+
+// controllerFake is an IController that tracks whether its single server is
+// live and records the methods called.
+type controllerFake struct {
+	live      bool
+	lifecycle model.Lifecycle
+	reason    string
+
+	getErr   error
+	startErr error
+
+	calls []string
+	wipes []model.Wipe
+}
+
+func (c *controllerFake) GetServer(_ context.Context, id uuid.UUID) (interface{}, error) {
+	c.calls = append(c.calls, "GetServer")
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+
+	server := model.Server{
+		Lifecycle:       c.lifecycle,
+		LifecycleReason: c.reason,
+	}
+	if c.live {
+		return &model.LiveServer{Server: server}, nil
+	}
+	return &model.DormantServer{Server: server}, nil
+}
+
+func (c *controllerFake) CreateServer(_ context.Context, server model.Server) (*model.DormantServer, error) {
+	c.calls = append(c.calls, "CreateServer")
+	return &model.DormantServer{Server: server}, nil
+}
+
+func (c *controllerFake) StartServer(context.Context, uuid.UUID) (*model.DormantServer, error) {
+	c.calls = append(c.calls, "StartServer")
+	if c.startErr != nil {
+		return nil, c.startErr
+	}
+	return &model.DormantServer{}, nil
+}
+
+func (c *controllerFake) MakeServerLive(context.Context, uuid.UUID) (*model.LiveServer, error) {
+	c.calls = append(c.calls, "MakeServerLive")
+	c.live = true
+	return &model.LiveServer{}, nil
+}
+
+func (c *controllerFake) StopServer(context.Context, uuid.UUID) (*model.DormantServer, error) {
+	c.calls = append(c.calls, "StopServer")
+	c.live = false
+	return &model.DormantServer{}, nil
+}
+
+func (c *controllerFake) WipeServer(_ context.Context, _ uuid.UUID, wipe model.Wipe) error {
+	c.calls = append(c.calls, "WipeServer")
+	c.wipes = append(c.wipes, wipe)
+	return nil
+}
+
+func (c *controllerFake) RecoverServer(context.Context, uuid.UUID) error {
+	c.calls = append(c.calls, "RecoverServer")
+	return nil
+}
+
+func (c *controllerFake) DecommissionServer(context.Context, uuid.UUID) (*model.ArchivedServer, error) {
+	c.calls = append(c.calls, "DecommissionServer")
+	return &model.ArchivedServer{}, nil
+}
+
+func (c *controllerFake) UnarchiveServer(context.Context, uuid.UUID) (*model.DormantServer, error) {
+	c.calls = append(c.calls, "UnarchiveServer")
+	return &model.DormantServer{}, nil
+}
+
+func (c *controllerFake) RetryExecution(context.Context, uuid.UUID) (*model.Execution, error) {
+	c.calls = append(c.calls, "RetryExecution")
+	return &model.Execution{Outcome: model.ExecutionOutcomeSucceeded}, nil
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/tjper/rustcron/cmd/cronman/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultPollInterval is the default interval at which the Runner checks
+	// for jobs to run.
+	defaultPollInterval = 5 * time.Second
+
+	// defaultConcurrency is the default number of jobs a Runner runs at once.
+	defaultConcurrency = 4
+
+	// heartbeatInterval is the interval at which a Runner indicates the jobs
+	// it is running are still running.
+	heartbeatInterval = 30 * time.Second
+
+	// orphanedAfter is the duration after which a running job without a
+	// heartbeat is considered abandoned and may be claimed by another Runner.
+	orphanedAfter = 4 * heartbeatInterval
+
+	// maxAttempts is the maximum number of times a job may be claimed.
+	maxAttempts = 3
+)
+
+// IController represents the API by which the Runner runs jobs.
+type IController interface {
+	GetServer(context.Context, uuid.UUID) (interface{}, error)
+	CreateServer(context.Context, model.Server) (*model.DormantServer, error)
+	StartServer(context.Context, uuid.UUID) (*model.DormantServer, error)
+	MakeServerLive(context.Context, uuid.UUID) (*model.LiveServer, error)
+	StopServer(context.Context, uuid.UUID) (*model.DormantServer, error)
+	WipeServer(context.Context, uuid.UUID, model.Wipe) error
+	DecommissionServer(context.Context, uuid.UUID) (*model.ArchivedServer, error)
+	UnarchiveServer(context.Context, uuid.UUID) (*model.DormantServer, error)
+	RecoverServer(context.Context, uuid.UUID) error
+	RetryExecution(context.Context, uuid.UUID) (*model.Execution, error)
+}
+
+// Runner claims durable jobs and runs them through the Controller. Multiple
+// Runners may share a store, each job is run by a single Runner at a time.
+type Runner struct {
+	logger     *zap.Logger
+	store      *gorm.DB
+	controller IController
+
+	pollInterval time.Duration
+	concurrency  int
+}
+
+// Option mutates a Runner instance. Typically used with New to configure a
+// Runner instance.
+type Option func(*Runner)
+
+// WithPollInterval is an Option that configures the interval at which the
+// Runner checks for jobs to run.
+func WithPollInterval(interval time.Duration) Option {
+	return func(r *Runner) {
+		r.pollInterval = interval
+	}
+}
+
+// WithConcurrency is an Option that configures the number of jobs the Runner
+// runs at once.
+func WithConcurrency(concurrency int) Option {
+	return func(r *Runner) {
+		r.concurrency = concurrency
+	}
+}
+
+// New creates a new Runner object.
+func New(
+	logger *zap.Logger,
+	store *gorm.DB,
+	controller IController,
+	options ...Option,
+) *Runner {
+	r := &Runner{
+		logger:       logger.With(zap.String("runner-id", uuid.NewString())),
+		store:        store,
+		controller:   controller,
+		pollInterval: defaultPollInterval,
+		concurrency:  defaultConcurrency,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+	if r.concurrency < 1 {
+		r.concurrency = 1
+	}
+	return r
+}
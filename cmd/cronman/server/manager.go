@@ -12,13 +12,36 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 var (
 	errUnexpectedNumberOfInstances = errors.New("unexpected number of EC2 instances")
+
+	// ErrInstanceDNE indicates the instance does not exist.
+	ErrInstanceDNE = errors.New("instance does not exist")
+	// ErrAddressDNE indicates the address allocation does not exist.
+	ErrAddressDNE = errors.New("address does not exist")
 )
 
+// apiError is implemented by errors returned by the AWS API.
+type apiError interface {
+	ErrorCode() string
+}
+
+// hasErrorCode reports if err was returned by the AWS API with the specified
+// error code.
+func hasErrorCode(err error, code string) bool {
+	var aerr apiError
+	return errors.As(err, &aerr) && aerr.ErrorCode() == code
+}
+
+// TagServerID is the tag key under which the ID of the server an EC2 resource
+// was created for is stored. Resources without this tag were not created by
+// cronman, or were created prior to resources being tagged.
+const TagServerID = "cronman-server-id"
+
 func NewManager(
 	logger *zap.Logger,
 	ec2 *ec2.Client,
@@ -42,14 +65,20 @@ type CreateInstanceOutput struct {
 }
 
 // CreateInstance creates a Rust server based on the template provided. The
-// creation may be terminated via the ctx. Cancelling the context does not
-// necessarily terminate resources created.
+// instance and address created are tagged with the serverID. The creation may
+// be terminated via the ctx. Cancelling the context does not necessarily
+// terminate resources created.
 func (m Manager) CreateInstance(
 	ctx context.Context,
 	template model.InstanceKind,
+	serverID uuid.UUID,
 ) (*CreateInstanceOutput, error) {
 	tmpl := fmt.Sprintf("rustpm-%s", strings.ToLower(string(template)))
-	m.logger.Info("creating instance", zap.String("template", tmpl))
+	m.logger.Info(
+		"creating instance",
+		zap.String("template", tmpl),
+		zap.Stringer("server-id", serverID),
+	)
 
 	var instance types.Instance
 	{ // launch EC2 instance
@@ -59,6 +88,7 @@ func (m Manager) CreateInstance(
 			LaunchTemplate: &types.LaunchTemplateSpecification{
 				LaunchTemplateName: aws.String(tmpl),
 			},
+			TagSpecifications: serverTags(types.ResourceTypeInstance, serverID),
 		}
 
 		reservation, err := m.ec2.RunInstances(ctx, input)
@@ -90,7 +120,8 @@ func (m Manager) CreateInstance(
 	var address *ec2.AllocateAddressOutput
 	{ // allocate elastic IP address
 		input := &ec2.AllocateAddressInput{
-			Domain: types.DomainType("vpc"),
+			Domain:            types.DomainType("vpc"),
+			TagSpecifications: serverTags(types.ResourceTypeElasticIp, serverID),
 		}
 
 		addr, err := m.ec2.AllocateAddress(ctx, input)
@@ -252,21 +283,167 @@ func (m Manager) TerminateInstance(
 	instanceID string,
 	allocationID string,
 ) error {
-	{ // terminate instance
-		input := &ec2.TerminateInstancesInput{
-			InstanceIds: []string{instanceID},
+	if err := m.DeleteInstance(ctx, instanceID); err != nil {
+		return err
+	}
+	return m.ReleaseAddress(ctx, allocationID)
+}
+
+// DeleteInstance permanently deletes the instance. If the instance does not
+// exist, an error wrapping ErrInstanceDNE is returned.
+func (m Manager) DeleteInstance(ctx context.Context, instanceID string) error {
+	m.logger.Info("deleting instance", zap.String("instance-id", instanceID))
+
+	input := &ec2.TerminateInstancesInput{
+		InstanceIds: []string{instanceID},
+	}
+	_, err := m.ec2.TerminateInstances(ctx, input)
+	if hasErrorCode(err, "InvalidInstanceID.NotFound") {
+		return fmt.Errorf("terminate instances; id: %s, error: %w", instanceID, ErrInstanceDNE)
+	}
+	if err != nil {
+		return fmt.Errorf("terminate instances; id: %s, error: %w", instanceID, err)
+	}
+	return nil
+}
+
+// ReleaseAddress releases the address allocation. The address must not be
+// associated with an instance. If the allocation does not exist, an error
+// wrapping ErrAddressDNE is returned.
+func (m Manager) ReleaseAddress(ctx context.Context, allocationID string) error {
+	m.logger.Info("releasing address", zap.String("allocation-id", allocationID))
+
+	input := &ec2.ReleaseAddressInput{
+		AllocationId: aws.String(allocationID),
+	}
+	_, err := m.ec2.ReleaseAddress(ctx, input)
+	if hasErrorCode(err, "InvalidAllocationID.NotFound") {
+		return fmt.Errorf("release address; id: %s, error: %w", allocationID, ErrAddressDNE)
+	}
+	if err != nil {
+		return fmt.Errorf("release address; id: %s, error: %w", allocationID, err)
+	}
+	return nil
+}
+
+// Instance is an EC2 instance created by cronman.
+type Instance struct {
+	ID         string
+	ServerID   uuid.UUID
+	State      string
+	LaunchTime time.Time
+}
+
+// ListInstances lists the instances tagged with a server ID. Terminated
+// instances are not included.
+func (m Manager) ListInstances(ctx context.Context) ([]Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("tag-key"), Values: []string{TagServerID}},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{"pending", "running", "stopping", "stopped"},
+			},
+		},
+	}
+
+	instances := make([]Instance, 0)
+	paginator := ec2.NewDescribeInstancesPaginator(m.ec2, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error describing EC2 instances; %w", err)
 		}
-		if _, err := m.ec2.TerminateInstances(ctx, input); err != nil {
-			return fmt.Errorf("terminate instances; id: %s, error: %w", instanceID, err)
+
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				serverID, ok := serverIDTag(instance.Tags)
+				if !ok {
+					continue
+				}
+
+				listed := Instance{
+					ID:       aws.ToString(instance.InstanceId),
+					ServerID: serverID,
+				}
+				if instance.State != nil {
+					listed.State = string(instance.State.Name)
+				}
+				if instance.LaunchTime != nil {
+					listed.LaunchTime = *instance.LaunchTime
+				}
+				instances = append(instances, listed)
+			}
 		}
 	}
-	{ // release address allocation
-		input := &ec2.ReleaseAddressInput{
-			AllocationId: aws.String(allocationID),
+	return instances, nil
+}
+
+// Address is an Elastic IP address allocated by cronman.
+type Address struct {
+	AllocationID  string
+	AssociationID string
+	InstanceID    string
+	PublicIP      string
+	ServerID      uuid.UUID
+}
+
+// ListAddresses lists the addresses tagged with a server ID.
+func (m Manager) ListAddresses(ctx context.Context) ([]Address, error) {
+	input := &ec2.DescribeAddressesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("tag-key"), Values: []string{TagServerID}},
+		},
+	}
+
+	output, err := m.ec2.DescribeAddresses(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("error describing elastic IP addresses; %w", err)
+	}
+
+	addresses := make([]Address, 0, len(output.Addresses))
+	for _, address := range output.Addresses {
+		serverID, ok := serverIDTag(address.Tags)
+		if !ok {
+			continue
 		}
-		if _, err := m.ec2.ReleaseAddress(ctx, input); err != nil {
-			return fmt.Errorf("release address; id: %s, error: %w", allocationID, err)
+
+		addresses = append(addresses, Address{
+			AllocationID:  aws.ToString(address.AllocationId),
+			AssociationID: aws.ToString(address.AssociationId),
+			InstanceID:    aws.ToString(address.InstanceId),
+			PublicIP:      aws.ToString(address.PublicIp),
+			ServerID:      serverID,
+		})
+	}
+	return addresses, nil
+}
+
+// serverTags creates the tag specification that tags a resource of the
+// specified type with the serverID.
+func serverTags(resource types.ResourceType, serverID uuid.UUID) []types.TagSpecification {
+	return []types.TagSpecification{
+		{
+			ResourceType: resource,
+			Tags: []types.Tag{
+				{Key: aws.String(TagServerID), Value: aws.String(serverID.String())},
+			},
+		},
+	}
+}
+
+// serverIDTag retrieves the server ID from the tags. If the tags do not
+// contain a valid server ID, false is returned.
+func serverIDTag(tags []types.Tag) (uuid.UUID, bool) {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) != TagServerID {
+			continue
 		}
+		id, err := uuid.Parse(aws.ToString(tag.Value))
+		if err != nil {
+			return uuid.Nil, false
+		}
+		return id, true
 	}
-	return nil
+	return uuid.Nil, false
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -24,7 +25,7 @@ func TestIntegration(t *testing.T) {
 
 	var createInstanceOutput CreateInstanceOutput
 	t.Run("create instance", func(t *testing.T) {
-		out, err := suite.manager.CreateInstance(ctx, model.InstanceKindStandard)
+		out, err := suite.manager.CreateInstance(ctx, model.InstanceKindStandard, uuid.New())
 		require.Nil(t, err)
 		createInstanceOutput = *out
 	})
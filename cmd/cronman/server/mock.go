@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/tjper/rustcron/cmd/cronman/model"
+
+	"github.com/google/uuid"
 )
 
 // NewMockManager creates a MockManager instance.
@@ -14,25 +16,29 @@ func NewMockManager() *MockManager {
 // MockManager provides methods to mock interactions with cronman servers. This
 // is typically used in testing to avoid interacting with AWS.
 type MockManager struct {
-	createInstanceHandler          func(context.Context, model.InstanceKind) (*CreateInstanceOutput, error)
+	createInstanceHandler          func(context.Context, model.InstanceKind, uuid.UUID) (*CreateInstanceOutput, error)
 	makeInstanceAvailableHandler   func(context.Context, string, string) (*AssociationOutput, error)
 	makeInstanceUnavailableHandler func(context.Context, string) error
 	startInstanceHandler           func(context.Context, string, string) error
 	stopInstanceHandler            func(context.Context, string) error
+	deleteInstanceHandler          func(context.Context, string) error
+	releaseAddressHandler          func(context.Context, string) error
+	listInstancesHandler           func(context.Context) ([]Instance, error)
+	listAddressesHandler           func(context.Context) ([]Address, error)
 }
 
 // SetCreateInstanceHandler sets the handler of the CreateInstance method to
 // the passed function.
-func (m *MockManager) SetCreateInstanceHandler(handler func(context.Context, model.InstanceKind) (*CreateInstanceOutput, error)) {
+func (m *MockManager) SetCreateInstanceHandler(handler func(context.Context, model.InstanceKind, uuid.UUID) (*CreateInstanceOutput, error)) {
 	m.createInstanceHandler = handler
 }
 
 // CreateInstance mocks the creation of a cronman server instance.
-func (m MockManager) CreateInstance(ctx context.Context, kind model.InstanceKind) (*CreateInstanceOutput, error) {
+func (m MockManager) CreateInstance(ctx context.Context, kind model.InstanceKind, serverID uuid.UUID) (*CreateInstanceOutput, error) {
 	if m.createInstanceHandler == nil {
 		return &CreateInstanceOutput{}, nil
 	}
-	return m.createInstanceHandler(ctx, kind)
+	return m.createInstanceHandler(ctx, kind, serverID)
 }
 
 // SetStartInstanceHandler sets the handler of the StartInstance method to the
@@ -91,3 +97,59 @@ func (m MockManager) MakeInstanceUnavailable(ctx context.Context, id string) err
 	}
 	return m.makeInstanceUnavailableHandler(ctx, id)
 }
+
+// SetDeleteInstanceHandler sets the handler of the DeleteInstance method to
+// the passed function.
+func (m *MockManager) SetDeleteInstanceHandler(handler func(context.Context, string) error) {
+	m.deleteInstanceHandler = handler
+}
+
+// DeleteInstance mocks the deletion of a cronman server instance.
+func (m MockManager) DeleteInstance(ctx context.Context, id string) error {
+	if m.deleteInstanceHandler == nil {
+		return nil
+	}
+	return m.deleteInstanceHandler(ctx, id)
+}
+
+// SetReleaseAddressHandler sets the handler of the ReleaseAddress method to
+// the passed function.
+func (m *MockManager) SetReleaseAddressHandler(handler func(context.Context, string) error) {
+	m.releaseAddressHandler = handler
+}
+
+// ReleaseAddress mocks the release of a cronman server address.
+func (m MockManager) ReleaseAddress(ctx context.Context, allocationID string) error {
+	if m.releaseAddressHandler == nil {
+		return nil
+	}
+	return m.releaseAddressHandler(ctx, allocationID)
+}
+
+// SetListInstancesHandler sets the handler of the ListInstances method to the
+// passed function.
+func (m *MockManager) SetListInstancesHandler(handler func(context.Context) ([]Instance, error)) {
+	m.listInstancesHandler = handler
+}
+
+// ListInstances mocks the listing of cronman server instances.
+func (m MockManager) ListInstances(ctx context.Context) ([]Instance, error) {
+	if m.listInstancesHandler == nil {
+		return []Instance{}, nil
+	}
+	return m.listInstancesHandler(ctx)
+}
+
+// SetListAddressesHandler sets the handler of the ListAddresses method to the
+// passed function.
+func (m *MockManager) SetListAddressesHandler(handler func(context.Context) ([]Address, error)) {
+	m.listAddressesHandler = handler
+}
+
+// ListAddresses mocks the listing of cronman server addresses.
+func (m MockManager) ListAddresses(ctx context.Context) ([]Address, error) {
+	if m.listAddressesHandler == nil {
+		return []Address{}, nil
+	}
+	return m.listAddressesHandler(ctx)
+}
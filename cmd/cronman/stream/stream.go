@@ -124,12 +124,15 @@ func (h Handler) handleVipRefreshEvent(ctx context.Context, event *event.VipRefr
 	}
 
 	// Vip with the specified server ID and steam was found. Just update its
-	// ExpiresAt field.
+	// ExpiresAt field, a revoked Vip is no longer revoked.
 	if err == nil {
 		if err := h.store.
 			WithContext(ctx).
 			Model(vip).
-			Update("expires_at", event.ExpiresAt).Error; err != nil {
+			Updates(map[string]interface{}{
+				"expires_at": event.ExpiresAt,
+				"revoked_at": nil,
+			}).Error; err != nil {
 			return fmt.Errorf("while updating vip: %w", err)
 		}
 	}
@@ -25,23 +25,26 @@ done
 su -c "cat <<EOT > /home/rustserver/server/%s/cfg/users.cfg
 %s
 EOT" -  rustserver
+`
+	// NOTE: The bans.cfg file is processed on each launch of the rust server.
+	// Bans removed and or added to bans.cfg will be added and removed from the
+	// server, no other operations are necessary.
+	bansCfgTemplate = `
+su -c "cat <<EOT > /home/rustserver/server/%s/cfg/bans.cfg
+%s
+EOT" -  rustserver
 `
 	// NOTE: The server.cfg is processed on each launch of the rust server. The
 	// settings it modifies may persist between server starts, therefore it is
 	// critical to remove and initialize all configuration to ensure the server
 	// is operating predictably.
 	//
-	// NOTE: This script assumes that the following oxide plugins are installed:
-	// bypassqueue, adminradar, and vanish.
+	// NOTE: Plugin permission grants are written after the vip group is
+	// recreated, as plugins may grant permissions to the vip group.
 	serverCfgTemplate = `
 su -c "cat <<EOT > /home/rustserver/server/%s/cfg/server.cfg
-oxide.grant group admin adminradar.allowed
-oxide.grant group admin adminradar.bypass
-oxide.grant group admin vanish.allow
-
 oxide.group remove vip
 oxide.group add vip
-oxide.grant group vip bypassqueue.allow
 %s
 EOT" -  rustserver
 `
@@ -220,16 +223,15 @@ su -c "curl --output Oxide.Rust-linux.zip -L https://github.com/OxideMod/Oxide.R
 su -c "unzip -o -d /home/rustserver/ Oxide.Rust-linux.zip" - rustserver
 `
 
-	installBypassQueuePluginScript = `
-su -c "curl https://umod.org/plugins/BypassQueue.cs --output /home/rustserver/oxide/plugins/BypassQueue.cs --create-dirs" - rustserver
-`
-
-	installVanishPluginScript = `
-su -c "curl https://umod.org/plugins/Vanish.cs --output /home/rustserver/oxide/plugins/Vanish.cs --create-dirs" - rustserver
+	// NOTE: Plugins are removed prior to installing the enabled plugins. Oxide
+	// loads every plugin in the plugins directory, so plugins disabled since
+	// the last launch must not remain.
+	removePluginsScript = `
+su -c "mkdir -p /home/rustserver/oxide/plugins && find /home/rustserver/oxide/plugins -name '*.cs' -delete" - rustserver
 `
 
-	installAdminRadarPluginScript = `
-su -c "curl https://umod.org/plugins/AdminRadar.cs --output /home/rustserver/oxide/plugins/AdminRadar.cs --create-dirs" - rustserver
+	installPluginScript = `
+su -c "curl %s --output /home/rustserver/oxide/plugins/%s.cs --create-dirs" - rustserver
 `
 )
 
@@ -319,26 +321,30 @@ func WithMapWipe(identity string) Option {
 	}
 }
 
-// WithQueueBypassPlugin returns an Option that enables the queue bypass oxide
-// plugin.
-func WithQueueBypassPlugin() Option {
-	return func() string {
-		return installBypassQueuePluginScript
-	}
+// Plugin is an Oxide plugin to be installed on the server, and the
+// permissions it grants.
+type Plugin struct {
+	// Name is the plugin's name, this is also the name of the plugin's file
+	// without the .cs extension.
+	Name string
+	// URL is the location the plugin's source file is downloaded from. The
+	// source file is not pinned, the URL must identify the version desired.
+	URL string
+	// Grants are Oxide permission grants, each of the form
+	// "group <group> <permission>".
+	Grants []string
 }
 
-// WithVanishPlugin returns an Option that enables the vanish oxide plugin.
-func WithVanishPlugin() Option {
+// WithPlugins returns an Option that installs the passed Oxide plugins. Any
+// plugins that are installed but not passed are removed.
+func WithPlugins(plugins []Plugin) Option {
 	return func() string {
-		return installVanishPluginScript
-	}
-}
-
-// WithAdminRadarPlugin returns an Option that enables the admin radar oxide
-// plugin.
-func WithAdminRadarPlugin() Option {
-	return func() string {
-		return installAdminRadarPluginScript
+		var s strings.Builder
+		s.WriteString(removePluginsScript)
+		for _, plugin := range plugins {
+			fmt.Fprintf(&s, installPluginScript, plugin.URL, plugin.Name)
+		}
+		return s.String()
 	}
 }
 
@@ -366,9 +372,15 @@ func WithUserCfg(identity string, ownerIDs, moderatorIDs []string) Option {
 }
 
 // WithServerCfg returns an Option that configures the userdata to create a
-// server config.
-func WithServerCfg(identity string, steamIDs []string) Option {
+// server config. The server config grants the permissions of the passed
+// plugins and adds the passed steam IDs to the vip group.
+func WithServerCfg(identity string, steamIDs []string, plugins []Plugin) Option {
 	cmds := make([]string, 0, len(steamIDs))
+	for _, plugin := range plugins {
+		for _, grant := range plugin.Grants {
+			cmds = append(cmds, fmt.Sprintf("oxide.grant %s", grant))
+		}
+	}
 	for _, id := range steamIDs {
 		cmds = append(cmds, fmt.Sprintf("oxide.usergroup add %s vip", id))
 	}
@@ -376,3 +388,29 @@ func WithServerCfg(identity string, steamIDs []string) Option {
 		return fmt.Sprintf(serverCfgTemplate, identity, strings.Join(cmds, "\n"))
 	}
 }
+
+// Ban is a steam ID banned from the server, and the reason for the ban.
+type Ban struct {
+	SteamID string
+	Reason  string
+}
+
+// WithBansCfg returns an Option that configures the userdata to create a bans
+// config.
+func WithBansCfg(identity string, bans []Ban) Option {
+	cmds := make([]string, 0, len(bans))
+	for _, ban := range bans {
+		cmds = append(cmds, fmt.Sprintf("banid %s \\\"unnamed\\\" \\\"%s\\\"", ban.SteamID, sanitize(ban.Reason)))
+	}
+	return func() string {
+		return fmt.Sprintf(bansCfgTemplate, identity, strings.Join(cmds, "\n"))
+	}
+}
+
+// sanitizer removes characters from free-form text that would otherwise be
+// interpreted by the shell or break a quoted config argument.
+var sanitizer = strings.NewReplacer("\"", "'", "\\", "", "$", "", "`", "")
+
+func sanitize(s string) string {
+	return sanitizer.Replace(s)
+}
@@ -11,6 +11,24 @@ import (
 var golden = flag.Bool("golden", false, "enable golden tests to overwrite .golden files")
 
 func TestGenerate(t *testing.T) {
+	plugins := []Plugin{
+		{
+			Name:   "BypassQueue",
+			URL:    "https://umod.org/plugins/BypassQueue.cs",
+			Grants: []string{"group vip bypassqueue.allow"},
+		},
+		{
+			Name:   "AdminRadar",
+			URL:    "https://umod.org/plugins/AdminRadar.cs",
+			Grants: []string{"group admin adminradar.allowed", "group admin adminradar.bypass"},
+		},
+		{
+			Name:   "Vanish",
+			URL:    "https://umod.org/plugins/Vanish.cs",
+			Grants: []string{"group admin vanish.allow"},
+		},
+	}
+
 	tests := map[string]struct {
 		ip           string
 		identity     string
@@ -119,11 +137,7 @@ func TestGenerate(t *testing.T) {
 			bannerURL:    "https://s3.amazonaws.com/rustpm.public.assets/banner.png",
 			description:  "Rustpm US East Main | Test Description",
 			optionsFlags: map[string]interface{}{},
-			opts: []Option{
-				WithQueueBypassPlugin(),
-				WithAdminRadarPlugin(),
-				WithVanishPlugin(),
-			},
+			opts:         []Option{WithPlugins(plugins)},
 		},
 		"usercfg": {
 			ip:           "east-main.rustpm.com",
@@ -158,7 +172,29 @@ func TestGenerate(t *testing.T) {
 			bannerURL:    "https://s3.amazonaws.com/rustpm.public.assets/banner.png",
 			description:  "Rustpm US East Main | Test Description",
 			optionsFlags: map[string]interface{}{},
-			opts:         []Option{WithServerCfg("Rustpm East Main", []string{"user1", "user2", "user3"})},
+			opts:         []Option{WithServerCfg("Rustpm East Main", []string{"user1", "user2", "user3"}, plugins)},
+		},
+		"banscfg": {
+			ip:           "east-main.rustpm.com",
+			identity:     "Rustpm East Main",
+			hostName:     "rustpm-east-1",
+			rconPassword: "rustpm-rconpassword",
+			maxPlayers:   100,
+			worldSize:    2000,
+			seed:         123,
+			salt:         321,
+			tickRate:     30,
+			bannerURL:    "https://s3.amazonaws.com/rustpm.public.assets/banner.png",
+			description:  "Rustpm US East Main | Test Description",
+			opts: []Option{
+				WithBansCfg(
+					"Rustpm East Main",
+					[]Ban{
+						{SteamID: "76561197962911631", Reason: "cheating"},
+						{SteamID: "76561197962911632", Reason: "said \"$HOME\" in chat"},
+					},
+				),
+			},
 		},
 		"cloudwatch agent": {
 			ip:           "east-main.rustpm.com",
@@ -33,6 +33,8 @@ func Parse(b []byte) (interface{}, error) {
 		event = &StripeWebhookEvent{}
 	case VipRefresh:
 		event = &VipRefreshEvent{}
+	case VipExpired:
+		event = &VipExpiredEvent{}
 	case ServerStatusChange:
 		event = &ServerStatusChangeEvent{}
 	default:
@@ -51,6 +53,7 @@ type Kind string
 const (
 	StripeWebhook      Kind = "stripe_webhook"
 	VipRefresh         Kind = "vip_refresh"
+	VipExpired         Kind = "vip_expired"
 	ServerStatusChange Kind = "server_status_change"
 )
 
@@ -108,6 +111,29 @@ func NewVipRefreshEvent(
 	}
 }
 
+// VipExpiredEvent is fired when a VIP within the Rustpm system has expired,
+// and its privileges have been revoked from the server.
+type VipExpiredEvent struct {
+	Event
+	ServerID  uuid.UUID `json:"serverId"`
+	SteamID   string    `json:"steamId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewVipExpiredEvent creates a new VipExpiredEvent instance.
+func NewVipExpiredEvent(
+	serverID uuid.UUID,
+	steamID string,
+	expiresAt time.Time,
+) VipExpiredEvent {
+	return VipExpiredEvent{
+		Event:     New(VipExpired),
+		ServerID:  serverID,
+		SteamID:   steamID,
+		ExpiresAt: expiresAt,
+	}
+}
+
 type ServerStatus string
 
 const (
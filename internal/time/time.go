@@ -3,7 +3,10 @@
 // the usage of time-related data-types mockable.
 package time
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // Time wraps time-related functionality from the standard library to enable
 // mocking in tests.
@@ -19,6 +22,11 @@ func (t Time) Until(when time.Time) time.Duration {
 	return time.Until(when)
 }
 
+// After wraps time.After.
+func (t Time) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
 // NewMock initializes a new Mock instance.
 func NewMock(now time.Time) *Mock {
 	return &Mock{now: now}
@@ -26,21 +34,40 @@ func NewMock(now time.Time) *Mock {
 
 // Mock may be used to mock the functionality provided by Time.
 type Mock struct {
+	mutex sync.Mutex
 	now   time.Time
 	until time.Duration
 }
 
 // Now retrieves the mocked time.Now value.
-func (m Mock) Now() time.Time {
+func (m *Mock) Now() time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	return m.now
 }
 
 // SetUntil sets the value to be returned by Mock.Until.
 func (m *Mock) SetUntil(until time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	m.until = until
 }
 
 // Until mocks Time.Until by returning the value set by SetUntil.
-func (m Mock) Until(_ time.Time) time.Duration {
+func (m *Mock) Until(_ time.Time) time.Duration {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	return m.until
 }
+
+// After mocks Time.After. Rather than waiting, the mocked time.Now value is
+// advanced by d and the returned channel is immediately ready.
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.now = m.now.Add(d)
+	c := make(chan time.Time, 1)
+	c <- m.now
+	return c
+}